@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ParseAbiArguments converts command line arguments into the Go values
+// abi.Pack expects for the given inputs. Scalars are given as plain text;
+// arrays and tuples as JSON, e.g. '[1,2]' or '["0xabc...", 5]'.
+func ParseAbiArguments(inputs abi.Arguments, args []string) ([]interface{}, error) {
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("expected %d arguments (%s) but got %d", len(inputs), describeAbiArguments(inputs), len(args))
+	}
+
+	values := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		value, err := ParseAbiArgument(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s %s): %w", i, input.Type.String(), input.Name, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// ParseAbiArgument converts a single command line argument.
+func ParseAbiArgument(abiType abi.Type, arg string) (interface{}, error) {
+	var raw interface{} = arg
+	if abiType.T == abi.SliceTy || abiType.T == abi.ArrayTy || abiType.T == abi.TupleTy {
+		decoder := json.NewDecoder(strings.NewReader(arg))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("expected JSON for %s: %w", abiType.String(), err)
+		}
+	}
+
+	value, err := convertAbiValue(abiType, raw)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+func convertAbiValue(abiType abi.Type, raw interface{}) (reflect.Value, error) {
+	goType := abiType.GetType()
+
+	switch abiType.T {
+	case abi.IntTy, abi.UintTy:
+		number, err := parseAbiInteger(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if abiType.T == abi.UintTy && number.Sign() < 0 {
+			return reflect.Value{}, fmt.Errorf("%s cannot be negative", abiType.String())
+		}
+		if abiType.T == abi.UintTy && number.BitLen() > abiType.Size {
+			return reflect.Value{}, fmt.Errorf("%v overflows %s", number, abiType.String())
+		}
+		if abiType.T == abi.IntTy {
+			// two's complement leaves one bit for the sign, so intN holds
+			// -2^(N-1) up to 2^(N-1)-1
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(abiType.Size-1))
+			if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return reflect.Value{}, fmt.Errorf("%v overflows %s", number, abiType.String())
+			}
+		}
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(number), nil
+		}
+		value := reflect.New(goType).Elem()
+		if abiType.T == abi.IntTy {
+			value.SetInt(number.Int64())
+		} else {
+			value.SetUint(number.Uint64())
+		}
+		return value, nil
+
+	case abi.BoolTy:
+		text := fmt.Sprint(raw)
+		boolean, err := strconv.ParseBool(text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bool: %s", text)
+		}
+		return reflect.ValueOf(boolean), nil
+
+	case abi.StringTy:
+		text, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a string but got %v", raw)
+		}
+		return reflect.ValueOf(text), nil
+
+	case abi.AddressTy:
+		text := fmt.Sprint(raw)
+		if !common.IsHexAddress(text) {
+			return reflect.Value{}, fmt.Errorf("invalid address: %s", text)
+		}
+		return reflect.ValueOf(common.HexToAddress(text)), nil
+
+	case abi.BytesTy:
+		decoded, err := hexutil.Decode(fmt.Sprint(raw))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid hex bytes: %v", raw)
+		}
+		return reflect.ValueOf(decoded), nil
+
+	case abi.FixedBytesTy:
+		decoded, err := hexutil.Decode(fmt.Sprint(raw))
+		if err != nil || len(decoded) > abiType.Size {
+			return reflect.Value{}, fmt.Errorf("invalid %s: %v", abiType.String(), raw)
+		}
+		value := reflect.New(goType).Elem()
+		reflect.Copy(value, reflect.ValueOf(common.RightPadBytes(decoded, abiType.Size)))
+		return value, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		elements, ok := raw.([]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a JSON array for %s", abiType.String())
+		}
+		if abiType.T == abi.ArrayTy && len(elements) != abiType.Size {
+			return reflect.Value{}, fmt.Errorf("%s needs exactly %d elements", abiType.String(), abiType.Size)
+		}
+
+		var value reflect.Value
+		if abiType.T == abi.SliceTy {
+			value = reflect.MakeSlice(goType, len(elements), len(elements))
+		} else {
+			value = reflect.New(goType).Elem()
+		}
+		for i, element := range elements {
+			converted, err := convertAbiValue(*abiType.Elem, element)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			value.Index(i).Set(converted)
+		}
+		return value, nil
+
+	case abi.TupleTy:
+		value := reflect.New(goType).Elem()
+		for i, element := range abiType.TupleElems {
+			var field interface{}
+			switch fields := raw.(type) {
+			case []interface{}:
+				if len(fields) != len(abiType.TupleElems) {
+					return reflect.Value{}, fmt.Errorf("tuple needs exactly %d fields", len(abiType.TupleElems))
+				}
+				field = fields[i]
+			case map[string]interface{}:
+				found, ok := fields[abiType.TupleRawNames[i]]
+				if !ok {
+					return reflect.Value{}, fmt.Errorf("tuple field %s is missing", abiType.TupleRawNames[i])
+				}
+				field = found
+			default:
+				return reflect.Value{}, fmt.Errorf("expected a JSON array or object for %s", abiType.String())
+			}
+
+			converted, err := convertAbiValue(*element, field)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", abiType.TupleRawNames[i], err)
+			}
+			value.Field(i).Set(converted)
+		}
+		return value, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported abi type %s", abiType.String())
+}
+
+// parseAbiInteger accepts decimal and 0x prefixed hex integers. A leading
+// zero is still decimal and separators are refused, so that an amount is
+// never read in another base than the one it was typed in.
+func parseAbiInteger(raw interface{}) (*big.Int, error) {
+	text := strings.TrimSpace(fmt.Sprint(raw))
+
+	digits := strings.TrimPrefix(text, "-")
+	negative := digits != text
+	base := 10
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits, base = digits[2:], 16
+	}
+	number, ok := new(big.Int).SetString(digits, base)
+	if !ok || digits == "" || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid integer: %s", text)
+	}
+	if negative {
+		number.Neg(number)
+	}
+	return number, nil
+}
+
+// FormatAbiValue renders a decoded value for display: byte values as hex,
+// integers in decimal and tuples with their field names.
+func FormatAbiValue(value interface{}) string {
+	return formatAbiValue(reflect.ValueOf(value))
+}
+
+func formatAbiValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+
+	switch typed := value.Interface().(type) {
+	case *big.Int:
+		return typed.String()
+	case common.Address:
+		return typed.Hex()
+	case common.Hash:
+		return typed.Hex()
+	case []byte:
+		return hexutil.Encode(typed)
+	}
+
+	switch value.Kind() {
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(bytes), value)
+			return hexutil.Encode(bytes)
+		}
+		fallthrough
+	case reflect.Slice:
+		elements := make([]string, value.Len())
+		for i := range elements {
+			elements[i] = formatAbiValue(value.Index(i))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, value.NumField())
+		for i := range fields {
+			fields[i] = value.Type().Field(i).Name + ": " + formatAbiValue(value.Field(i))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			fields[i] = key.String() + ": " + formatAbiValue(value.MapIndex(key))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return "<nil>"
+		}
+		return formatAbiValue(value.Elem())
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+func describeAbiArguments(arguments abi.Arguments) string {
+	var description bytes.Buffer
+	for i, argument := range arguments {
+		if i > 0 {
+			description.WriteString(", ")
+		}
+		description.WriteString(argument.Type.String())
+		if argument.Name != "" {
+			description.WriteString(" " + argument.Name)
+		}
+	}
+	return description.String()
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+func TestParseAbiArgumentIntegerBounds(t *testing.T) {
+	tests := []struct {
+		abiType string
+		arg     string
+		valid   bool
+	}{
+		{"int8", "127", true},
+		{"int8", "128", false},
+		{"int8", "200", false},
+		{"int8", "-128", true},
+		{"int8", "-129", false},
+		{"int16", "32767", true},
+		{"int16", "-32768", true},
+		{"int16", "32768", false},
+		{"int64", "9223372036854775807", true},
+		{"int64", "9223372036854775808", false},
+		{"int64", "-9223372036854775808", true},
+		{"int64", "-9223372036854775809", false},
+		{"int24", "8388607", true},
+		{"int24", "8388608", false},
+		{"int24", "-8388608", true},
+		{"int256", "57896044618658097711785492504343953926634992332820282019728792003956564819967", true},
+		{"int256", "57896044618658097711785492504343953926634992332820282019728792003956564819968", false},
+		{"int256", "-57896044618658097711785492504343953926634992332820282019728792003956564819968", true},
+		{"int256", "-57896044618658097711785492504343953926634992332820282019728792003956564819969", false},
+		{"int256", "0x8000000000000000000000000000000000000000000000000000000000000000", false},
+		{"uint8", "255", true},
+		{"uint8", "256", false},
+		{"uint8", "-1", false},
+		{"uint64", "18446744073709551615", true},
+		{"uint64", "18446744073709551616", false},
+		{"uint256", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", true},
+		{"uint256", "0x10000000000000000000000000000000000000000000000000000000000000000", false},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.abiType, test.arg), func(t *testing.T) {
+			abiType, err := abi.NewType(test.abiType, "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			value, err := ParseAbiArgument(abiType, test.arg)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("accepted %s as %s", test.arg, test.abiType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// an accepted value packs and decodes back unchanged
+			arguments := abi.Arguments{{Type: abiType}}
+			packed, err := arguments.Pack(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := arguments.Unpack(packed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := new(big.Int).SetString(test.arg, 0)
+			if got := FormatAbiValue(decoded[0]); got != want.String() {
+				t.Fatalf("%s round tripped as %s, want %s", test.arg, got, want)
+			}
+		})
+	}
+}
+
+func TestParseAbiArgumentIntegerBases(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"1000", "1000"},
+		{"010", "10"},
+		{"0x10", "16"},
+		{"0X1f", "31"},
+		{"-0x10", "-16"},
+		{"1_000", ""},
+		{"0x1_0", ""},
+		{"0o17", ""},
+		{"0b101", ""},
+		{"0x", ""},
+		{"--1", ""},
+		{"-+1", ""},
+		{"1e3", ""},
+	}
+	uint256, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	int256, err := abi.NewType("int256", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		abiType := uint256
+		if test.want != "" && test.want[0] == '-' {
+			abiType = int256
+		}
+		value, err := ParseAbiArgument(abiType, test.arg)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("accepted %s as %v", test.arg, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.arg, err)
+			continue
+		}
+		if got := FormatAbiValue(value); got != test.want {
+			t.Errorf("%s parsed as %s, want %s", test.arg, got, test.want)
+		}
+	}
+}
@@ -6,23 +6,44 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/chenzhijie/go-web3"
 	"github.com/chenzhijie/go-web3/eth"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const GoerliChainId = int64(5)
 
+// buildTarget is a contract under ./contracts and the Go package its
+// binding is generated into.
+type buildTarget struct {
+	Name    string
+	Package string
+}
+
+var buildTargets = []buildTarget{
+	{Name: "Lottery", Package: "lottery"},
+	{Name: "FredCoin", Package: "token"},
+	{Name: "LotteryFactory", Package: "factory"},
+	{Name: "LotteryProxy", Package: "proxy"},
+	{Name: "Multicall3", Package: "multicall"},
+}
+
 func buildAndBindContractCommand() *cobra.Command {
 	return &cobra.Command{
 		Use: "build",
 		Run: func(cmd *cobra.Command, args []string) {
-			log.Println("building api...")
-			buildAbi()
-			log.Println("building binary...")
-			buildBinary()
-			log.Println("generating go client code...")
-			buildGoContractClient()
+			for _, target := range buildTargets {
+				log.Println("building ", target.Name, " api...")
+				buildAbi(target)
+				log.Println("building ", target.Name, " binary...")
+				buildBinary(target)
+				log.Println("building ", target.Name, " storage layout...")
+				buildStorageLayout(target)
+				log.Println("generating ", target.Name, " go client code...")
+				buildGoContractClient(target)
+			}
 			log.Println("testing contract binding with goerli chain...")
 			manager, _ := GetContractManagerAddress()
 			log.Println("contract owner address: ", manager)
@@ -43,7 +64,7 @@ func GetContractManagerAddress() (interface{}, error) {
 }
 
 func getContract() (*eth.Contract, error) {
-	var rpcProviderURL = os.Getenv("NODE_ENDPOINT")
+	var rpcProviderURL = getNodeEndpoints()[0]
 	web3Client, err := web3.NewWeb3(rpcProviderURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to provision web3 client: %w", err)
@@ -75,13 +96,27 @@ func getContractAbi() string {
 	return abiFileContentString
 }
 
-func buildGoContractClient() {
+// getContractBinary reads the deployable bytecode solc wrote for a contract.
+func getContractBinary(name string) ([]byte, error) {
+	binary, err := os.ReadFile(fmt.Sprintf("./build/%s.bin", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s binary: %w", name, err)
+	}
+	bytecode := common.FromHex(strings.TrimSpace(string(binary)))
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("no %s binary found, run the build command first", name)
+	}
+	return bytecode, nil
+}
+
+func buildGoContractClient(target buildTarget) {
 	command := exec.Command(
 		"abigen",
-		"--abi=./build/Lottery.abi",
-		"--bin=./build/Lottery.bin",
-		"--pkg=lottery",
-		"--out=./lottery/Lottery.go")
+		fmt.Sprintf("--abi=./build/%s.abi", target.Name),
+		fmt.Sprintf("--bin=./build/%s.bin", target.Name),
+		fmt.Sprintf("--pkg=%s", target.Package),
+		fmt.Sprintf("--type=%s", target.Name),
+		fmt.Sprintf("--out=./%s/%s.go", target.Package, target.Name))
 
 	_, err := command.Output()
 	if err != nil {
@@ -89,13 +124,31 @@ func buildGoContractClient() {
 	}
 }
 
-func buildBinary() {
+func buildBinary(target buildTarget) {
 	command := exec.Command(
 		"solc",
 		"--optimize",
 		"--bin",
 		"--overwrite",
-		"./contracts/Lottery.sol",
+		fmt.Sprintf("./contracts/%s.sol", target.Name),
+		"-o",
+		"build")
+
+	_, err := command.Output()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// buildStorageLayout writes build/<Name>_storage.json, which the upgrade
+// command uses to refuse upgrades that would corrupt proxy storage.
+func buildStorageLayout(target buildTarget) {
+	command := exec.Command(
+		"solc",
+		"--optimize",
+		"--storage-layout",
+		"--overwrite",
+		fmt.Sprintf("./contracts/%s.sol", target.Name),
 		"-o",
 		"build")
 
@@ -105,13 +158,13 @@ func buildBinary() {
 	}
 }
 
-func buildAbi() {
+func buildAbi(target buildTarget) {
 	command := exec.Command(
 		"solc",
 		"--optimize",
 		"--abi",
 		"--overwrite",
-		"./contracts/lottery.sol",
+		fmt.Sprintf("./contracts/%s.sol", target.Name),
 		"-o",
 		"build")
 
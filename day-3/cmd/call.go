@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func callCommand() *cobra.Command {
+	var abiSource string
+
+	command := &cobra.Command{
+		Use:   "call <address|contract> <method> [args...]",
+		Short: "call a view method of any contract and decode its result",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			results, method, err := CallContractMethod(args[0], abiSource, args[1], args[2:])
+			if err != nil {
+				log.Fatal(err)
+			}
+			logAbiValues(method.Outputs, results)
+		},
+	}
+	command.Flags().StringVar(&abiSource, "abi", "", "abi file, or the name of a contract in ./build")
+	return command
+}
+
+func sendCommand() *cobra.Command {
+	var abiSource string
+	var value string
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use:   "send <address|contract> <method> [args...]",
+		Short: "send a transaction to any contract method and print its receipt",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			amount, err := ParseUnits(value, EtherDecimals)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if dryRun {
+				simulation, bound, err := SimulateContractMethod(args[0], abiSource, args[1], args[2:], amount)
+				if err != nil {
+					log.Fatal(err)
+				}
+				logSimulation(simulation, &bound.Abi, map[common.Address]string{
+					simulation.From: "sender",
+					bound.Address:   "contract",
+				})
+				if !simulation.Reverted && len(bound.Method.Outputs) > 0 {
+					results, err := bound.Method.Outputs.Unpack(simulation.ReturnData)
+					if err != nil {
+						log.Fatal(fmt.Errorf("failed to decode %s result: %w", bound.Method.Sig, err))
+					}
+					logAbiValues(bound.Method.Outputs, results)
+				}
+				return
+			}
+
+			receipt, contractAbi, err := SendContractMethod(args[0], abiSource, args[1], args[2:], amount)
+			if err != nil {
+				log.Fatal(err)
+			}
+			logReceipt(receipt, contractAbi)
+		},
+	}
+	command.Flags().StringVar(&abiSource, "abi", "", "abi file, or the name of a contract in ./build")
+	command.Flags().StringVar(&value, "value", "0", "ether to send with the transaction")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the transaction against the pending block without sending it")
+	return command
+}
+
+// BoundMethod is a contract method resolved from the command line together
+// with its parsed arguments.
+type BoundMethod struct {
+	Address   common.Address
+	AbiSource string
+	Abi       abi.ABI
+	Method    abi.Method
+	Arguments []interface{}
+}
+
+// Calldata packs the selector and arguments.
+func (b *BoundMethod) Calldata() ([]byte, error) {
+	calldata, err := b.Abi.Pack(b.Method.Name, b.Arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", b.Method.Sig, err)
+	}
+	return calldata, nil
+}
+
+func CallContractMethod(target string, abiSource string, methodName string, args []string) ([]interface{}, *abi.Method, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bound, err := bindMethod(client, target, abiSource, methodName, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results, err := callBoundMethod(client, bound)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, &bound.Method, nil
+}
+
+// SendContractMethod waits for the transaction to be mined and returns its
+// receipt along with the abi used to decode its logs.
+func SendContractMethod(target string, abiSource string, methodName string, args []string, value *big.Int) (*types.Receipt, *abi.ABI, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bound, err := bindMethod(client, target, abiSource, methodName, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := sendBoundMethod(client, bound, value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipt, &bound.Abi, nil
+}
+
+// SimulateContractMethod dry runs what SendContractMethod would send.
+func SimulateContractMethod(target string, abiSource string, methodName string, args []string, value *big.Int) (*Simulation, *BoundMethod, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bound, err := bindMethod(client, target, abiSource, methodName, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	calldata, err := bound.Calldata()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	simulation, err := SimulateTransaction(client, &bound.Address, value, calldata)
+	if err != nil {
+		return nil, nil, err
+	}
+	return simulation, bound, nil
+}
+
+func callBoundMethod(client *ethclient.Client, bound *BoundMethod) ([]interface{}, error) {
+	contract := bind.NewBoundContract(bound.Address, bound.Abi, client, client, client)
+	var results []interface{}
+	err := contract.Call(&bind.CallOpts{From: getAccountAddress()}, &results, bound.Method.Name, bound.Arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", bound.Method.Sig, err)
+	}
+	return results, nil
+}
+
+func sendBoundMethod(client *ethclient.Client, bound *BoundMethod, value *big.Int) (*types.Receipt, error) {
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+	transactionOptions.Value = value
+
+	contract := bind.NewBoundContract(bound.Address, bound.Abi, client, client, client)
+	transaction, err := contract.Transact(transactionOptions, bound.Method.Name, bound.Arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", bound.Method.Sig, err)
+	}
+	log.Println("transaction sent: ", transaction.Hash())
+
+	receipt, err := bind.WaitMined(context.Background(), client, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error occured while waiting for transaction: %w", err)
+	}
+	return receipt, nil
+}
+
+// bindMethod resolves the target to an address and abi, then finds the
+// method by name or by full signature, which is needed for overloads.
+func bindMethod(client *ethclient.Client, target string, abiSource string, methodName string, args []string) (*BoundMethod, error) {
+	address, contractName, err := resolveContract(client, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if abiSource == "" {
+		abiSource = contractName
+	}
+	if abiSource == "" {
+		return nil, fmt.Errorf("no abi known for %v, pass --abi", address)
+	}
+
+	contractAbi, err := loadAbi(abiSource)
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := findMethod(contractAbi, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := ParseAbiArguments(method.Inputs, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method.Sig, err)
+	}
+
+	return &BoundMethod{Address: address, AbiSource: abiSource, Abi: *contractAbi, Method: *method, Arguments: arguments}, nil
+}
+
+// resolveContract accepts an address or the name of a contract in the
+// registry, and returns the registry name for an address when it has one.
+func resolveContract(client *ethclient.Client, target string) (common.Address, string, error) {
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return common.Address{}, "", fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return common.Address{}, "", err
+	}
+
+	if !common.IsHexAddress(target) {
+		deployment, ok := registry.Lookup(chainId, target)
+		if !ok {
+			return common.Address{}, "", fmt.Errorf("%s is neither an address nor a contract deployed on chain %v", target, chainId)
+		}
+		return deployment.Address, target, nil
+	}
+
+	address := common.HexToAddress(target)
+	name, _ := registry.Name(chainId, address)
+	return address, name, nil
+}
+
+// loadAbi reads an abi file, falling back to the build artifact of the
+// contract with that name.
+func loadAbi(source string) (*abi.ABI, error) {
+	content, err := readAbi(source)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse abi %s: %w", source, err)
+	}
+	return &parsed, nil
+}
+
+func readAbi(source string) ([]byte, error) {
+	content, err := os.ReadFile(source)
+	if errors.Is(err, os.ErrNotExist) {
+		content, err = os.ReadFile(fmt.Sprintf("./build/%s.abi", source))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read abi %s: %w", source, err)
+	}
+	return content, nil
+}
+
+func findMethod(contractAbi *abi.ABI, name string) (*abi.Method, error) {
+	if method, ok := contractAbi.Methods[name]; ok {
+		return &method, nil
+	}
+	for _, method := range contractAbi.Methods {
+		if method.Sig == strings.ReplaceAll(name, " ", "") {
+			return &method, nil
+		}
+	}
+	return nil, fmt.Errorf("no method %s in abi", name)
+}
+
+func logAbiValues(arguments abi.Arguments, values []interface{}) {
+	for i, value := range values {
+		name := fmt.Sprintf("%d", i)
+		if i < len(arguments) && arguments[i].Name != "" {
+			name = arguments[i].Name
+		}
+		log.Println(name, ": ", FormatAbiValue(value))
+	}
+}
+
+// logReceipt prints the outcome of a transaction and decodes the logs that
+// match events in the given abi.
+func logReceipt(receipt *types.Receipt, contractAbi *abi.ABI) {
+	status := "succeeded"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = "reverted"
+	}
+	log.Println("transaction ", receipt.TxHash, " ", status, " in block ", receipt.BlockNumber)
+	log.Println("gas used: ", receipt.GasUsed)
+	if receipt.ContractAddress != (common.Address{}) {
+		log.Println("contract created at: ", receipt.ContractAddress)
+	}
+	logEvents(receipt.Logs, contractAbi)
+}
+
+// logEvents decodes the logs that match events in the given abi and prints
+// the others raw.
+func logEvents(logs []*types.Log, contractAbi *abi.ABI) {
+	for _, receiptLog := range logs {
+		if contractAbi == nil || len(receiptLog.Topics) == 0 {
+			log.Println("log from ", receiptLog.Address, ": ", receiptLog.Topics)
+			continue
+		}
+
+		event, fields, err := decodeEvent(contractAbi, receiptLog)
+		if event == nil {
+			log.Println("log from ", receiptLog.Address, ": ", receiptLog.Topics)
+			continue
+		}
+		if err != nil {
+			log.Println("event ", event.Name, " could not be decoded: ", err)
+			continue
+		}
+		log.Println("event ", event.Name, ": ", FormatAbiValue(fields))
+	}
+}
+
+// decodeEvent unpacks both the data and the indexed topics of a log. It
+// returns a nil event when the log does not match any event in the abi.
+func decodeEvent(contractAbi *abi.ABI, eventLog *types.Log) (*abi.Event, map[string]interface{}, error) {
+	if len(eventLog.Topics) == 0 {
+		return nil, nil, errors.New("anonymous log")
+	}
+
+	event, err := contractAbi.EventByID(eventLog.Topics[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err := contractAbi.UnpackIntoMap(fields, event.Name, eventLog.Data); err != nil {
+		return event, nil, err
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, eventLog.Topics[1:]); err != nil {
+		return event, nil, err
+	}
+	return event, fields, nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"day-3/lottery"
+	"day-3/token"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testChainId is the chain id of the simulated backend.
+var testChainId = big.NewInt(1337)
+
+// testChain is a simulated chain whose accounts each start with 1000
+// ether.
+type testChain struct {
+	*backends.SimulatedBackend
+	t        *testing.T
+	keys     []*ecdsa.PrivateKey
+	accounts []common.Address
+	requests atomic.Int64
+}
+
+func newTestChain(t *testing.T, accounts int) *testChain {
+	t.Helper()
+	chain := &testChain{t: t}
+	alloc := core.GenesisAlloc{}
+	for i := 0; i < accounts; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		alloc[address] = core.GenesisAccount{Balance: mustParseEther(t, "1000")}
+		chain.keys = append(chain.keys, key)
+		chain.accounts = append(chain.accounts, address)
+	}
+	chain.SimulatedBackend = backends.NewSimulatedBackend(alloc, 30_000_000)
+	t.Cleanup(func() { chain.Close() })
+	return chain
+}
+
+func mustParseEther(t *testing.T, amount string) *big.Int {
+	t.Helper()
+	value, err := ParseUnits(amount, EtherDecimals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+// transactor signs as the account, with the gas estimated.
+func (c *testChain) transactor(account int) *bind.TransactOpts {
+	c.t.Helper()
+	auth, err := bind.NewKeyedTransactorWithChainID(c.keys[account], testChainId)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return auth
+}
+
+// paying is transactor sending value along.
+func (c *testChain) paying(account int, value *big.Int) *bind.TransactOpts {
+	c.t.Helper()
+	auth := c.transactor(account)
+	auth.Value = value
+	return auth
+}
+
+// mine mines the transaction and fails the test unless it succeeded.
+func (c *testChain) mine(transaction *types.Transaction, err error) *types.Receipt {
+	c.t.Helper()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.Commit()
+	receipt, err := c.TransactionReceipt(context.Background(), transaction.Hash())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		c.t.Fatalf("transaction %v reverted", transaction.Hash())
+	}
+	return receipt
+}
+
+func (c *testChain) head() BlockRef {
+	c.t.Helper()
+	header, err := c.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return headerRef(header)
+}
+
+// gasCost is what the account paid for the transaction's gas.
+func (c *testChain) gasCost(receipt *types.Receipt) *big.Int {
+	c.t.Helper()
+	transaction, _, err := c.TransactionByHash(context.Background(), receipt.TxHash)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	header, err := c.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	price := new(big.Int).Add(header.BaseFee, transaction.GasTipCap())
+	if price.Cmp(transaction.GasFeeCap()) > 0 {
+		price = transaction.GasFeeCap()
+	}
+	return price.Mul(price, new(big.Int).SetUint64(receipt.GasUsed))
+}
+
+func (c *testChain) balance(address common.Address) *big.Int {
+	c.t.Helper()
+	balance, err := c.BalanceAt(context.Background(), address, nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return balance
+}
+
+// deployLottery deploys a lottery managed by the account.
+func (c *testChain) deployLottery(manager int) (common.Address, *lottery.Lottery) {
+	c.t.Helper()
+	address, transaction, lotteryContract, err := lottery.DeployLottery(c.transactor(manager), c, c.accounts[manager])
+	c.mine(transaction, err)
+	return address, lotteryContract
+}
+
+// deployToken deploys FredCoin owned by the account, capped at supplyCap
+// whole tokens.
+func (c *testChain) deployToken(owner int, supplyCap string) (common.Address, *token.FredCoin) {
+	c.t.Helper()
+	address, transaction, tokenContract, err := token.DeployFredCoin(c.transactor(owner), c, mustParseEther(c.t, supplyCap))
+	c.mine(transaction, err)
+	return address, tokenContract
+}
+
+// testdataDir is resolved when the tests start, as some move to the module
+// root.
+var testdataDir, _ = filepath.Abs("testdata")
+
+// deployTestContract deploys a contract compiled into testdata.
+func (c *testChain) deployTestContract(name string, deployer int, params ...interface{}) (common.Address, *bind.BoundContract) {
+	c.t.Helper()
+	abiJSON, err := os.ReadFile(filepath.Join(testdataDir, name+".abi"))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	bytecode, err := os.ReadFile(filepath.Join(testdataDir, name+".bin"))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	address, transaction, contract, err := bind.DeployContract(c.transactor(deployer), parsed, common.FromHex(strings.TrimSpace(string(bytecode))), c, params...)
+	c.mine(transaction, err)
+	return address, contract
+}
+
+func (c *testChain) enter(lotteryContract *lottery.Lottery, account int, stake string) {
+	c.t.Helper()
+	c.mine(lotteryContract.Enter(c.paying(account, mustParseEther(c.t, stake))))
+}
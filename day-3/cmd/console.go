@@ -0,0 +1,506 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/peterh/liner"
+)
+
+const consoleHistoryFile = ".fred-coin_history"
+
+var (
+	consoleCallPattern   = regexp.MustCompile(`^(\w+)\.(\w+)\s*(?:\{([^}]*)\})?\s*\((.*)\)$`)
+	consoleAmountPattern = regexp.MustCompile(`^(\d*\.?\d+)\s*(wei|gwei|ether)$`)
+)
+
+// consoleUnits are the ether denominations understood by the console,
+// as decimals relative to wei.
+var consoleUnits = map[string]int{
+	"wei":   0,
+	"gwei":  9,
+	"ether": EtherDecimals,
+}
+
+var consoleBuiltins = []string{"help", "contracts", "account", "balance", "network", "toWei", "fromWei", "exit"}
+
+func consoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "console",
+		Short: "open an interactive console bound to the deployed contracts",
+		Run: func(cmd *cobra.Command, args []string) {
+			console, err := NewConsole()
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := console.Run(); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+}
+
+// ConsoleContract is a deployed contract exposed to the console under a
+// variable name, e.g. lottery or fredCoin.
+type ConsoleContract struct {
+	Name    string
+	Address common.Address
+	Abi     *abi.ABI
+}
+
+// Console evaluates lines such as lottery.getPlayers() or
+// lottery.enter{value: 0.02ether}() against the connected network, signing
+// with the same account and transaction options as the other commands.
+type Console struct {
+	client    *ethclient.Client
+	chainId   *big.Int
+	contracts map[string]*ConsoleContract
+}
+
+func NewConsole() (*Console, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	console := &Console{client: client, chainId: chainId, contracts: map[string]*ConsoleContract{}}
+	for name, deployment := range registry[chainId.String()] {
+		console.bind(name, deployment.Address)
+	}
+
+	// contracts configured the old way, through the environment
+	if _, ok := console.contracts["lottery"]; !ok {
+		console.bind("Lottery", getLotteryAddress())
+	}
+	if _, ok := console.contracts["fredCoin"]; !ok && os.Getenv("TOKEN_CONTRACT_ADDRESS") != "" {
+		console.bind("FredCoin", getTokenAddress())
+	}
+
+	return console, nil
+}
+
+// bind exposes a contract if its abi is among the build artifacts.
+func (c *Console) bind(name string, address common.Address) {
+	contractAbi, err := loadAbi(name)
+	if err != nil {
+		return
+	}
+
+	variable := strings.ToLower(name[:1]) + name[1:]
+	c.contracts[variable] = &ConsoleContract{Name: name, Address: address, Abi: contractAbi}
+}
+
+func (c *Console) Run() error {
+	line := liner.NewLiner()
+	defer line.Close()
+
+	line.SetCtrlCAborts(true)
+	line.SetCompleter(c.complete)
+
+	historyFile := consoleHistoryFile
+	if home, err := os.UserHomeDir(); err == nil {
+		historyFile = filepath.Join(home, consoleHistoryFile)
+	}
+	if history, err := os.Open(historyFile); err == nil {
+		line.ReadHistory(history)
+		history.Close()
+	}
+	defer func() {
+		if history, err := os.Create(historyFile); err == nil {
+			line.WriteHistory(history)
+			history.Close()
+		}
+	}()
+
+	log.Println("connected to chain ", c.chainId, " as ", getAccountAddress())
+	c.listContracts()
+	log.Println("type help for the available commands")
+
+	for {
+		input, err := line.Prompt("> ")
+		if errors.Is(err, liner.ErrPromptAborted) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read console input: %w", err)
+		}
+
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+		line.AppendHistory(input)
+
+		if input == "exit" || input == "quit" {
+			return nil
+		}
+		if err := c.Evaluate(input); err != nil {
+			log.Println("error: ", err)
+		}
+	}
+}
+
+// Evaluate runs a single console line.
+func (c *Console) Evaluate(input string) error {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil
+	}
+	input = strings.TrimSpace(input)
+	switch fields[0] {
+	case "help":
+		c.help()
+		return nil
+	case "contracts":
+		c.listContracts()
+		return nil
+	case "account":
+		return c.logBalance(getAccountAddress())
+	case "balance":
+		if len(fields) != 2 {
+			return errors.New("usage: balance <address|contract>")
+		}
+		address, err := c.resolveAddress(fields[1])
+		if err != nil {
+			return err
+		}
+		return c.logBalance(address)
+	case "network":
+		block, err := c.client.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to fetch block number: %w", err)
+		}
+		log.Println("chain ", c.chainId, " at block ", block)
+		return nil
+	case "toWei", "fromWei":
+		return c.convertUnits(fields)
+	}
+
+	if contract, ok := c.contracts[input]; ok {
+		c.listMethods(contract)
+		return nil
+	}
+	if variable, member, ok := strings.Cut(input, "."); ok && member == "address" {
+		contract, ok := c.contracts[variable]
+		if !ok {
+			return fmt.Errorf("unknown contract %s", variable)
+		}
+		log.Println(contract.Address)
+		return nil
+	}
+
+	match := consoleCallPattern.FindStringSubmatch(input)
+	if match == nil {
+		return fmt.Errorf("cannot parse %q, expected contract.method(args...)", input)
+	}
+	return c.invoke(match[1], match[2], match[3], match[4])
+}
+
+// invoke calls view methods and sends a transaction for every other method.
+func (c *Console) invoke(variable string, methodName string, options string, arguments string) error {
+	contract, ok := c.contracts[variable]
+	if !ok {
+		return fmt.Errorf("unknown contract %s", variable)
+	}
+
+	method, err := findMethod(contract.Abi, methodName)
+	if err != nil {
+		return err
+	}
+
+	value, err := parseConsoleOptions(options)
+	if err != nil {
+		return err
+	}
+
+	args, err := splitConsoleArguments(arguments)
+	if err != nil {
+		return err
+	}
+	for i, arg := range args {
+		if i < len(method.Inputs) && (method.Inputs[i].Type.T == abi.UintTy || method.Inputs[i].Type.T == abi.IntTy) {
+			if amount, ok, err := parseConsoleAmount(arg); ok {
+				if err != nil {
+					return err
+				}
+				args[i] = amount.String()
+			}
+		}
+	}
+
+	parsed, err := ParseAbiArguments(method.Inputs, args)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method.Sig, err)
+	}
+
+	bound := &BoundMethod{Address: contract.Address, Abi: *contract.Abi, Method: *method, Arguments: parsed}
+	if method.IsConstant() {
+		if value.Sign() > 0 {
+			return fmt.Errorf("%s is a view method and cannot receive ether", method.Sig)
+		}
+		results, err := callBoundMethod(c.client, bound)
+		if err != nil {
+			return err
+		}
+		logAbiValues(method.Outputs, results)
+		return nil
+	}
+
+	if value.Sign() > 0 && !method.IsPayable() {
+		return fmt.Errorf("%s is not payable", method.Sig)
+	}
+	receipt, err := sendBoundMethod(c.client, bound, value)
+	if err != nil {
+		return err
+	}
+	logReceipt(receipt, contract.Abi)
+	return nil
+}
+
+// parseConsoleOptions reads the call options between braces, of which only
+// value is supported, e.g. {value: 0.02ether}.
+func parseConsoleOptions(options string) (*big.Int, error) {
+	value := big.NewInt(0)
+	if strings.TrimSpace(options) == "" {
+		return value, nil
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		key, setting, ok := strings.Cut(option, ":")
+		if !ok || strings.TrimSpace(key) != "value" {
+			return nil, fmt.Errorf("unsupported call option %q, only value is supported", strings.TrimSpace(option))
+		}
+
+		amount, isAmount, err := parseConsoleAmount(strings.TrimSpace(setting))
+		if err != nil {
+			return nil, err
+		}
+		if !isAmount {
+			parsed, err := parseAbiInteger(strings.TrimSpace(setting))
+			if err != nil || parsed.Sign() < 0 {
+				return nil, fmt.Errorf("invalid value %q", strings.TrimSpace(setting))
+			}
+			amount = parsed
+		}
+		value = amount
+	}
+	return value, nil
+}
+
+// parseConsoleAmount converts amounts with a unit, such as 0.02ether or
+// 30gwei, into wei. It reports false when the text has no unit.
+func parseConsoleAmount(text string) (*big.Int, bool, error) {
+	match := consoleAmountPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, false, nil
+	}
+
+	amount, err := ParseUnits(match[1], consoleUnits[match[2]])
+	return amount, true, err
+}
+
+// splitConsoleArguments splits on the commas that are not nested in JSON
+// arrays, objects or strings, and unquotes plain string arguments, in which
+// the quote may be escaped with a backslash.
+func splitConsoleArguments(arguments string) ([]string, error) {
+	if strings.TrimSpace(arguments) == "" {
+		return nil, nil
+	}
+
+	var args []string
+	var depth int
+	var quote rune
+	start := 0
+	for i, character := range arguments {
+		switch {
+		case quote != 0:
+			if character == quote && (i == 0 || arguments[i-1] != '\\') {
+				quote = 0
+			}
+		case character == '"' || character == '\'':
+			quote = character
+		case character == '[' || character == '{' || character == '(':
+			depth++
+		case character == ']' || character == '}' || character == ')':
+			depth--
+		case character == ',' && depth == 0:
+			args = append(args, arguments[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, errors.New("unbalanced quotes or brackets in arguments")
+	}
+	args = append(args, arguments[start:])
+
+	for i, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') && arg[len(arg)-1] == arg[0] {
+			quote := string(arg[0])
+			arg = strings.ReplaceAll(arg[1:len(arg)-1], `\`+quote, quote)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+func (c *Console) resolveAddress(target string) (common.Address, error) {
+	if contract, ok := c.contracts[target]; ok {
+		return contract.Address, nil
+	}
+	if !common.IsHexAddress(target) {
+		return common.Address{}, fmt.Errorf("%s is neither an address nor a contract", target)
+	}
+	return common.HexToAddress(target), nil
+}
+
+func (c *Console) logBalance(address common.Address) error {
+	balance, err := c.client.BalanceAt(context.Background(), address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch balance: %w", err)
+	}
+	log.Println(address, ": ", FormatUnits(balance, EtherDecimals), " ether")
+	return nil
+}
+
+func (c *Console) convertUnits(fields []string) error {
+	if len(fields) < 2 || len(fields) > 3 {
+		return fmt.Errorf("usage: %s <amount> [wei|gwei|ether]", fields[0])
+	}
+
+	unit := "ether"
+	if len(fields) == 3 {
+		unit = fields[2]
+	}
+	decimals, ok := consoleUnits[unit]
+	if !ok {
+		return fmt.Errorf("unknown unit %s", unit)
+	}
+
+	if fields[0] == "toWei" {
+		amount, err := ParseUnits(fields[1], decimals)
+		if err != nil {
+			return err
+		}
+		log.Println(amount)
+		return nil
+	}
+
+	amount, ok := new(big.Int).SetString(fields[1], 10)
+	if !ok {
+		return fmt.Errorf("invalid wei amount: %s", fields[1])
+	}
+	log.Println(FormatUnits(amount, decimals))
+	return nil
+}
+
+func (c *Console) help() {
+	log.Println("contract.method(args...)          call a view method or send a transaction")
+	log.Println("contract.method{value: 1ether}()  send ether along with a payable method")
+	log.Println("contract                          list the methods of a contract")
+	log.Println("contract.address                  print the address of a contract")
+	log.Println("contracts                         list the preloaded contracts")
+	log.Println("account                           print the account and its balance")
+	log.Println("balance <address|contract>        print an ether balance")
+	log.Println("network                           print the chain id and block number")
+	log.Println("toWei <amount> [unit]             convert an amount into wei")
+	log.Println("fromWei <wei> [unit]              convert wei into an amount")
+	log.Println("exit                              leave the console")
+	log.Println("arrays and tuples are written as JSON, amounts may use wei, gwei or ether")
+}
+
+func (c *Console) listContracts() {
+	for _, variable := range c.sortedContracts() {
+		log.Println(variable, ": ", c.contracts[variable].Address)
+	}
+}
+
+func (c *Console) listMethods(contract *ConsoleContract) {
+	var signatures []string
+	for _, method := range contract.Abi.Methods {
+		signature := fmt.Sprintf("%s(%s)", method.Name, describeAbiArguments(method.Inputs))
+		if len(method.Outputs) > 0 {
+			signature += " returns (" + describeAbiArguments(method.Outputs) + ")"
+		}
+		if method.IsPayable() {
+			signature += " payable"
+		}
+		signatures = append(signatures, signature)
+	}
+	sort.Strings(signatures)
+
+	for _, signature := range signatures {
+		log.Println(signature)
+	}
+}
+
+func (c *Console) sortedContracts() []string {
+	var variables []string
+	for variable := range c.contracts {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// complete suggests builtins and contracts, then the methods of a contract
+// once its name is followed by a dot.
+func (c *Console) complete(line string) []string {
+	var completions []string
+
+	variable, prefix, ok := strings.Cut(line, ".")
+	if !ok {
+		for _, builtin := range consoleBuiltins {
+			if strings.HasPrefix(builtin, line) {
+				completions = append(completions, builtin)
+			}
+		}
+		for _, variable := range c.sortedContracts() {
+			if strings.HasPrefix(variable, line) {
+				completions = append(completions, variable+".")
+			}
+		}
+		return completions
+	}
+
+	contract, ok := c.contracts[variable]
+	if !ok {
+		return nil
+	}
+	if strings.HasPrefix("address", prefix) {
+		completions = append(completions, variable+".address")
+	}
+	for name := range contract.Abi.Methods {
+		if strings.HasPrefix(name, prefix) {
+			completions = append(completions, variable+"."+name+"(")
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
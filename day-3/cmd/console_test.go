@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"day-3/lottery"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+func TestParseConsoleOptions(t *testing.T) {
+	tests := []struct {
+		options string
+		value   string
+		valid   bool
+	}{
+		{"", "0", true},
+		{"  ", "0", true},
+		{"value: 0.02ether", "20000000000000000", true},
+		{"value:0.02 ether", "20000000000000000", true},
+		{" value : 30gwei ", "30000000000", true},
+		{"value: 5wei", "5", true},
+		{"value: 1000", "1000", true},
+		{"value: 010", "10", true},
+		{"value: 0x10", "16", true},
+		{"value: 1_000", "", false},
+		{"value: -1", "", false},
+		{"value: 1.5", "", false},
+		{"value: 1.5finney", "", false},
+		{"value: 0.0000000001gwei", "", false},
+		{"value", "", false},
+		{"gas: 100000", "", false},
+		{"value: 1ether, gas: 100000", "", false},
+		{"from: 0x0000000000000000000000000000000000000001", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.options, func(t *testing.T) {
+			value, err := parseConsoleOptions(test.options)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("accepted {%s} as a value of %v", test.options, value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value.String() != test.value {
+				t.Fatalf("{%s} is %v wei, want %s", test.options, value, test.value)
+			}
+		})
+	}
+}
+
+func TestParseConsoleAmount(t *testing.T) {
+	tests := []struct {
+		text     string
+		amount   string
+		isAmount bool
+		valid    bool
+	}{
+		{"0.02ether", "20000000000000000", true, true},
+		{"1 ether", "1000000000000000000", true, true},
+		{".5ether", "500000000000000000", true, true},
+		{"30gwei", "30000000000", true, true},
+		{"1.5gwei", "1500000000", true, true},
+		{"7wei", "7", true, true},
+		{"1.5wei", "", true, false},
+		{"1000", "", false, true},
+		{"ether", "", false, true},
+		{"1ETHER", "", false, true},
+		{"-1ether", "", false, true},
+		{"1e18wei", "", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			amount, isAmount, err := parseConsoleAmount(test.text)
+			if isAmount != test.isAmount {
+				t.Fatalf("%q read as an amount: %v", test.text, isAmount)
+			}
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("accepted %q as %v wei", test.text, amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if isAmount && amount.String() != test.amount {
+				t.Fatalf("%q is %v wei, want %s", test.text, amount, test.amount)
+			}
+		})
+	}
+}
+
+func TestSplitConsoleArguments(t *testing.T) {
+	tests := []struct {
+		arguments string
+		args      []string
+		valid     bool
+	}{
+		{"", nil, true},
+		{"  ", nil, true},
+		{"1", []string{"1"}, true},
+		{"1, 2 ,3", []string{"1", "2", "3"}, true},
+		{"0x0000000000000000000000000000000000000001, 2ether", []string{"0x0000000000000000000000000000000000000001", "2ether"}, true},
+		{"[1,2,3], 4", []string{"[1,2,3]", "4"}, true},
+		{`{"a": 1, "b": [2, 3]}, [[1, 2], [3]]`, []string{`{"a": 1, "b": [2, 3]}`, "[[1, 2], [3]]"}, true},
+		{`["a,b", "c"], 1`, []string{`["a,b", "c"]`, "1"}, true},
+		{`(1, "x"), 2`, []string{`(1, "x")`, "2"}, true},
+		{`"hello, world", 'single, quoted'`, []string{"hello, world", "single, quoted"}, true},
+		{`"say \"hi\", then go", 1`, []string{`say "hi", then go`, "1"}, true},
+		{`'it\'s', "a 'b' c"`, []string{"it's", "a 'b' c"}, true},
+		{`["quote \" inside, here"], 2`, []string{`["quote \" inside, here"]`, "2"}, true},
+		{`"unterminated, 1`, nil, false},
+		{`"escaped to the end\"`, nil, false},
+		{"[1, 2", nil, false},
+		{`{"a": 1`, nil, false},
+		{"1]", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.arguments, func(t *testing.T) {
+			args, err := splitConsoleArguments(test.arguments)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("split %q into %q", test.arguments, args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Fatalf("split %q into %q, want %q", test.arguments, args, test.args)
+			}
+		})
+	}
+}
+
+func TestConsoleComplete(t *testing.T) {
+	lotteryAbi, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	console := &Console{contracts: map[string]*ConsoleContract{
+		"lottery": {Name: "Lottery", Abi: &lotteryAbi},
+	}}
+
+	tests := []struct {
+		line        string
+		completions []string
+	}{
+		{"he", []string{"help"}},
+		{"lo", []string{"lottery."}},
+		{"", append(append([]string{}, consoleBuiltins...), "lottery.")},
+		{"x", nil},
+		{"lottery.pick", []string{"lottery.pickWinner("}},
+		{"lottery.add", []string{"lottery.address"}},
+		{"lottery.withdraw", []string{"lottery.withdraw(", "lottery.withdrawFees(", "lottery.withdrawToken(", "lottery.withdrawTokenFees("}},
+		{"lottery.nothing", nil},
+		{"unknown.", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			if completions := console.complete(test.line); !reflect.DeepEqual(completions, test.completions) {
+				t.Fatalf("%q completes to %q, want %q", test.line, completions, test.completions)
+			}
+		})
+	}
+}
+
+func TestConsoleEvaluateWithoutTheNetwork(t *testing.T) {
+	console := &Console{chainId: big.NewInt(1), contracts: map[string]*ConsoleContract{}}
+
+	// blank lines are ignored rather than indexed into
+	for _, input := range []string{"", "   ", "\t\n"} {
+		if err := console.Evaluate(input); err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+	}
+
+	tests := []string{
+		"lottery.enter{value: 0.02ether}()",
+		"lottery.address",
+		"balance",
+		"toWei",
+		"toWei 1 finney",
+		"fromWei 1.5",
+		"not a call",
+		"lottery.enter{value: 1ether()",
+	}
+	for _, input := range tests {
+		if err := console.Evaluate(input); err == nil {
+			t.Fatalf("%q was evaluated", input)
+		}
+	}
+}
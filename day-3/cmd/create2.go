@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"day-3/lottery"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The deterministic deployment proxy is deployed by a presigned transaction
+// without a chain id, sent from an account nobody holds the key of. It lands
+// at the same address on every network that accepts the transaction, which
+// is what makes CREATE2 addresses through it the same everywhere.
+var (
+	Create2DeployerAddress = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+	create2DeployerTransaction = common.FromHex("0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf31ba02222222222222222222222222222222222222222222222222222222222222222a02222222222222222222222222222222222222222222222222222222222222222")
+	create2DeployerCode        = common.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+)
+
+func deployCreate2DeployerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "deploy-create2-deployer",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("deploying CREATE2 deployer...")
+			address, err := DeployCreate2Deployer()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("CREATE2 deployer deployed to address: ", address)
+		},
+	}
+}
+
+// ParseSalt uses a 32 byte hex value as is and hashes anything else, so
+// that readable salts such as "lottery-v1" can be used.
+func ParseSalt(salt string) common.Hash {
+	if strings.HasPrefix(salt, "0x") && len(salt) == 66 {
+		return common.HexToHash(salt)
+	}
+	return crypto.Keccak256Hash([]byte(salt))
+}
+
+// LotteryInitCode is the creation bytecode followed by the constructor
+// arguments, which together with the salt determine the CREATE2 address.
+// The manager is a constructor argument, so the same salt gives a different
+// address for every manager: deploy from the same account, or with the same
+// ACCOUNT_ADDRESS, to share an address across networks.
+func LotteryInitCode(manager common.Address) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lottery abi: %w", err)
+	}
+
+	arguments, err := parsed.Pack("", manager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack constructor arguments: %w", err)
+	}
+
+	return append(common.FromHex(lottery.LotteryBin), arguments...), nil
+}
+
+// PredictCreate2Address computes the address offline, exactly as the
+// deployer's CREATE2 opcode will.
+func PredictCreate2Address(deployer common.Address, salt common.Hash, initCode []byte) (common.Address, common.Hash) {
+	initCodeHash := crypto.Keccak256Hash(initCode)
+	return crypto.CreateAddress2(deployer, salt, initCodeHash.Bytes()), initCodeHash
+}
+
+// create2Calldata is what the deployment proxy expects: the salt followed
+// by the init code, with no function selector.
+func create2Calldata(salt common.Hash, initCode []byte) []byte {
+	return append(salt.Bytes(), initCode...)
+}
+
+// DeployCreate2Deployer sends the presigned deployment of the proxy, after
+// funding its one-time sender with the gas it pays for. Networks that
+// already have the proxy are only recorded.
+func DeployCreate2Deployer() (common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	deployed, err := verifyCreate2Deployer(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if deployed {
+		return Create2DeployerAddress, recordDeployment(client, "Create2Deployer", Deployment{Address: Create2DeployerAddress})
+	}
+
+	transaction := new(types.Transaction)
+	if err := transaction.UnmarshalBinary(create2DeployerTransaction); err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode CREATE2 deployer transaction: %w", err)
+	}
+	sender, err := types.Sender(types.HomesteadSigner{}, transaction)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover CREATE2 deployer sender: %w", err)
+	}
+
+	balance, err := client.BalanceAt(context.Background(), sender, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch balance of %v: %w", sender, err)
+	}
+	cost := new(big.Int).Mul(transaction.GasPrice(), new(big.Int).SetUint64(transaction.Gas()))
+	if balance.Cmp(cost) < 0 {
+		transactionOptions, err := newTransactionOptions(client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		transactionOptions.Value = new(big.Int).Sub(cost, balance)
+		// a plain transfer, which estimation refuses to a codeless address
+		transactionOptions.GasLimit = params.TxGas
+
+		funding, err := bind.NewBoundContract(sender, abi.ABI{}, client, client, client).Transfer(transactionOptions)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to fund CREATE2 deployer sender: %w", err)
+		}
+		if err := waitSuccessful(client, funding); err != nil {
+			return common.Address{}, err
+		}
+	}
+
+	// the transaction has no chain id, which some nodes refuse unless they
+	// allow unprotected transactions
+	if err := client.SendTransaction(context.Background(), transaction); err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy CREATE2 deployer: %w", err)
+	}
+	if err := waitSuccessful(client, transaction); err != nil {
+		return common.Address{}, err
+	}
+	if deployed, err := verifyCreate2Deployer(client); err != nil || !deployed {
+		return common.Address{}, fmt.Errorf("CREATE2 deployer is missing after %v: %v", transaction.Hash(), err)
+	}
+
+	err = recordDeployment(client, "Create2Deployer", Deployment{Address: Create2DeployerAddress, TransactionHash: transaction.Hash()})
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return Create2DeployerAddress, nil
+}
+
+// verifyCreate2Deployer reports whether the proxy is deployed, and fails
+// when something else is at its address.
+func verifyCreate2Deployer(client *ethclient.Client) (bool, error) {
+	code, err := client.CodeAt(context.Background(), Create2DeployerAddress, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch code at %v: %w", Create2DeployerAddress, err)
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+	if !bytes.Equal(code, create2DeployerCode) {
+		return false, fmt.Errorf("the code at %v is not the CREATE2 deployer", Create2DeployerAddress)
+	}
+	return true, nil
+}
+
+func waitSuccessful(client *ethclient.Client, transaction *types.Transaction) error {
+	receipt, err := bind.WaitMined(context.Background(), client, transaction)
+	if err != nil {
+		return fmt.Errorf("error occured while waiting for transaction %v: %w", transaction.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %v reverted", transaction.Hash())
+	}
+	return nil
+}
+
+// DeployContractWithSalt deploys the lottery through the CREATE2 deployer
+// and records the salt so the same address can be reproduced elsewhere.
+func DeployContractWithSalt(salt common.Hash) (*lottery.Lottery, common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	deployer, err := getCreate2DeployerAddress(client)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	initCode, err := LotteryInitCode(getAccountAddress())
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	address, initCodeHash := PredictCreate2Address(deployer, salt, initCode)
+	code, err := client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to fetch code at %v: %w", address, err)
+	}
+	if len(code) > 0 {
+		return nil, common.Address{}, fmt.Errorf("a contract is already deployed at %v with this salt", address)
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	deployerContract := bind.NewBoundContract(deployer, abi.ABI{}, client, client, client)
+	transaction, err := deployerContract.RawTransact(transactionOptions, create2Calldata(salt, initCode))
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to deploy contract with CREATE2: %w", err)
+	}
+	if err := waitSuccessful(client, transaction); err != nil {
+		return nil, common.Address{}, fmt.Errorf("CREATE2 deployment failed: %w", err)
+	}
+
+	err = recordDeployment(client, "Lottery", Deployment{
+		Address:         address,
+		TransactionHash: transaction.Hash(),
+		Salt:            &salt,
+		InitCodeHash:    &initCodeHash,
+		Deployer:        &deployer,
+	})
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	contract, err := lottery.NewLottery(address, client)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to bind lottery contract: %w", err)
+	}
+
+	return contract, address, nil
+}
+
+// SimulateDeployContract dry runs the lottery deployment, directly or
+// through the CREATE2 deployer when a salt is given, and returns the address
+// the lottery would be deployed to.
+func SimulateDeployContract(salt *common.Hash) (*Simulation, common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	initCode, err := LotteryInitCode(getAccountAddress())
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	if salt == nil {
+		simulation, err := SimulateTransaction(client, nil, nil, initCode)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+
+		// the contract lands at the address of the signer's next nonce
+		nonce, err := client.PendingNonceAt(context.Background(), simulation.From)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("failed to fetch pending nonce: %w", err)
+		}
+		return simulation, crypto.CreateAddress(simulation.From, nonce), nil
+	}
+
+	deployer, err := getCreate2DeployerAddress(client)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	simulation, err := SimulateTransaction(client, &deployer, nil, create2Calldata(*salt, initCode))
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	address, _ := PredictCreate2Address(deployer, *salt, initCode)
+	return simulation, address, nil
+}
+
+// getCreate2DeployerAddress checks the deployer is deployed on the connected
+// network, and that the registry does not record another one for it.
+func getCreate2DeployerAddress(client *ethclient.Client) (common.Address, error) {
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return common.Address{}, err
+	}
+	if deployment, ok := registry.Lookup(chainId, "Create2Deployer"); ok && deployment.Address != Create2DeployerAddress {
+		return common.Address{}, fmt.Errorf("the registry records CREATE2 deployer %v on chain %v, expected %v", deployment.Address, chainId, Create2DeployerAddress)
+	}
+
+	deployed, err := verifyCreate2Deployer(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !deployed {
+		return common.Address{}, fmt.Errorf("no CREATE2 deployer on chain %v, run deploy-create2-deployer first", chainId)
+	}
+	return Create2DeployerAddress, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParseSalt(t *testing.T) {
+	hexSalt := "0x00000000000000000000000000000000000000000000000000000000000000ff"
+	if salt := ParseSalt(hexSalt); salt != common.HexToHash(hexSalt) {
+		t.Fatalf("32 byte salt parsed as %v", salt)
+	}
+	if salt := ParseSalt("lottery-v1"); salt != crypto.Keccak256Hash([]byte("lottery-v1")) {
+		t.Fatalf("readable salt parsed as %v", salt)
+	}
+	// too short to be a salt, so it is hashed rather than padded
+	if salt := ParseSalt("0xff"); salt != crypto.Keccak256Hash([]byte("0xff")) {
+		t.Fatalf("short hex salt parsed as %v", salt)
+	}
+}
+
+func TestCreate2DeployLandsAtPredictedAddress(t *testing.T) {
+	chain := newTestChain(t, 2)
+	chain.serve()
+	t.Setenv("DEPLOYMENTS_FILE", filepath.Join(t.TempDir(), "deployments.json"))
+	useSigner(t, &keySigner{key: chain.keys[0]})
+	salt := ParseSalt("lottery-v1")
+
+	if _, _, err := DeployContractWithSalt(salt); err == nil {
+		t.Fatal("deployed through a missing CREATE2 deployer")
+	}
+	deployerAddress, err := DeployCreate2Deployer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployerAddress != Create2DeployerAddress {
+		t.Fatalf("deployer deployed to %v, want %v", deployerAddress, Create2DeployerAddress)
+	}
+	// deploying again finds the deployer in place
+	if _, err := DeployCreate2Deployer(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the manager is in the init code, so another manager gets another address
+	t.Setenv("ACCOUNT_ADDRESS", chain.accounts[1].Hex())
+	initCode, err := LotteryInitCode(chain.accounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	predicted, initCodeHash := PredictCreate2Address(Create2DeployerAddress, salt, initCode)
+	otherInitCode, err := LotteryInitCode(chain.accounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := PredictCreate2Address(Create2DeployerAddress, salt, otherInitCode); other == predicted {
+		t.Fatal("both managers predict the same address")
+	}
+
+	lotteryContract, deployed, err := DeployContractWithSalt(salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployed != predicted {
+		t.Fatalf("lottery deployed to %v, predicted %v", deployed, predicted)
+	}
+	manager, err := lotteryContract.Manager(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manager != chain.accounts[1] {
+		t.Fatalf("lottery is managed by %v, want %v", manager, chain.accounts[1])
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment, _ := registry.Lookup(testChainId, "Lottery")
+	if deployment.Address != predicted || *deployment.Deployer != Create2DeployerAddress || *deployment.InitCodeHash != initCodeHash || *deployment.Salt != salt {
+		t.Fatalf("registry records %+v", deployment)
+	}
+
+	// the address is taken, so the same salt and init code cannot deploy again
+	if _, _, err := DeployContractWithSalt(salt); err == nil {
+		t.Fatal("deploying twice with the same salt succeeded")
+	}
+
+	// a registry naming another deployer for the network is refused
+	registry.Record(testChainId, "Create2Deployer", Deployment{Address: chain.accounts[0]})
+	if err := registry.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DeployContractWithSalt(ParseSalt("lottery-v2")); err == nil {
+		t.Fatal("deployed through a deployer the registry disagrees with")
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type RoundDeadline struct {
+	Deadline  time.Time
+	ChainTime time.Time
+}
+
+// Open reports whether a round with players is in progress.
+func (d *RoundDeadline) Open() bool {
+	return !d.Deadline.IsZero()
+}
+
+// Expired reports whether anyone may now refund the round.
+func (d *RoundDeadline) Expired() bool {
+	return d.Open() && !d.ChainTime.Before(d.Deadline)
+}
+
+// Remaining is measured against the latest block rather than the local
+// clock, since that is what the contract compares the deadline with.
+func (d *RoundDeadline) Remaining() time.Duration {
+	if !d.Open() || d.Expired() {
+		return 0
+	}
+	return d.Deadline.Sub(d.ChainTime)
+}
+
+func lotteryRefundExpiredCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "refund-expired",
+		Run: func(cmd *cobra.Command, args []string) {
+			deadline, err := GetRoundDeadline()
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !deadline.Expired() {
+				log.Fatal("the round has not passed its deadline yet")
+			}
+
+			log.Println("refunding the expired round...")
+			transaction, err := RefundExpiredRound()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("round refunded in transaction hash: ", transaction.Hash())
+			log.Println("players can now reclaim their tickets with lottery claim")
+		},
+	}
+}
+
+func lotterySetRoundDurationCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "set-round-duration <duration>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			duration, err := time.ParseDuration(args[0])
+			if err != nil || duration < time.Second {
+				log.Fatalf("invalid round duration: %s", args[0])
+			}
+
+			log.Println("setting round duration to ", duration, "...")
+			transaction, err := SetRoundDuration(duration)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("round duration set in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func logRoundDeadline(deadline *RoundDeadline) {
+	switch {
+	case !deadline.Open():
+		log.Println("no round in progress")
+	case deadline.Expired():
+		log.Println("round deadline passed at: ", deadline.Deadline)
+		log.Println("anyone can now refund the round with lottery refund-expired")
+	default:
+		log.Println("round deadline is: ", deadline.Deadline, " (in ", deadline.Remaining(), ")")
+		log.Println("until then only the manager or an operator can pick a winner")
+	}
+}
+
+func GetRoundDeadline() (*RoundDeadline, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, err := lotteryContract.RoundDeadline(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch round deadline: %w", err)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	roundDeadline := &RoundDeadline{ChainTime: time.Unix(int64(header.Time), 0)}
+	if deadline.Sign() > 0 {
+		roundDeadline.Deadline = time.Unix(deadline.Int64(), 0)
+	}
+	return roundDeadline, nil
+}
+
+func RefundExpiredRound() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.RefundExpiredRound(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund expired round: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func SetRoundDuration(duration time.Duration) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := big.NewInt(int64(duration / time.Second))
+	transaction, err := lotteryContract.SetRoundDuration(transactionOptions, seconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set round duration: %w", err)
+	}
+
+	return transaction, nil
+}
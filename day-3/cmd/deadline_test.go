@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestRefundExpiredRoundOnlyAfterDeadline(t *testing.T) {
+	chain := newTestChain(t, 3)
+	_, lotteryContract := chain.deployLottery(0)
+	chain.mine(lotteryContract.SetRoundDuration(chain.transactor(0), big.NewInt(int64(time.Hour/time.Second))))
+
+	deadline, err := lotteryContract.RoundDeadline(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deadline.Sign() != 0 {
+		t.Fatalf("empty round has deadline %v, want none", deadline)
+	}
+
+	chain.enter(lotteryContract, 1, "1")
+	chain.enter(lotteryContract, 2, "2")
+	deadline, err = lotteryContract.RoundDeadline(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a player cannot refund before the deadline
+	if _, err := lotteryContract.RefundExpiredRound(chain.transactor(2)); err == nil {
+		t.Fatal("refunding a running round succeeded")
+	}
+
+	if err := chain.AdjustTime(59 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	chain.Commit()
+	if _, err := lotteryContract.RefundExpiredRound(chain.transactor(2)); err == nil {
+		t.Fatal("refunding a minute before the deadline succeeded")
+	}
+
+	if err := chain.AdjustTime(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	chain.Commit()
+	header, err := chain.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Time < deadline.Uint64() {
+		t.Fatalf("chain time %v is still before the deadline %v", header.Time, deadline)
+	}
+
+	// anyone may now refund, not only the manager
+	chain.mine(lotteryContract.RefundExpiredRound(chain.transactor(2)))
+	for account, stake := range map[int]string{1: "1", 2: "2"} {
+		pending, err := lotteryContract.PendingWithdrawals(nil, chain.accounts[account])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := mustParseEther(t, stake); pending.Cmp(want) != 0 {
+			t.Fatalf("account %d was refunded %v, want %v", account, pending, want)
+		}
+	}
+	deadline, err = lotteryContract.RoundDeadline(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deadline.Sign() != 0 {
+		t.Fatalf("refunded round kept deadline %v", deadline)
+	}
+	if _, err := lotteryContract.RefundExpiredRound(chain.transactor(1)); err == nil {
+		t.Fatal("refunding an empty round succeeded")
+	}
+}
+
+func TestRoundDeadline(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name      string
+		deadline  time.Time
+		open      bool
+		expired   bool
+		remaining time.Duration
+	}{
+		{"no round", time.Time{}, false, false, 0},
+		{"running", now.Add(90 * time.Minute), true, false, 90 * time.Minute},
+		{"at the deadline", now, true, true, 0},
+		{"past the deadline", now.Add(-time.Second), true, true, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deadline := &RoundDeadline{Deadline: test.deadline, ChainTime: now}
+			if deadline.Open() != test.open || deadline.Expired() != test.expired || deadline.Remaining() != test.remaining {
+				t.Fatalf("got open %v, expired %v, remaining %v, want %v, %v, %v", deadline.Open(), deadline.Expired(), deadline.Remaining(), test.open, test.expired, test.remaining)
+			}
+		})
+	}
+}
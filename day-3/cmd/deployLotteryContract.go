@@ -12,24 +12,72 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 const TestContractAddress = "0xEf4B9cf94fC0139880c4aE697fa09Fbf71600c05"
 
 func deployAndTestLotteryContract() *cobra.Command {
-	return &cobra.Command{
+	var salt string
+	var predict bool
+	var dryRun bool
+
+	command := &cobra.Command{
 		Use: "deploy",
 		Run: func(cmd *cobra.Command, args []string) {
 
+			if predict {
+				if salt == "" {
+					log.Fatal("--predict needs --salt")
+				}
+				// the manager is part of the init code, so it moves the address
+				manager := getAccountAddress()
+				initCode, err := LotteryInitCode(manager)
+				if err != nil {
+					log.Fatal(err)
+				}
+				address, initCodeHash := PredictCreate2Address(Create2DeployerAddress, ParseSalt(salt), initCode)
+				log.Println("salt: ", ParseSalt(salt).Hex())
+				log.Println("manager: ", manager)
+				log.Println("init code hash: ", initCodeHash.Hex())
+				log.Println("contract will deploy to address: ", address)
+				return
+			}
+
+			if dryRun {
+				var saltHash *common.Hash
+				if salt != "" {
+					parsed := ParseSalt(salt)
+					saltHash = &parsed
+				}
+				simulation, address, err := SimulateDeployContract(saltHash)
+				if err != nil {
+					log.Fatal(err)
+				}
+				logSimulation(simulation, nil, map[common.Address]string{
+					simulation.From: "sender",
+					address:         "lottery",
+				})
+				if !simulation.Reverted {
+					log.Println("contract would deploy to address: ", address)
+				}
+				return
+			}
+
 			balance, _ := GetAccountBalance()
 			log.Println("current account balance is: ", balance)
 
 			log.Println("deploying contract...")
-			_, address, _ := DeployContract()
+			var address common.Address
+			if salt != "" {
+				_, address, _ = DeployContractWithSalt(ParseSalt(salt))
+			} else {
+				_, address, _ = DeployContract()
+			}
 
 			log.Println("contract deployed to address: ", address)
+			// play the demo round on the lottery just deployed
+			selectedLottery = &address
 
 			log.Println("entering the lottery...")
 			_, _ = EnterLottery()
@@ -45,6 +93,10 @@ func deployAndTestLotteryContract() *cobra.Command {
 			log.Println("done.")
 		},
 	}
+	command.Flags().StringVar(&salt, "salt", "", "deploy through the CREATE2 deployer with this salt, to an address that depends on the manager")
+	command.Flags().BoolVar(&predict, "predict", false, "only compute the CREATE2 address for --salt and the account, offline")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the deployment against the pending block without sending it")
+	return command
 }
 
 func GetAccountBalance() (*big.Int, error) {
@@ -69,29 +121,21 @@ func EnterLottery() (*types.Transaction, error) {
 		return nil, err
 	}
 
-	lotteryContract, err := lottery.NewLottery(common.HexToAddress(TestContractAddress), client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to instantiate Storage contract: %w", err)
-	}
-
-	transactionOptions, err := GetTransactionOptions(client)
+	lotteryContract, err := getLotteryContract(client)
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), common.HexToAddress(os.Getenv("ACCOUNT_ADDRESS")))
+	transactionOptions, err := newTransactionOptions(client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pending nonce: %w", err)
+		return nil, err
 	}
-
-	transactionOptions.Nonce = big.NewInt(int64(nonce))
 	transactionOptions.Value = big.NewInt(12000000000000000)
-	transactionOptions.GasLimit = uint64(300000)
 
 	transaction, err := lotteryContract.Enter(transactionOptions)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch lottery players: %w", err)
+		return nil, fmt.Errorf("failed to enter lottery: %w", err)
 	}
 
 	log.Println("Lottery entered: ", transaction.Hash())
@@ -104,9 +148,9 @@ func GetLotteryPlayers() ([]common.Address, error) {
 		return nil, err
 	}
 
-	lotteryContract, err := lottery.NewLottery(common.HexToAddress(TestContractAddress), client)
+	lotteryContract, err := getLotteryContract(client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to bind lottery contract: %w", err)
+		return nil, err
 	}
 
 	players, err := lotteryContract.GetPlayers(nil)
@@ -123,21 +167,17 @@ func PickLotteryWinner() (*types.Transaction, error) {
 		return nil, err
 	}
 
-	lotteryContract, err := lottery.NewLottery(common.HexToAddress(TestContractAddress), client)
+	lotteryContract, err := getLotteryContract(client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to bind lottery contract: %w", err)
+		return nil, err
 	}
 
-	auth, err := GetTransactionOptions(client)
+	// pickWinner is not payable, so no value is sent with it
+	auth, err := newTransactionOptions(client)
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, _ := client.PendingNonceAt(context.Background(), common.HexToAddress(os.Getenv("ACCOUNT_ADDRESS")))
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(12000000000000000)
-	auth.GasLimit = uint64(300000)
-
 	winner, err := lotteryContract.PickWinner(auth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pick lottery winner: %w", err)
@@ -158,7 +198,7 @@ func DeployContract() (*lottery.Lottery, common.Address, error) {
 		return nil, common.Address{}, err
 	}
 
-	contractAddress, transaction, contract, err := lottery.DeployLottery(transactionOptions, client)
+	contractAddress, transaction, contract, err := lottery.DeployLottery(transactionOptions, client, getAccountAddress())
 	if err != nil {
 		return nil, common.Address{}, fmt.Errorf("failed to deploy contract: %w", err)
 	}
@@ -173,31 +213,36 @@ func DeployContract() (*lottery.Lottery, common.Address, error) {
 
 	fmt.Printf("Contract deployed at %v\n", contractAddress)
 
+	err = recordDeployment(client, "Lottery", Deployment{Address: contractAddress, TransactionHash: transaction.Hash()})
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
 	return contract, contractAddress, nil
 }
 
 func GetTransactionOptions(cl *ethclient.Client) (*bind.TransactOpts, error) {
-	var (
-		sk = crypto.ToECDSAUnsafe(common.FromHex(os.Getenv("ACCOUNT_PRIVATE_KEY")))
-	)
+	signer, err := GetSigner()
+	if err != nil {
+		return nil, err
+	}
+
 	// Retrieve the chainid (needed for signer)
 	chainid, err := cl.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
 	}
 
-	// Create the transactOpts (signer)
-	transactOpts, err := bind.NewKeyedTransactorWithChainID(sk, chainid)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create keyed transaction: %w", err)
+	// Create the transactOpts, signing through whichever backend is selected
+	transactOpts := &bind.TransactOpts{
+		From: signer.Address(),
+		Signer: func(address common.Address, transaction *types.Transaction) (*types.Transaction, error) {
+			if address != signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(transaction, chainid)
+		},
+		Context: context.Background(),
 	}
 	return transactOpts, nil
 }
-
-func GetClient() (*ethclient.Client, error) {
-	client, err := ethclient.Dial(os.Getenv("NODE_ENDPOINT"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create eth client: %w", err)
-	}
-	return client, nil
-}
@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"context"
+	"day-3/token"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// permitValidity is how long a locally signed permit stays usable.
+const permitValidity = 20 * time.Minute
+
+func lotteryEnterCommand() *cobra.Command {
+	var value string
+	var usePermit bool
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use: "enter",
+		Run: func(cmd *cobra.Command, args []string) {
+			paymentToken, ticketPrice, err := GetLotteryPaymentToken()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if paymentToken == (common.Address{}) {
+				amount, err := ParseUnits(value, EtherDecimals)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				if dryRun {
+					simulation, err := SimulateLotteryCall(amount, "enter")
+					if err != nil {
+						log.Fatal(err)
+					}
+					logLotterySimulation(simulation)
+					return
+				}
+
+				log.Println("entering the lottery with ", value, " ether...")
+				transaction, err := EnterLotteryWithEther(amount)
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println("lottery entered in transaction hash: ", transaction.Hash())
+				return
+			}
+
+			decimals, err := GetTokenDecimals(paymentToken)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery tickets cost ", FormatUnits(ticketPrice, decimals), " of token ", paymentToken)
+			if dryRun {
+				simulation, err := SimulateLotteryEntryWithToken(paymentToken, ticketPrice, usePermit)
+				if err != nil {
+					log.Fatal(err)
+				}
+				logLotterySimulation(simulation)
+				return
+			}
+
+			transaction, err := EnterLotteryWithToken(paymentToken, ticketPrice, usePermit)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery entered in transaction hash: ", transaction.Hash())
+		},
+	}
+	command.Flags().StringVar(&value, "value", "0.02", "ether to pay when the lottery is not priced in a token")
+	command.Flags().BoolVar(&usePermit, "permit", false, "sign an EIP-2612 permit instead of sending an approval")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the entry against the pending block without sending it")
+	return command
+}
+
+func lotterySetPaymentTokenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "set-payment-token <token-address|eth> [ticket-price]",
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			paymentToken := common.Address{}
+			ticketPrice := new(big.Int)
+
+			if !strings.EqualFold(args[0], "eth") {
+				if len(args) != 2 {
+					log.Fatal("a ticket price is required when pricing tickets in a token")
+				}
+				paymentToken = parseAddressArg(args[0])
+				var err error
+				ticketPrice, err = ParseTokenAmount(paymentToken, args[1])
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			log.Println("setting the lottery payment token...")
+			transaction, err := SetLotteryPaymentToken(paymentToken, ticketPrice)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("payment token set in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+// GetLotteryPaymentToken returns the zero address when tickets are paid
+// in ether.
+func GetLotteryPaymentToken() (common.Address, *big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	paymentToken, err := lotteryContract.PaymentToken(nil)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("failed to fetch lottery payment token: %w", err)
+	}
+
+	ticketPrice, err := lotteryContract.TicketPrice(nil)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("failed to fetch lottery ticket price: %w", err)
+	}
+
+	return paymentToken, ticketPrice, nil
+}
+
+// LotteryEntry is the transaction a wallet signs to enter the lottery. In a
+// token priced lottery without enough allowance it is the approval instead,
+// and the entry is built once the approval is mined.
+type LotteryEntry struct {
+	ApprovalRequired bool                 `json:"approvalRequired"`
+	Transaction      *UnsignedTransaction `json:"transaction"`
+}
+
+// BuildLotteryEntry builds the entry for an external wallet to sign, paying
+// value in ether or the ticket price in the payment token.
+func BuildLotteryEntry(from common.Address, value *big.Int) (*LotteryEntry, error) {
+	lotteryAddress := getLotteryAddress()
+
+	paymentToken, ticketPrice, err := GetLotteryPaymentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentToken == (common.Address{}) {
+		transaction, err := BuildUnsignedTransaction(from, lotteryAddress.Hex(), "Lottery", "enter", nil, value, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &LotteryEntry{Transaction: transaction}, nil
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	tokenContract, err := token.NewFredCoin(paymentToken, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token contract: %w", err)
+	}
+	allowance, err := tokenContract.Allowance(nil, from, lotteryAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token allowance: %w", err)
+	}
+
+	if allowance.Cmp(ticketPrice) < 0 {
+		approval, err := BuildUnsignedTransaction(from, paymentToken.Hex(), "FredCoin", "approve", []string{lotteryAddress.Hex(), ticketPrice.String()}, new(big.Int), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &LotteryEntry{ApprovalRequired: true, Transaction: approval}, nil
+	}
+
+	transaction, err := BuildUnsignedTransaction(from, lotteryAddress.Hex(), "Lottery", "enterWithToken", nil, new(big.Int), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryEntry{Transaction: transaction}, nil
+}
+
+func EnterLotteryWithEther(value *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+	transactionOptions.Value = value
+
+	transaction, err := lotteryContract.Enter(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter lottery: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// EnterLotteryWithToken pays the ticket price in the lottery's payment
+// token. Without an existing allowance it either sends an approval first and
+// waits for it to be mined, or signs a permit so that approving and entering
+// happen in one transaction.
+func EnterLotteryWithToken(paymentToken common.Address, ticketPrice *big.Int, usePermit bool) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := token.NewFredCoin(paymentToken, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token contract: %w", err)
+	}
+
+	// the entry spends from the signer's account, so that is whose
+	// allowance counts
+	signer, err := GetSigner()
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := tokenContract.Allowance(nil, signer.Address(), getLotteryAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token allowance: %w", err)
+	}
+
+	if allowance.Cmp(ticketPrice) < 0 && usePermit {
+		permit, err := newLotteryPermit(tokenContract, paymentToken, ticketPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		transactionOptions, err := newTransactionOptions(client)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Println("entering the lottery with a signed permit...")
+		transaction, err := lotteryContract.EnterWithPermit(transactionOptions, permit.Deadline, permit.V, permit.R, permit.S)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enter lottery with permit: %w", err)
+		}
+		return transaction, nil
+	}
+
+	if allowance.Cmp(ticketPrice) < 0 {
+		transactionOptions, err := newTransactionOptions(client)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Println("approving the lottery to spend the ticket price...")
+		approval, err := tokenContract.Approve(transactionOptions, getLotteryAddress(), ticketPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to approve ticket price: %w", err)
+		}
+
+		receipt, err := bind.WaitMined(context.Background(), client, approval)
+		if err != nil {
+			return nil, fmt.Errorf("error occured while waiting for approval: %w", err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("approval %v reverted", approval.Hash())
+		}
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("entering the lottery with tokens...")
+	transaction, err := lotteryContract.EnterWithToken(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter lottery with tokens: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// SimulateLotteryEntryWithToken dry runs the entry EnterLotteryWithToken
+// would send. A permit is signed locally, since that sends nothing, but an
+// approval would have to be mined before the entry, so without an allowance
+// the approval is simulated instead.
+func SimulateLotteryEntryWithToken(paymentToken common.Address, ticketPrice *big.Int, usePermit bool) (*Simulation, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := token.NewFredCoin(paymentToken, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token contract: %w", err)
+	}
+
+	signer, err := GetSigner()
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := tokenContract.Allowance(nil, signer.Address(), getLotteryAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token allowance: %w", err)
+	}
+
+	if allowance.Cmp(ticketPrice) >= 0 {
+		return SimulateLotteryCall(nil, "enterWithToken")
+	}
+
+	if usePermit {
+		permit, err := newLotteryPermit(tokenContract, paymentToken, ticketPrice)
+		if err != nil {
+			return nil, err
+		}
+		return SimulateLotteryCall(nil, "enterWithPermit", permit.Deadline, permit.V, permit.R, permit.S)
+	}
+
+	log.Println("entering needs an approval first, simulating the approval only")
+	parsed, err := abi.JSON(strings.NewReader(token.FredCoinABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token abi: %w", err)
+	}
+
+	calldata, err := parsed.Pack("approve", getLotteryAddress(), ticketPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack approve call: %w", err)
+	}
+
+	return SimulateTransaction(client, &paymentToken, nil, calldata)
+}
+
+func newLotteryPermit(tokenContract *token.FredCoin, paymentToken common.Address, value *big.Int) (*Permit, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	name, err := tokenContract.Name(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token name: %w", err)
+	}
+
+	// refuse to sign for a domain the token would not recognise
+	domainSeparator := PermitDomainSeparator(name, chainId, paymentToken)
+	onChainSeparator, err := tokenContract.DOMAINSEPARATOR(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token domain separator: %w", err)
+	}
+	if domainSeparator != onChainSeparator {
+		return nil, fmt.Errorf("token %v does not use the expected permit domain", paymentToken)
+	}
+
+	// the permit approves for whoever signs it, which is the signer's
+	// account even when ACCOUNT_ADDRESS names another one
+	key, err := getAccountKey()
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign a permit: %w", err)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := tokenContract.Nonces(nil, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch permit nonce: %w", err)
+	}
+
+	permit := &Permit{
+		Owner:    owner,
+		Spender:  getLotteryAddress(),
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: big.NewInt(time.Now().Add(permitValidity).Unix()),
+	}
+	if err := SignPermit(key, domainSeparator, permit); err != nil {
+		return nil, err
+	}
+
+	return permit, nil
+}
+
+func SetLotteryPaymentToken(paymentToken common.Address, ticketPrice *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.SetPaymentToken(transactionOptions, paymentToken, ticketPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set lottery payment token: %w", err)
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// watchPoll is how often watchers check for new blocks on nodes without a
+// head subscription.
+var watchPoll = 2 * time.Second
+
+type LotteryEventKind string
+
+const (
+	PlayerEnteredEvent  LotteryEventKind = "PlayerEntered"
+	WinnerPickedEvent   LotteryEventKind = "WinnerPicked"
+	RoundCancelledEvent LotteryEventKind = "RoundCancelled"
+
+	// NewRoundEvent follows the end of a round in the websocket feed. The
+	// new round is open from the same block, and starts its clock with
+	// the first entry.
+	NewRoundEvent LotteryEventKind = "NewRound"
+
+	// ReorgEvent tells watchers to discard every event above its block.
+	ReorgEvent LotteryEventKind = "Reorg"
+)
+
+// LotteryEvent is a decoded lottery event as pushed to watchers. Player is
+// the entering player or the winner, and Amount the stake, the prize or
+// the refund.
+type LotteryEvent struct {
+	Kind        LotteryEventKind `json:"kind"`
+	Lottery     common.Address   `json:"lottery"`
+	BlockNumber uint64           `json:"blockNumber"`
+	BlockHash   common.Hash      `json:"blockHash"`
+	TxHash      common.Hash      `json:"transactionHash,omitempty"`
+	LogIndex    uint             `json:"logIndex"`
+	Player      *common.Address  `json:"player,omitempty"`
+	Amount      *big.Int         `json:"amount,omitempty"`
+
+	// Replayed is set on the events replayed from the start block, before
+	// new blocks are followed.
+	Replayed bool `json:"-"`
+}
+
+// WatchLotteryEvents calls handle with the events of the given lotteries
+// until the context is cancelled or handle fails. With a start block the
+// events from there up to the head are replayed first, then new blocks are
+// followed as they arrive.
+func WatchLotteryEvents(ctx context.Context, lotteries []common.Address, fromBlock *uint64, handle func(LotteryEvent) error) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	lotteryAbi, err := loadAbi("Lottery")
+	if err != nil {
+		return err
+	}
+
+	emit := func(logs []types.Log, replayed bool) error {
+		for _, eventLog := range logs {
+			event, ok, err := decodeLotteryEvent(lotteryAbi, eventLog)
+			if err != nil {
+				return err
+			}
+			if ok {
+				event.Replayed = replayed
+				if err := handle(event); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	follower := NewBlockFollower(client, 0, watchPoll)
+	if fromBlock != nil {
+		head, err := replayLotteryEvents(ctx, client, lotteries, *fromBlock, func(logs []types.Log) error {
+			return emit(logs, true)
+		})
+		if err != nil {
+			return err
+		}
+		follower.Resume([]BlockRef{head})
+	}
+
+	return follower.Follow(ctx, func(event FollowerEvent) error {
+		switch event.Kind {
+		case NewBlock:
+			hash := event.Block.Hash
+			logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &hash, Addresses: lotteries})
+			if err != nil {
+				return fmt.Errorf("failed to fetch logs for block %d: %w", event.Block.Number, err)
+			}
+			return emit(logs, false)
+		case Reorg:
+			return handle(LotteryEvent{Kind: ReorgEvent, BlockNumber: event.Block.Number, BlockHash: event.Block.Hash})
+		}
+		return nil
+	})
+}
+
+// replayLotteryEvents emits the events from a block up to the head in
+// batched ranges, and returns the head it stopped at.
+func replayLotteryEvents(ctx context.Context, client *ethclient.Client, lotteries []common.Address, fromBlock uint64, emit func([]types.Log) error) (BlockRef, error) {
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return BlockRef{}, fmt.Errorf("failed to fetch head: %w", err)
+	}
+
+	for from := fromBlock; from <= head.Number.Uint64(); from += defaultIndexerBatch {
+		to := from + defaultIndexerBatch - 1
+		if to > head.Number.Uint64() {
+			to = head.Number.Uint64()
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: lotteries,
+		})
+		if err != nil {
+			return BlockRef{}, fmt.Errorf("failed to fetch logs for blocks %d to %d: %w", from, to, err)
+		}
+		if err := emit(logs); err != nil {
+			return BlockRef{}, err
+		}
+	}
+
+	return headerRef(head), nil
+}
+
+// decodeLotteryEvent reports false for events watchers are not told about.
+func decodeLotteryEvent(lotteryAbi *abi.ABI, eventLog types.Log) (LotteryEvent, bool, error) {
+	if eventLog.Removed {
+		return LotteryEvent{}, false, nil
+	}
+
+	event, fields, err := decodeEvent(lotteryAbi, &eventLog)
+	if event == nil {
+		return LotteryEvent{}, false, nil
+	}
+	if err != nil {
+		return LotteryEvent{}, false, fmt.Errorf("failed to decode %s event in %v: %w", event.Name, eventLog.TxHash, err)
+	}
+
+	decoded := LotteryEvent{
+		Lottery:     eventLog.Address,
+		BlockNumber: eventLog.BlockNumber,
+		BlockHash:   eventLog.BlockHash,
+		TxHash:      eventLog.TxHash,
+		LogIndex:    eventLog.Index,
+	}
+	switch event.Name {
+	case "PlayerEntered":
+		player := fields["player"].(common.Address)
+		decoded.Kind, decoded.Player, decoded.Amount = PlayerEnteredEvent, &player, fields["stake"].(*big.Int)
+	case "WinnerPicked":
+		winner := fields["winner"].(common.Address)
+		decoded.Kind, decoded.Player, decoded.Amount = WinnerPickedEvent, &winner, fields["amount"].(*big.Int)
+	case "RoundCancelled":
+		decoded.Kind, decoded.Amount = RoundCancelledEvent, fields["refunded"].(*big.Int)
+	default:
+		return LotteryEvent{}, false, nil
+	}
+	return decoded, true, nil
+}
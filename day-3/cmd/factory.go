@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"day-3/factory"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func lotteryDeployFactoryCommand() *cobra.Command {
+	var implementation string
+
+	command := &cobra.Command{
+		Use: "deploy-factory",
+		Run: func(cmd *cobra.Command, args []string) {
+			implementationAddress := getLotteryAddress()
+			if implementation != "" {
+				implementationAddress = parseAddressArg(implementation)
+			}
+
+			log.Println("deploying lottery factory for implementation ", implementationAddress, "...")
+			_, address, err := DeployLotteryFactory(implementationAddress)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("factory deployed to address: ", address)
+			log.Println("set LOTTERY_FACTORY_ADDRESS to create lotteries with it")
+		},
+	}
+	command.Flags().StringVar(&implementation, "implementation", "", "lottery every clone delegates to (defaults to the selected lottery)")
+	return command
+}
+
+func lotteryCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "create",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("creating a new lottery...")
+			created, err := CreateLottery()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery ", created.Id, " created at address: ", created.Lottery)
+			log.Println("use it with --lottery ", created.Id)
+		},
+	}
+}
+
+func lotteryListCommand() *cobra.Command {
+	var creator string
+
+	command := &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			creatorAddress := getAccountAddress()
+			if creator != "" {
+				creatorAddress = parseAddressArg(creator)
+			}
+
+			lotteries, err := ListLotteriesByCreator(creatorAddress)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println(len(lotteries), " lotteries created by ", creatorAddress)
+			for _, lottery := range lotteries {
+				log.Println("lottery ", lottery.Id, ": ", lottery.Lottery)
+			}
+		},
+	}
+	command.Flags().StringVar(&creator, "creator", "", "list the lotteries of this creator (defaults to ACCOUNT_ADDRESS)")
+	return command
+}
+
+func lotteryResolveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "resolve <id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id, ok := new(big.Int).SetString(args[0], 10)
+			if !ok {
+				log.Fatalf("invalid lottery id: %s", args[0])
+			}
+
+			address, err := ResolveLottery(id)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery ", id, " is at address: ", address)
+		},
+	}
+}
+
+// LotteryCreated is a lottery created through the factory.
+type LotteryCreated struct {
+	Id      *big.Int
+	Lottery common.Address
+}
+
+// selectLottery points the lottery commands at the --lottery flag, which
+// takes either a contract address or a factory id.
+func selectLottery(selection string) error {
+	if selection == "" {
+		return nil
+	}
+
+	if common.IsHexAddress(selection) {
+		address := common.HexToAddress(selection)
+		selectedLottery = &address
+		return nil
+	}
+
+	id, ok := new(big.Int).SetString(selection, 10)
+	if !ok {
+		return fmt.Errorf("--lottery must be an address or a factory id: %s", selection)
+	}
+
+	address, err := ResolveLottery(id)
+	if err != nil {
+		return err
+	}
+	selectedLottery = &address
+	return nil
+}
+
+// DeployLotteryFactory deploys a factory cloning the given implementation
+// and records it in the registry.
+func DeployLotteryFactory(implementation common.Address) (*factory.LotteryFactory, common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	_, transaction, _, err := factory.DeployLotteryFactory(transactionOptions, client, implementation)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to deploy factory: %w", err)
+	}
+
+	address, err := bind.WaitDeployed(context.Background(), client, transaction)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("error occured while waiting for factory to deploy: %w", err)
+	}
+
+	err = recordDeployment(client, "LotteryFactory", Deployment{Address: address, TransactionHash: transaction.Hash()})
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	contract, err := factory.NewLotteryFactory(address, client)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to bind factory contract: %w", err)
+	}
+
+	return contract, address, nil
+}
+
+// CreateLottery waits for the creation to be mined so that the new
+// lottery's id and address can be read from its LotteryCreated event.
+func CreateLottery() (*LotteryCreated, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	factoryContract, err := getFactoryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := factoryContract.CreateLottery(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lottery: %w", err)
+	}
+
+	receipt, err := bind.WaitMined(context.Background(), client, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error occured while waiting for lottery creation: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("lottery creation %v reverted", transaction.Hash())
+	}
+
+	for _, receiptLog := range receipt.Logs {
+		created, err := factoryContract.ParseLotteryCreated(*receiptLog)
+		if err == nil {
+			return &LotteryCreated{Id: created.Id, Lottery: created.Lottery}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no LotteryCreated event in transaction %v", transaction.Hash())
+}
+
+func ListLotteriesByCreator(creator common.Address) ([]LotteryCreated, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	factoryContract, err := getFactoryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := factoryContract.GetLotteriesByCreator(nil, creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lotteries by creator: %w", err)
+	}
+
+	lotteries := make([]LotteryCreated, 0, len(ids))
+	for _, id := range ids {
+		address, err := factoryContract.Lotteries(nil, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve lottery %v: %w", id, err)
+		}
+		lotteries = append(lotteries, LotteryCreated{Id: id, Lottery: address})
+	}
+
+	return lotteries, nil
+}
+
+func ResolveLottery(id *big.Int) (common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	factoryContract, err := getFactoryContract(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	count, err := factoryContract.LotteryCount(nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch lottery count: %w", err)
+	}
+	if id.Sign() < 0 || id.Cmp(count) >= 0 {
+		return common.Address{}, fmt.Errorf("lottery %v does not exist, the factory has created %v", id, count)
+	}
+
+	address, err := factoryContract.Lotteries(nil, id)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve lottery %v: %w", id, err)
+	}
+
+	return address, nil
+}
+
+func getFactoryContract(client *ethclient.Client) (*factory.LotteryFactory, error) {
+	factoryContract, err := factory.NewLotteryFactory(common.HexToAddress(os.Getenv("LOTTERY_FACTORY_ADDRESS")), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind factory contract: %w", err)
+	}
+	return factoryContract, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"day-3/factory"
+	"day-3/lottery"
+	"testing"
+)
+
+func TestFactoryCreatesLotteriesManagedByTheirCreator(t *testing.T) {
+	chain := newTestChain(t, 3)
+	implementation, _ := chain.deployLottery(0)
+	_, transaction, factoryContract, err := factory.DeployLotteryFactory(chain.transactor(0), chain, implementation)
+	chain.mine(transaction, err)
+
+	var created []*factory.LotteryFactoryLotteryCreated
+	for _, creator := range []int{1, 2, 1} {
+		receipt := chain.mine(factoryContract.CreateLottery(chain.transactor(creator)))
+		for _, receiptLog := range receipt.Logs {
+			if event, err := factoryContract.ParseLotteryCreated(*receiptLog); err == nil {
+				created = append(created, event)
+			}
+		}
+	}
+	if len(created) != 3 {
+		t.Fatalf("factory logged %d creations, want 3", len(created))
+	}
+
+	for i, event := range created {
+		if event.Id.Int64() != int64(i) {
+			t.Fatalf("creation %d was given id %v", i, event.Id)
+		}
+		address, err := factoryContract.Lotteries(nil, event.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if address != event.Lottery {
+			t.Fatalf("lottery %v resolves to %v, logged %v", event.Id, address, event.Lottery)
+		}
+	}
+
+	ids, err := factoryContract.GetLotteriesByCreator(nil, chain.accounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0].Int64() != 0 || ids[1].Int64() != 2 {
+		t.Fatalf("creator's lotteries are %v, want [0 2]", ids)
+	}
+
+	// each clone is an independent lottery run by its creator
+	clone, err := lottery.NewLottery(created[1].Lottery, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := clone.Manager(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manager != chain.accounts[2] {
+		t.Fatalf("clone is managed by %v, want its creator %v", manager, chain.accounts[2])
+	}
+	if _, err := clone.Initialize(chain.transactor(1), chain.accounts[1]); err == nil {
+		t.Fatal("initializing a created lottery again succeeded")
+	}
+	chain.enter(clone, 1, "1")
+	if _, err := clone.PickWinner(chain.transactor(1)); err == nil {
+		t.Fatal("a player who did not create the lottery picked its winner")
+	}
+	chain.mine(clone.PickWinner(chain.transactor(2)))
+
+	// a clone never reads the ERC-1967 slot, so even its manager cannot
+	// upgrade it
+	newImplementation, _ := chain.deployLottery(0)
+	if _, err := clone.UpgradeTo(chain.transactor(2), newImplementation); err == nil {
+		t.Fatal("a clone's manager upgraded it")
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// feedBuffer is how many new events a websocket client may fall behind
+	// before it is disconnected. Replayed events wait for the client instead.
+	feedBuffer = 256
+
+	feedWriteWait    = 10 * time.Second
+	feedPongWait     = 60 * time.Second
+	feedPingInterval = feedPongWait / 2
+)
+
+// errSlowClient stops the watcher of a client whose buffer is full.
+var errSlowClient = errors.New("client is too slow")
+
+// handleEvents upgrades GET /api/lottery/events to a websocket pushing
+// decoded events as JSON. Clients choose the lotteries with one or more
+// lottery parameters, and replay from a block with fromBlock.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+
+	lotteries := []common.Address{getLotteryAddress()}
+	if len(query["lottery"]) > 0 {
+		lotteries = nil
+		for _, lottery := range query["lottery"] {
+			if !common.IsHexAddress(lottery) {
+				return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid lottery address %q", lottery)}
+			}
+			lotteries = append(lotteries, common.HexToAddress(lottery))
+		}
+	}
+
+	var fromBlock *uint64
+	if value := query.Get("fromBlock"); value != "" {
+		block, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid fromBlock %q", value)}
+		}
+		fromBlock = &block
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: s.checkOrigin}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already answered the request
+		return nil, nil
+	}
+	s.streamEvents(r.Context(), conn, lotteries, fromBlock)
+	return nil, nil
+}
+
+// checkOrigin accepts browsers on the origin allowed for CORS, besides
+// the server's own.
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || s.allowOrigin == "*" || origin == s.allowOrigin {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+// streamEvents writes the events to the connection until the client goes
+// away or the context is cancelled. Events are buffered between the
+// watcher and the connection. The replay from fromBlock waits for room in
+// the buffer, however long it is, but a client that lets new events fill
+// it up is closed with 1013 (try again later), and can reconnect from the
+// block of the last event it received.
+func (s *Server) streamEvents(ctx context.Context, conn *websocket.Conn, lotteries []common.Address, fromBlock *uint64) {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// clients send nothing but control frames, reading processes them and
+	// notices when the client leaves
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(feedPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(feedPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	events := make(chan LotteryEvent, feedBuffer)
+	watched := make(chan error, 1)
+	go func() {
+		watched <- WatchLotteryEvents(ctx, lotteries, fromBlock, func(event LotteryEvent) error {
+			for _, message := range feedMessages(event) {
+				if event.Replayed {
+					select {
+					case events <- message:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+					continue
+				}
+				select {
+				case events <- message:
+				default:
+					return errSlowClient
+				}
+			}
+			return nil
+		})
+	}()
+
+	ping := time.NewTicker(feedPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			closeFeed(conn, websocket.CloseGoingAway, "feed closed")
+			return
+		case err := <-watched:
+			if errors.Is(err, errSlowClient) {
+				closeFeed(conn, websocket.CloseTryAgainLater, "client is too slow, reconnect with fromBlock")
+			} else if err != nil && ctx.Err() == nil {
+				log.Println("event feed failed: ", err)
+				closeFeed(conn, websocket.CloseInternalServerErr, "event feed failed")
+			}
+			return
+		case event := <-events:
+			conn.SetWriteDeadline(time.Now().Add(feedWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(feedWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// feedMessages adds a NewRound message after the end of a round.
+func feedMessages(event LotteryEvent) []LotteryEvent {
+	if event.Kind != WinnerPickedEvent && event.Kind != RoundCancelledEvent {
+		return []LotteryEvent{event}
+	}
+	return []LotteryEvent{event, {
+		Kind:        NewRoundEvent,
+		Lottery:     event.Lottery,
+		BlockNumber: event.BlockNumber,
+		BlockHash:   event.BlockHash,
+		TxHash:      event.TxHash,
+		LogIndex:    event.LogIndex,
+	}}
+}
+
+func closeFeed(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(feedWriteWait))
+}
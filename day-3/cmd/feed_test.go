@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"day-3/lottery"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+)
+
+// feedTest serves the API of a served lottery over HTTP, through a listener
+// that can stall the server's writes as a client that stopped reading does
+// once the socket buffers are full.
+type feedTest struct {
+	*servedLottery
+	url   string
+	stall sync.Mutex
+}
+
+func newFeedTest(t *testing.T) *feedTest {
+	t.Helper()
+	served := newServedLottery(t, "")
+	inModuleRoot(t)
+	poll := watchPoll
+	watchPoll = 10 * time.Millisecond
+	t.Cleanup(func() { watchPoll = poll })
+
+	test := &feedTest{servedLottery: served}
+	server := httptest.NewUnstartedServer(served.server.Handler())
+	server.Listener = &stallingListener{Listener: server.Listener, stall: &test.stall}
+	server.Start()
+	t.Cleanup(server.Close)
+	test.url = "ws" + strings.TrimPrefix(server.URL, "http") + "/api/lottery/events"
+	return test
+}
+
+type stallingListener struct {
+	net.Listener
+	stall *sync.Mutex
+}
+
+func (l *stallingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	return &stallingConn{Conn: conn, stall: l.stall}, err
+}
+
+type stallingConn struct {
+	net.Conn
+	stall *sync.Mutex
+}
+
+func (c *stallingConn) Write(content []byte) (int, error) {
+	c.stall.Lock()
+	c.stall.Unlock()
+	return c.Conn.Write(content)
+}
+
+// dial connects to the feed, replaying from the block so that no event is
+// missed between the connection and the first poll.
+func (f *feedTest) dial(fromBlock uint64, lotteries ...common.Address) *websocket.Conn {
+	f.t.Helper()
+	query := fmt.Sprintf("?fromBlock=%d", fromBlock)
+	for _, address := range lotteries {
+		query += "&lottery=" + address.Hex()
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(f.url+query, nil)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func (f *feedTest) read(conn *websocket.Conn) LotteryEvent {
+	f.t.Helper()
+	var event LotteryEvent
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(&event); err != nil {
+		f.t.Fatal(err)
+	}
+	return event
+}
+
+func (f *feedTest) expect(conn *websocket.Conn, kind LotteryEventKind, lotteryAddress common.Address) LotteryEvent {
+	f.t.Helper()
+	event := f.read(conn)
+	if event.Kind != kind || event.Lottery != lotteryAddress {
+		f.t.Fatalf("feed sent %s of %v, want %s of %v", event.Kind, event.Lottery, kind, lotteryAddress)
+	}
+	return event
+}
+
+// enterMany has account 1 enter the lottery count times, in a few blocks.
+func (f *feedTest) enterMany(contract *lottery.Lottery, count int) {
+	f.t.Helper()
+	for i := 0; i < count; i++ {
+		if _, err := contract.Enter(f.paying(1, mustParseEther(f.t, "0.02"))); err != nil {
+			f.t.Fatal(err)
+		}
+		if i%100 == 99 || i == count-1 {
+			f.Commit()
+		}
+	}
+}
+
+func TestFeedSendsOnlyTheChosenLotteries(t *testing.T) {
+	test := newFeedTest(t)
+	other, otherContract := test.deployLottery(0)
+	from := test.head().Number
+
+	conn := test.dial(from, other)
+	test.enter(test.contract, 1, "1")
+	test.enter(otherContract, 2, "1")
+
+	entry := test.expect(conn, PlayerEnteredEvent, other)
+	if *entry.Player != test.accounts[2] || entry.Amount.Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("entry is %+v", entry)
+	}
+
+	// both lotteries at once
+	both := test.dial(from, test.address, other)
+	test.expect(both, PlayerEnteredEvent, test.address)
+	test.expect(both, PlayerEnteredEvent, other)
+}
+
+func TestFeedResumesFromABlock(t *testing.T) {
+	test := newFeedTest(t)
+	test.enter(test.contract, 1, "1")
+	resumed := test.head().Number + 1
+	test.enter(test.contract, 2, "2")
+
+	conn := test.dial(resumed)
+	entry := test.expect(conn, PlayerEnteredEvent, test.address)
+	if entry.BlockNumber != resumed || *entry.Player != test.accounts[2] {
+		t.Fatalf("feed resumed with %+v, want the entry of block %d", entry, resumed)
+	}
+
+	// new blocks follow the replay
+	test.enter(test.contract, 1, "1")
+	if entry := test.expect(conn, PlayerEnteredEvent, test.address); entry.BlockNumber != resumed+1 {
+		t.Fatalf("feed followed with %+v", entry)
+	}
+}
+
+func TestFeedStartsANewRoundAfterTheWinnerIsPicked(t *testing.T) {
+	test := newFeedTest(t)
+	conn := test.dial(test.head().Number)
+	test.enter(test.contract, 1, "1")
+	test.mine(test.contract.PickWinner(test.transactor(0)))
+
+	test.expect(conn, PlayerEnteredEvent, test.address)
+	picked := test.expect(conn, WinnerPickedEvent, test.address)
+	if *picked.Player != test.accounts[1] || picked.Amount.Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("winner picked is %+v", picked)
+	}
+	round := test.expect(conn, NewRoundEvent, test.address)
+	if round.BlockNumber != picked.BlockNumber || round.TxHash != picked.TxHash || round.Player != nil {
+		t.Fatalf("new round is %+v after %+v", round, picked)
+	}
+}
+
+func TestFeedWaitsForASlowClientDuringTheReplay(t *testing.T) {
+	test := newFeedTest(t)
+	from := test.head().Number
+	test.enterMany(test.contract, feedBuffer+50)
+
+	conn := test.dial(from)
+	test.stall.Lock()
+	time.Sleep(100 * time.Millisecond)
+	test.stall.Unlock()
+
+	for i := 0; i < feedBuffer+50; i++ {
+		test.expect(conn, PlayerEnteredEvent, test.address)
+	}
+}
+
+func TestFeedDisconnectsASlowClient(t *testing.T) {
+	test := newFeedTest(t)
+	conn := test.dial(test.head().Number)
+	test.enter(test.contract, 1, "1")
+	test.expect(conn, PlayerEnteredEvent, test.address)
+
+	// new events beyond the buffer are not waited for
+	test.stall.Lock()
+	test.enterMany(test.contract, feedBuffer+50)
+	time.Sleep(100 * time.Millisecond)
+	test.stall.Unlock()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var event LotteryEvent
+		err := conn.ReadJSON(&event)
+		if websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("feed ended with %v, want close code %d", err, websocket.CloseTryAgainLater)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MaxFeeBasisPoints mirrors MAX_FEE_BASIS_POINTS in Lottery.sol.
+const MaxFeeBasisPoints = 10000
+
+type LotteryFees struct {
+	FeeBasisPoints *big.Int
+	Treasury       common.Address
+	AccruedFees    *big.Int
+
+	// only set while tickets are priced in a token
+	PaymentToken     common.Address
+	TokenDecimals    int
+	AccruedTokenFees *big.Int
+}
+
+func lotteryFeesCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use: "fees",
+	}
+	command.AddCommand(lotteryFeesShowCommand())
+	command.AddCommand(lotteryFeesWithdrawCommand())
+	command.AddCommand(lotteryFeesSetCommand())
+	return command
+}
+
+func lotteryFeesShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "show",
+		Run: func(cmd *cobra.Command, args []string) {
+			fees, err := GetLotteryFees()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("manager fee in basis points: ", fees.FeeBasisPoints)
+			log.Println("treasury address: ", fees.Treasury)
+			log.Println("accrued fees: ", fees.AccruedFees)
+			if fees.PaymentToken != (common.Address{}) {
+				log.Println("accrued fees in token ", fees.PaymentToken, ": ", FormatUnits(fees.AccruedTokenFees, fees.TokenDecimals))
+			}
+
+			pot, err := GetLotteryPot()
+			if err != nil {
+				log.Fatal(err)
+			}
+			prize, fee := SplitPot(pot, fees.FeeBasisPoints)
+			log.Println("current pot would pay out: ", prize, " with a fee of: ", fee)
+		},
+	}
+}
+
+func lotteryFeesWithdrawCommand() *cobra.Command {
+	var feeToken string
+
+	command := &cobra.Command{
+		Use: "withdraw",
+		Run: func(cmd *cobra.Command, args []string) {
+			if feeToken != "" {
+				log.Println("withdrawing token fees to treasury...")
+				transaction, err := WithdrawLotteryTokenFees(parseAddressArg(feeToken))
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println("token fees withdrawn in transaction hash: ", transaction.Hash())
+				return
+			}
+
+			log.Println("withdrawing fees to treasury...")
+			transaction, err := WithdrawLotteryFees()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("fees withdrawn in transaction hash: ", transaction.Hash())
+		},
+	}
+	command.Flags().StringVar(&feeToken, "token", "", "withdraw the fees accrued in this payment token instead of ether")
+	return command
+}
+
+func lotteryFeesSetCommand() *cobra.Command {
+	var treasury string
+
+	command := &cobra.Command{
+		Use:  "set <basis-points>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			feeBasisPoints, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil || feeBasisPoints > MaxFeeBasisPoints {
+				log.Fatalf("fee must be between 0 and %d basis points", MaxFeeBasisPoints)
+			}
+
+			treasuryAddress := getAccountAddress()
+			if treasury != "" {
+				if !common.IsHexAddress(treasury) {
+					log.Fatalf("invalid treasury address: %s", treasury)
+				}
+				treasuryAddress = common.HexToAddress(treasury)
+			}
+
+			log.Println("setting manager fee...")
+			transaction, err := SetLotteryFee(new(big.Int).SetUint64(feeBasisPoints), treasuryAddress)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("manager fee set in transaction hash: ", transaction.Hash())
+		},
+	}
+	command.Flags().StringVar(&treasury, "treasury", "", "address the fees are paid to (defaults to ACCOUNT_ADDRESS)")
+	return command
+}
+
+// SplitPot divides a pot into the winner's prize and the manager fee using
+// the same integer arithmetic as pickWinner, so the fee rounds down.
+func SplitPot(pot *big.Int, feeBasisPoints *big.Int) (*big.Int, *big.Int) {
+	fee := new(big.Int).Mul(pot, feeBasisPoints)
+	fee.Quo(fee, big.NewInt(MaxFeeBasisPoints))
+	prize := new(big.Int).Sub(pot, fee)
+	return prize, fee
+}
+
+func GetLotteryFees() (*LotteryFees, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	feeBasisPoints, err := lotteryContract.FeeBasisPoints(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lottery fee: %w", err)
+	}
+
+	treasury, err := lotteryContract.Treasury(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lottery treasury: %w", err)
+	}
+
+	accruedFees, err := lotteryContract.AccruedFees(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch accrued fees: %w", err)
+	}
+
+	paymentToken, err := lotteryContract.PaymentToken(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lottery payment token: %w", err)
+	}
+
+	accruedTokenFees := new(big.Int)
+	var tokenDecimals int
+	if paymentToken != (common.Address{}) {
+		accruedTokenFees, err = lotteryContract.AccruedTokenFees(nil, paymentToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch accrued token fees: %w", err)
+		}
+		tokenDecimals, err = readTokenDecimals(client, paymentToken, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &LotteryFees{
+		FeeBasisPoints:   feeBasisPoints,
+		Treasury:         treasury,
+		AccruedFees:      accruedFees,
+		PaymentToken:     paymentToken,
+		TokenDecimals:    tokenDecimals,
+		AccruedTokenFees: accruedTokenFees,
+	}, nil
+}
+
+func SetLotteryFee(feeBasisPoints *big.Int, treasury common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.SetFee(transactionOptions, feeBasisPoints, treasury)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set lottery fee: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func WithdrawLotteryFees() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.WithdrawFees(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw lottery fees: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func WithdrawLotteryTokenFees(feeToken common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.WithdrawTokenFees(transactionOptions, feeToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw lottery token fees: %w", err)
+	}
+
+	return transaction, nil
+}
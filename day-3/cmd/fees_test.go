@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSplitPot(t *testing.T) {
+	tests := []struct {
+		name      string
+		pot       string
+		basis     int64
+		wantPrize string
+		wantFee   string
+	}{
+		{"no fee", "1000000", 0, "1000000", "0"},
+		{"whole pot", "1000000", 10000, "0", "1000000"},
+		{"two and a half percent", "1000000", 250, "975000", "25000"},
+		{"fee rounds down", "9999", 1, "9999", "0"},
+		{"fee rounds down to a unit", "19999", 1, "19998", "1"},
+		{"odd pot at half", "3", 5000, "2", "1"},
+		{"empty pot", "0", 500, "0", "0"},
+		{"one wei below a basis point", "10000000000000009999", 1, "9999000000000009999", "1000000000000000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pot, _ := new(big.Int).SetString(test.pot, 10)
+			prize, fee := SplitPot(pot, big.NewInt(test.basis))
+			if prize.String() != test.wantPrize || fee.String() != test.wantFee {
+				t.Fatalf("SplitPot(%v, %v) = %v, %v, want %v, %v", test.pot, test.basis, prize, fee, test.wantPrize, test.wantFee)
+			}
+			if new(big.Int).Add(prize, fee).Cmp(pot) != 0 {
+				t.Fatalf("prize and fee do not add up to the pot")
+			}
+		})
+	}
+}
+
+// TestPickWinnerSplitsPotLikeSplitPot checks the contract rounds exactly as
+// SplitPot predicts, so the fees command reports what will be paid.
+func TestPickWinnerSplitsPotLikeSplitPot(t *testing.T) {
+	tests := []struct {
+		name   string
+		basis  int64
+		stakes []string
+	}{
+		{"no fee", 0, []string{"1"}},
+		{"whole pot", 10000, []string{"1"}},
+		{"two and a half percent", 250, []string{"0.5", "1.25"}},
+		{"fee rounds down", 3, []string{"0.010000000000000001"}},
+		{"odd pot", 3333, []string{"0.010000000000000001", "0.010000000000000002", "0.010000000000000004"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestChain(t, 3)
+			lotteryAddress, lotteryContract := chain.deployLottery(0)
+			treasury := chain.accounts[2]
+			chain.mine(lotteryContract.SetFee(chain.transactor(0), big.NewInt(test.basis), treasury))
+
+			pot := new(big.Int)
+			for _, stake := range test.stakes {
+				chain.enter(lotteryContract, 1, stake)
+				pot.Add(pot, mustParseEther(t, stake))
+			}
+			chain.mine(lotteryContract.PickWinner(chain.transactor(0)))
+
+			wantPrize, wantFee := SplitPot(pot, big.NewInt(test.basis))
+			prize, err := lotteryContract.PendingWithdrawals(nil, chain.accounts[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			fee, err := lotteryContract.AccruedFees(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prize.Cmp(wantPrize) != 0 || fee.Cmp(wantFee) != 0 {
+				t.Fatalf("pot %v paid %v and %v in fees, want %v and %v", pot, prize, fee, wantPrize, wantFee)
+			}
+
+			if fee.Sign() == 0 {
+				if _, err := lotteryContract.WithdrawFees(chain.transactor(0)); err == nil {
+					t.Fatal("withdrawing no fees succeeded")
+				}
+				return
+			}
+			before := chain.balance(treasury)
+			chain.mine(lotteryContract.WithdrawFees(chain.transactor(0)))
+			if paid := new(big.Int).Sub(chain.balance(treasury), before); paid.Cmp(wantFee) != 0 {
+				t.Fatalf("treasury received %v, want %v", paid, wantFee)
+			}
+			if left := chain.balance(lotteryAddress); left.Cmp(wantPrize) != 0 {
+				t.Fatalf("lottery holds %v after paying fees, want the prize %v", left, wantPrize)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// defaultConfirmations is how deep a block must be before it is treated
+// as final.
+const defaultConfirmations = 12
+
+// BlockRef identifies a block by number and hash.
+type BlockRef struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+func headerRef(header *types.Header) BlockRef {
+	return BlockRef{Number: header.Number.Uint64(), Hash: header.Hash()}
+}
+
+type FollowerEventKind int
+
+const (
+	// NewBlock is a block extending the followed chain.
+	NewBlock FollowerEventKind = iota
+	// Reorg drops every followed block above the common ancestor.
+	Reorg
+	// Finalized is a block that is now deep enough to be treated as final.
+	Finalized
+)
+
+func (k FollowerEventKind) String() string {
+	switch k {
+	case NewBlock:
+		return "new block"
+	case Reorg:
+		return "reorg"
+	case Finalized:
+		return "finalized"
+	}
+	return fmt.Sprintf("FollowerEventKind(%d)", int(k))
+}
+
+// FollowerEvent is a signal from the BlockFollower. Block is the new or
+// finalized block, or the common ancestor of a reorg, in which case
+// Removed lists the orphaned blocks from the newest down.
+type FollowerEvent struct {
+	Kind    FollowerEventKind
+	Block   BlockRef
+	Header  *types.Header
+	Removed []BlockRef
+}
+
+// FollowerBackend is the part of ethclient the follower uses, which the
+// simulated backend also implements.
+type FollowerBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// BlockFollower follows the canonical chain and turns it into new block,
+// reorg and finalized signals. It is woken by a head subscription where
+// the node supports one, and polls otherwise, or when the subscription
+// drops. Every block between the start and the head is reported, one at a
+// time and in order.
+type BlockFollower struct {
+	client        FollowerBackend
+	confirmations uint64
+	poll          time.Duration
+
+	// history holds the followed blocks from the last finalized one up to
+	// the tip, oldest first. It is searched for the common ancestor on a
+	// reorg.
+	history   []BlockRef
+	finalized *BlockRef
+}
+
+func NewBlockFollower(client FollowerBackend, confirmations uint64, poll time.Duration) *BlockFollower {
+	return &BlockFollower{client: client, confirmations: confirmations, poll: poll}
+}
+
+// Resume continues following from blocks handled before, such as the ones
+// a consumer stored before a restart. Without it the follower starts at the
+// current head. Reorgs that happened in the meantime are reported on the
+// first sync.
+func (f *BlockFollower) Resume(blocks []BlockRef) {
+	f.history = append([]BlockRef{}, blocks...)
+	sort.Slice(f.history, func(i, j int) bool {
+		return f.history[i].Number < f.history[j].Number
+	})
+	f.finalized = nil
+}
+
+// Tip is the newest followed block, or nil before the first sync.
+func (f *BlockFollower) Tip() *BlockRef {
+	if len(f.history) == 0 {
+		return nil
+	}
+	tip := f.history[len(f.history)-1]
+	return &tip
+}
+
+// Follow calls handle for every signal until the context is cancelled or
+// handle fails. A block is only taken as followed once handle accepted it,
+// so the same signal comes again after a failure if Follow is called again.
+// Node errors are logged and retried on the next wake up.
+func (f *BlockFollower) Follow(ctx context.Context, handle func(FollowerEvent) error) error {
+	heads := make(chan *types.Header, 16)
+	subscription, err := f.client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		log.Println("new head subscription unavailable, polling every ", f.poll, ": ", err)
+		subscription = nil
+	}
+	defer func() {
+		if subscription != nil {
+			subscription.Unsubscribe()
+		}
+	}()
+
+	ticker := time.NewTicker(f.poll)
+	defer ticker.Stop()
+
+	for {
+		if err := f.Sync(ctx, handle); err != nil {
+			return err
+		}
+
+		var dropped <-chan error
+		if subscription != nil {
+			dropped = subscription.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heads:
+		case <-ticker.C:
+		case err := <-dropped:
+			log.Println("new head subscription dropped, polling every ", f.poll, ": ", err)
+			subscription = nil
+		}
+	}
+}
+
+// Sync catches up with the current head once, reporting a reorg first if
+// the followed tip is no longer canonical. Only errors from handle are
+// returned.
+func (f *BlockFollower) Sync(ctx context.Context, handle func(FollowerEvent) error) error {
+	head, err := f.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		log.Println("failed to fetch head: ", err)
+		return nil
+	}
+
+	if len(f.history) == 0 {
+		if err := handle(FollowerEvent{Kind: NewBlock, Block: headerRef(head), Header: head}); err != nil {
+			return err
+		}
+		f.history = append(f.history, headerRef(head))
+		return f.finalize(handle)
+	}
+
+	if err := f.reconcile(ctx, head, handle); err != nil {
+		if errors.As(err, new(*nodeError)) {
+			log.Println(err)
+			return nil
+		}
+		return err
+	}
+
+	for number := f.history[len(f.history)-1].Number + 1; number <= head.Number.Uint64(); number++ {
+		header := head
+		if number != head.Number.Uint64() {
+			header, err = f.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				log.Println("failed to fetch block ", number, ": ", err)
+				return nil
+			}
+		}
+
+		// the chain moved while catching up, the next sync reports the reorg
+		if header.ParentHash != f.history[len(f.history)-1].Hash {
+			return nil
+		}
+
+		if err := handle(FollowerEvent{Kind: NewBlock, Block: headerRef(header), Header: header}); err != nil {
+			return err
+		}
+		f.history = append(f.history, headerRef(header))
+		if err := f.finalize(handle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reconcile reports a reorg if the followed tip is no longer canonical,
+// without catching up with the head. Consumers that resume far behind use
+// it before backfilling on their own.
+func (f *BlockFollower) Reconcile(ctx context.Context, handle func(FollowerEvent) error) error {
+	if len(f.history) == 0 {
+		return nil
+	}
+	head, err := f.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch head: %w", err)
+	}
+	return f.reconcile(ctx, head, handle)
+}
+
+// nodeError marks a failure to reach the node, as opposed to one returned
+// by the handler.
+type nodeError struct {
+	err error
+}
+
+func (e *nodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nodeError) Unwrap() error {
+	return e.err
+}
+
+func (f *BlockFollower) reconcile(ctx context.Context, head *types.Header, handle func(FollowerEvent) error) error {
+	ancestor, err := f.findAncestor(ctx, head)
+	if err != nil {
+		return &nodeError{err: err}
+	}
+	if tip := f.history[len(f.history)-1]; ancestor != tip {
+		return f.rollback(ancestor, handle)
+	}
+	return nil
+}
+
+// findAncestor returns the newest followed block that is still canonical.
+// When none is, the reorg is deeper than anything followed, and the block
+// before the oldest followed one stands in for the ancestor.
+func (f *BlockFollower) findAncestor(ctx context.Context, head *types.Header) (BlockRef, error) {
+	for i := len(f.history) - 1; i >= 0; i-- {
+		block := f.history[i]
+		if block.Number > head.Number.Uint64() {
+			continue
+		}
+		canonical, err := f.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.Number))
+		if err != nil {
+			return BlockRef{}, fmt.Errorf("failed to fetch block %d: %w", block.Number, err)
+		}
+		if canonical.Hash() == block.Hash {
+			return block, nil
+		}
+	}
+
+	number := uint64(0)
+	if oldest := f.history[0].Number; oldest > 0 {
+		number = oldest - 1
+	}
+	canonical, err := f.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return BlockRef{}, fmt.Errorf("failed to fetch block %d: %w", number, err)
+	}
+	log.Println("reorg reaches past every followed block, falling back to block ", number)
+	return headerRef(canonical), nil
+}
+
+func (f *BlockFollower) rollback(ancestor BlockRef, handle func(FollowerEvent) error) error {
+	var removed []BlockRef
+	kept := f.history[:0:0]
+	for i := len(f.history) - 1; i >= 0; i-- {
+		if f.history[i].Number > ancestor.Number {
+			removed = append(removed, f.history[i])
+		}
+	}
+	for _, block := range f.history {
+		if block.Number < ancestor.Number {
+			kept = append(kept, block)
+		}
+	}
+	kept = append(kept, ancestor)
+
+	if err := handle(FollowerEvent{Kind: Reorg, Block: ancestor, Removed: removed}); err != nil {
+		return err
+	}
+	f.history = kept
+	if f.finalized != nil && f.finalized.Number > ancestor.Number {
+		log.Println("reorg is deeper than ", f.confirmations, " confirmations, finalized block ", f.finalized.Number, " was orphaned")
+		f.finalized = &ancestor
+	}
+	return nil
+}
+
+// finalize reports the followed blocks that are now confirmations deep and
+// drops the history below the newest of them.
+func (f *BlockFollower) finalize(handle func(FollowerEvent) error) error {
+	tip := f.history[len(f.history)-1]
+	if tip.Number < f.confirmations {
+		return nil
+	}
+	final := tip.Number - f.confirmations
+
+	for _, block := range f.history {
+		if block.Number > final {
+			break
+		}
+		if f.finalized != nil && block.Number <= f.finalized.Number {
+			continue
+		}
+		if err := handle(FollowerEvent{Kind: Finalized, Block: block}); err != nil {
+			return err
+		}
+		finalized := block
+		f.finalized = &finalized
+	}
+
+	if f.finalized != nil {
+		for len(f.history) > 1 && f.history[0].Number < f.finalized.Number {
+			f.history = f.history[1:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// recordedEvents collects the follower's signals for comparison.
+type recordedEvents struct {
+	events []FollowerEvent
+}
+
+func (r *recordedEvents) handle(event FollowerEvent) error {
+	event.Header = nil
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordedEvents) take() []FollowerEvent {
+	events := r.events
+	r.events = nil
+	return events
+}
+
+// blockRef is the canonical block at the number.
+func (c *testChain) blockRef(number uint64) BlockRef {
+	c.t.Helper()
+	header, err := c.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return headerRef(header)
+}
+
+// mineEmpty mines blocks without transactions.
+func (c *testChain) mineEmpty(blocks int) {
+	for i := 0; i < blocks; i++ {
+		c.Commit()
+	}
+}
+
+// reorg replaces every block above the parent with a longer side chain of
+// the given length. Its first block carries a transfer, so that the side
+// chain's blocks differ from the ones they replace.
+func (c *testChain) reorg(parent uint64, blocks int) {
+	c.t.Helper()
+	ctx := context.Background()
+	if err := c.Fork(ctx, c.blockRef(parent).Hash); err != nil {
+		c.t.Fatal(err)
+	}
+
+	nonce, err := c.PendingNonceAt(ctx, c.accounts[0])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	transfer, err := types.SignNewTx(c.keys[0], types.LatestSignerForChainID(testChainId), &types.DynamicFeeTx{
+		ChainID:   testChainId,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100_000_000_000),
+		Gas:       21000,
+		To:        &c.accounts[1],
+		Value:     big.NewInt(1),
+	})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if err := c.SendTransaction(ctx, transfer); err != nil {
+		c.t.Fatal(err)
+	}
+	c.mineEmpty(blocks)
+
+	head, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if head.Number.Uint64() != parent+uint64(blocks) {
+		c.t.Fatalf("side chain did not become canonical, head is %v", head.Number)
+	}
+}
+
+func newBlocks(chain *testChain, from uint64, to uint64) []FollowerEvent {
+	var events []FollowerEvent
+	for number := from; number <= to; number++ {
+		events = append(events, FollowerEvent{Kind: NewBlock, Block: chain.blockRef(number)})
+	}
+	return events
+}
+
+func expectEvents(t *testing.T, got []FollowerEvent, want []FollowerEvent) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got events:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestBlockFollowerReportsEveryBlockInOrder(t *testing.T) {
+	chain := newTestChain(t, 1)
+	chain.mineEmpty(2)
+	follower := NewBlockFollower(chain, 3, 0)
+	recorded := &recordedEvents{}
+
+	// the first sync starts at the head
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, recorded.take(), newBlocks(chain, 2, 2))
+
+	chain.mineEmpty(4)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, recorded.take(), []FollowerEvent{
+		{Kind: NewBlock, Block: chain.blockRef(3)},
+		{Kind: NewBlock, Block: chain.blockRef(4)},
+		{Kind: NewBlock, Block: chain.blockRef(5)},
+		{Kind: Finalized, Block: chain.blockRef(2)},
+		{Kind: NewBlock, Block: chain.blockRef(6)},
+		{Kind: Finalized, Block: chain.blockRef(3)},
+	})
+
+	// nothing new, nothing reported
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, recorded.take(), nil)
+	if tip := follower.Tip(); *tip != chain.blockRef(6) {
+		t.Fatalf("tip is %v, want block 6", tip)
+	}
+}
+
+func TestBlockFollowerReportsReorgs(t *testing.T) {
+	chain := newTestChain(t, 2)
+	chain.mineEmpty(2)
+	follower := NewBlockFollower(chain, 10, 0)
+	recorded := &recordedEvents{}
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	chain.mineEmpty(3)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	recorded.take()
+	orphaned := []BlockRef{chain.blockRef(5), chain.blockRef(4), chain.blockRef(3)}
+
+	// blocks 3 to 5 are replaced by 3' to 6'
+	chain.reorg(2, 4)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	want := []FollowerEvent{{Kind: Reorg, Block: chain.blockRef(2), Removed: orphaned}}
+	expectEvents(t, recorded.take(), append(want, newBlocks(chain, 3, 6)...))
+	for _, block := range orphaned {
+		if block == chain.blockRef(block.Number) {
+			t.Fatalf("block %d was not replaced", block.Number)
+		}
+	}
+
+	// a one block reorg at the tip
+	tip := chain.blockRef(6)
+	chain.reorg(5, 2)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	want = []FollowerEvent{{Kind: Reorg, Block: chain.blockRef(5), Removed: []BlockRef{tip}}}
+	expectEvents(t, recorded.take(), append(want, newBlocks(chain, 6, 7)...))
+}
+
+func TestBlockFollowerReorgPastFinalizedBlocks(t *testing.T) {
+	chain := newTestChain(t, 2)
+	chain.mineEmpty(1)
+	follower := NewBlockFollower(chain, 2, 0)
+	recorded := &recordedEvents{}
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	chain.mineEmpty(5)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	recorded.take()
+
+	// the history was trimmed to the last finalized block, 4, so a reorg
+	// down to block 1 reaches past all of it
+	chain.reorg(1, 6)
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	events := recorded.take()
+	if len(events) == 0 || events[0].Kind != Reorg {
+		t.Fatalf("first event is %v, want a reorg", events)
+	}
+	// the ancestor stands in as the block below the oldest one followed
+	if events[0].Block != chain.blockRef(3) {
+		t.Fatalf("reorg rolled back to %v, want block 3", events[0].Block)
+	}
+
+	// from there the side chain is reported, and finalized again
+	var reported []uint64
+	for _, event := range events[1:] {
+		if event.Kind == NewBlock {
+			reported = append(reported, event.Block.Number)
+			if event.Block != chain.blockRef(event.Block.Number) {
+				t.Fatalf("block %d reported from the orphaned chain", event.Block.Number)
+			}
+		}
+	}
+	if !reflect.DeepEqual(reported, []uint64{4, 5, 6, 7}) {
+		t.Fatalf("reported blocks %v after the reorg, want 4 to 7", reported)
+	}
+}
+
+func TestBlockFollowerResumeReportsMissedReorg(t *testing.T) {
+	chain := newTestChain(t, 2)
+	chain.mineEmpty(4)
+	stored := []BlockRef{chain.blockRef(4), chain.blockRef(2), chain.blockRef(3)}
+
+	// the chain reorganised while the consumer was stopped
+	chain.reorg(2, 3)
+
+	follower := NewBlockFollower(chain, 10, 0)
+	follower.Resume(stored)
+	recorded := &recordedEvents{}
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	want := []FollowerEvent{{Kind: Reorg, Block: stored[1], Removed: []BlockRef{stored[0], stored[2]}}}
+	expectEvents(t, recorded.take(), append(want, newBlocks(chain, 3, 5)...))
+}
+
+func TestBlockFollowerRetriesWhatTheHandlerRefused(t *testing.T) {
+	chain := newTestChain(t, 1)
+	chain.mineEmpty(1)
+	follower := NewBlockFollower(chain, 10, 0)
+	recorded := &recordedEvents{}
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	recorded.take()
+	chain.mineEmpty(3)
+
+	refused := errors.New("store unavailable")
+	refuse := func(event FollowerEvent) error {
+		if event.Block.Number == 3 {
+			return refused
+		}
+		return recorded.handle(event)
+	}
+	if err := follower.Sync(context.Background(), refuse); !errors.Is(err, refused) {
+		t.Fatalf("sync returned %v, want the handler's error", err)
+	}
+	expectEvents(t, recorded.take(), newBlocks(chain, 2, 2))
+	if tip := follower.Tip(); tip.Number != 2 {
+		t.Fatalf("refused block was taken as followed, tip is %v", tip)
+	}
+
+	if err := follower.Sync(context.Background(), recorded.handle); err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, recorded.take(), newBlocks(chain, 3, 4))
+}
+
+func TestBlockFollowerEventsCarryTheHeader(t *testing.T) {
+	chain := newTestChain(t, 1)
+	follower := NewBlockFollower(chain, 10, 0)
+	var header *types.Header
+	err := follower.Sync(context.Background(), func(event FollowerEvent) error {
+		header = event.Header
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header == nil || header.Hash() != chain.blockRef(0).Hash {
+		t.Fatalf("new block event carried header %v", header)
+	}
+}
+
+func TestBlockFollowerWakesOnNewHeads(t *testing.T) {
+	chain := newTestChain(t, 1)
+	// polling never fires, so only the head subscription can wake it
+	follower := NewBlockFollower(chain, 10, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reported := make(chan uint64, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- follower.Follow(ctx, func(event FollowerEvent) error {
+			if event.Kind == NewBlock {
+				reported <- event.Block.Number
+			}
+			return nil
+		})
+	}()
+
+	for want := uint64(0); want <= 3; want++ {
+		select {
+		case number := <-reported:
+			if number != want {
+				t.Fatalf("reported block %d, want %d", number, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("block %d was never reported", want)
+		}
+		chain.Commit()
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"day-3/lotterypb"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGrpcAddress is where 'serve --grpc' listens without a value.
+const defaultGrpcAddress = ":9090"
+
+// GrpcServer returns a gRPC server for the lottery, backed by the same
+// helpers as the HTTP endpoints and sharing the API key and send lock.
+func (s *Server) GrpcServer() *grpc.Server {
+	server := grpc.NewServer()
+	lotterypb.RegisterLotteryServiceServer(server, &lotteryService{server: s})
+	return server
+}
+
+type lotteryService struct {
+	lotterypb.UnimplementedLotteryServiceServer
+	server *Server
+}
+
+func (l *lotteryService) GetState(ctx context.Context, request *lotterypb.GetStateRequest) (*lotterypb.LotteryState, error) {
+	state, err := GetLotteryState()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	response := &lotterypb.LotteryState{
+		Address:        state.Address.Hex(),
+		Manager:        state.Manager.Hex(),
+		Paused:         state.Paused,
+		Pot:            formatAmount(state.Pot),
+		Players:        formatAddresses(state.Players),
+		RoundOpen:      state.RoundOpen,
+		FeeBasisPoints: formatAmount(state.FeeBasis),
+		TicketPrice:    formatAmount(state.TicketPrice),
+	}
+	if state.Deadline != nil {
+		response.RoundDeadline = state.Deadline.Unix()
+	}
+	if state.PaymentToken != nil {
+		response.PaymentToken = state.PaymentToken.Hex()
+	}
+	return response, nil
+}
+
+func (l *lotteryService) ListPlayers(ctx context.Context, request *lotterypb.ListPlayersRequest) (*lotterypb.ListPlayersResponse, error) {
+	players, err := GetLotteryPlayers()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &lotterypb.ListPlayersResponse{Players: formatAddresses(players)}, nil
+}
+
+func (l *lotteryService) Enter(ctx context.Context, request *lotterypb.EnterRequest) (*lotterypb.EnterResponse, error) {
+	if !common.IsHexAddress(request.From) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid from address %q", request.From)
+	}
+	value := request.Value
+	if value == "" {
+		value = "0"
+	}
+	amount, err := ParseUnits(value, EtherDecimals)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	entry, err := BuildLotteryEntry(common.HexToAddress(request.From), amount)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	unsigned := entry.Transaction
+	return &lotterypb.EnterResponse{
+		ApprovalRequired: entry.ApprovalRequired,
+		Transaction: &lotterypb.UnsignedTransaction{
+			ChainId:              formatAmount(unsigned.ChainId),
+			From:                 unsigned.From.Hex(),
+			To:                   unsigned.To.Hex(),
+			Nonce:                unsigned.Nonce,
+			Gas:                  unsigned.Gas,
+			GasPrice:             formatAmount(unsigned.GasPrice),
+			MaxPriorityFeePerGas: formatAmount(unsigned.GasTipCap),
+			MaxFeePerGas:         formatAmount(unsigned.GasFeeCap),
+			Value:                formatAmount(unsigned.Value),
+			Data:                 unsigned.Data,
+			Abi:                  string(unsigned.Abi),
+		},
+	}, nil
+}
+
+func (l *lotteryService) PickWinner(ctx context.Context, request *lotterypb.PickWinnerRequest) (*lotterypb.TransactionResponse, error) {
+	if err := l.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	l.server.sendLock.Lock()
+	defer l.server.sendLock.Unlock()
+
+	transaction, err := PickLotteryWinner()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &lotterypb.TransactionResponse{TransactionHash: transaction.Hash().Hex()}, nil
+}
+
+func (l *lotteryService) WatchEvents(request *lotterypb.WatchEventsRequest, stream lotterypb.LotteryService_WatchEventsServer) error {
+	lotteries := []common.Address{getLotteryAddress()}
+	if len(request.Lotteries) > 0 {
+		lotteries = nil
+		for _, lottery := range request.Lotteries {
+			if !common.IsHexAddress(lottery) {
+				return status.Errorf(codes.InvalidArgument, "invalid lottery address %q", lottery)
+			}
+			lotteries = append(lotteries, common.HexToAddress(lottery))
+		}
+	}
+
+	var fromBlock *uint64
+	if request.FromBlock > 0 {
+		fromBlock = &request.FromBlock
+	}
+
+	err := WatchLotteryEvents(stream.Context(), lotteries, fromBlock, func(event LotteryEvent) error {
+		message := &lotterypb.LotteryEvent{
+			Kind:        lotteryEventKinds[event.Kind],
+			BlockNumber: event.BlockNumber,
+			BlockHash:   event.BlockHash.Hex(),
+			LogIndex:    uint32(event.LogIndex),
+			Amount:      formatAmount(event.Amount),
+		}
+		if event.Kind != ReorgEvent {
+			message.Lottery = event.Lottery.Hex()
+			message.TransactionHash = event.TxHash.Hex()
+		}
+		if event.Player != nil {
+			message.Player = event.Player.Hex()
+		}
+		return stream.Send(message)
+	})
+	if err != nil && stream.Context().Err() == nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+var lotteryEventKinds = map[LotteryEventKind]lotterypb.LotteryEvent_Kind{
+	PlayerEnteredEvent:  lotterypb.LotteryEvent_PLAYER_ENTERED,
+	WinnerPickedEvent:   lotterypb.LotteryEvent_WINNER_PICKED,
+	RoundCancelledEvent: lotterypb.LotteryEvent_ROUND_CANCELLED,
+	ReorgEvent:          lotterypb.LotteryEvent_REORG,
+}
+
+// GetRound reads ended rounds from the indexer database, and the open round
+// from the chain.
+func (l *lotteryService) GetRound(ctx context.Context, request *lotterypb.GetRoundRequest) (*lotterypb.Round, error) {
+	if request.Round < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid round %d", request.Round)
+	}
+
+	var store *IndexerStore
+	if _, err := os.Stat(l.server.database); err == nil {
+		store, err = OpenIndexerStore(l.server.database)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		defer store.Close()
+	}
+
+	lottery := getLotteryAddress()
+	var rounds []LotteryRound
+	if store != nil {
+		history, err := store.RoundHistory(&lottery)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		rounds = history
+	}
+
+	if request.Round == 0 {
+		players, err := GetLotteryPlayers()
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		pot, err := GetLotteryPot()
+		if err != nil {
+			return nil, grpcError(err)
+		}
+
+		// without the indexer the number of the open round is unknown
+		response := &lotterypb.Round{Outcome: "open", Amount: formatAmount(pot), Entries: int64(len(players))}
+		if store != nil {
+			response.Round = int64(len(rounds)) + 1
+		}
+		return response, nil
+	}
+
+	if store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ended rounds need the indexer, run 'indexer run' first")
+	}
+	for _, round := range rounds {
+		if round.Round != request.Round {
+			continue
+		}
+		response := &lotterypb.Round{
+			Round:           round.Round,
+			Outcome:         round.Outcome,
+			Amount:          formatAmount(round.Amount),
+			Entries:         round.Entries,
+			BlockNumber:     round.BlockNumber,
+			TransactionHash: round.TxHash.Hex(),
+		}
+		if round.Winner != nil {
+			response.Winner = round.Winner.Hex()
+		}
+		return response, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "round %d has not ended or is not indexed yet", request.Round)
+}
+
+// authorize checks the x-api-key metadata like the HTTP manager endpoints
+// check the X-API-Key header.
+func (l *lotteryService) authorize(ctx context.Context) error {
+	if l.server.apiKey == "" {
+		return status.Error(codes.PermissionDenied, "manager calls are disabled, no API key is configured")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("x-api-key")
+	if len(keys) != 1 || subtle.ConstantTimeCompare([]byte(keys[0]), []byte(l.server.apiKey)) != 1 {
+		return status.Error(codes.Unauthenticated, "missing or invalid x-api-key")
+	}
+	return nil
+}
+
+// grpcError maps request errors to their gRPC code, and anything else to
+// Unavailable, since it came from the node.
+func grpcError(err error) error {
+	var requestError *apiError
+	if errors.As(err, &requestError) {
+		code := codes.Unknown
+		switch requestError.status {
+		case http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case http.StatusServiceUnavailable:
+			code = codes.FailedPrecondition
+		}
+		return status.Error(code, requestError.message)
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func formatAmount(amount *big.Int) string {
+	if amount == nil {
+		return ""
+	}
+	return amount.String()
+}
+
+func formatAddresses(addresses []common.Address) []string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.Hex()
+	}
+	return formatted
+}
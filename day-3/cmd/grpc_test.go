@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lotterypb"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGrpc serves the server's gRPC service over an in-memory listener and
+// returns a client sending the API key.
+func dialGrpc(t *testing.T, server *Server, apiKey string) *lotterypb.Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := server.GrpcServer()
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	client, err := lotterypb.Dial("bufnet", apiKey, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// useLottery selects the lottery like --lottery does, for the rest of the
+// test.
+func useLottery(t *testing.T, address common.Address) {
+	t.Helper()
+	selectedLottery = &address
+	t.Cleanup(func() { selectedLottery = nil })
+}
+
+func TestGrpcReadsTheSelectedLottery(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+	// the configured lottery has a player of its own, which must not show
+	// up in the selected one
+	served.enter(served.contract, 2, "5")
+	selected, selectedContract := served.deployLottery(0)
+	useLottery(t, selected)
+	served.enter(selectedContract, 1, "1")
+	served.enter(selectedContract, 1, "0.5")
+	client := dialGrpc(t, served.server, "")
+	ctx := context.Background()
+
+	state, err := client.GetState(ctx, &lotterypb.GetStateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Address != selected.Hex() || state.Pot != mustParseEther(t, "1.5").String() || !state.RoundOpen || state.RoundDeadline == 0 {
+		t.Fatalf("state is %+v", state)
+	}
+
+	players, err := client.ListPlayers(ctx, &lotterypb.ListPlayersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{served.accounts[1].Hex(), served.accounts[1].Hex()}
+	if len(players.Players) != 2 || players.Players[0] != want[0] || players.Players[1] != want[1] {
+		t.Fatalf("players are %v, want %v", players.Players, want)
+	}
+
+	// without the indexer the open round is read from the chain, unnumbered
+	round, err := client.GetRound(ctx, &lotterypb.GetRoundRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Outcome != "open" || round.Entries != 2 || round.Amount != mustParseEther(t, "1.5").String() || round.Round != 0 {
+		t.Fatalf("open round is %+v", round)
+	}
+	if _, err := client.GetRound(ctx, &lotterypb.GetRoundRequest{Round: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("ended round without the indexer failed with %v", err)
+	}
+	if _, err := client.GetRound(ctx, &lotterypb.GetRoundRequest{Round: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("negative round failed with %v", err)
+	}
+}
+
+func TestGrpcEnterBuildsAnUnsignedEntry(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+	inModuleRoot(t)
+	client := dialGrpc(t, served.server, "")
+	ctx := context.Background()
+
+	response, err := client.Enter(ctx, &lotterypb.EnterRequest{From: served.accounts[1].Hex(), Value: "0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := response.Transaction
+	if unsigned.From != served.accounts[1].Hex() || unsigned.To != served.address.Hex() || unsigned.Value != mustParseEther(t, "0.1").String() {
+		t.Fatalf("entry is %+v", unsigned)
+	}
+	if unsigned.ChainId != testChainId.String() || unsigned.Gas == 0 || len(unsigned.Data) != 4 {
+		t.Fatalf("entry is %+v", unsigned)
+	}
+
+	if _, err := client.Enter(ctx, &lotterypb.EnterRequest{From: "0x1234"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid sender failed with %v", err)
+	}
+	if _, err := client.Enter(ctx, &lotterypb.EnterRequest{From: served.accounts[1].Hex(), Value: "lots"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid value failed with %v", err)
+	}
+}
+
+func TestGrpcPickWinnerNeedsTheApiKey(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+	served.enter(served.contract, 1, "1")
+	ctx := context.Background()
+
+	if _, err := dialGrpc(t, served.server, "").PickWinner(ctx, &lotterypb.PickWinnerRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("picking without the key failed with %v", err)
+	}
+	if _, err := dialGrpc(t, served.server, "wrong").PickWinner(ctx, &lotterypb.PickWinnerRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("picking with the wrong key failed with %v", err)
+	}
+
+	response, err := dialGrpc(t, served.server, testApiKey).PickWinner(ctx, &lotterypb.PickWinnerRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := served.TransactionReceipt(ctx, common.HexToHash(response.TransactionHash))
+	if err != nil || receipt.Status != 1 {
+		t.Fatalf("draw %v was not mined: %v", response.TransactionHash, err)
+	}
+	players, err := served.contract.GetPlayers(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 0 {
+		t.Fatalf("draw left players %v", players)
+	}
+
+	keyless := newServedLottery(t, "")
+	if _, err := dialGrpc(t, keyless.server, testApiKey).PickWinner(ctx, &lotterypb.PickWinnerRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("picking on a server without a key failed with %v", err)
+	}
+}
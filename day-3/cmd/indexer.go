@@ -0,0 +1,613 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"day-3/lottery"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const defaultIndexerBatch = 2000
+
+func indexerCommand() *cobra.Command {
+	var database string
+	var selection string
+
+	command := &cobra.Command{
+		Use:   "indexer",
+		Short: "index lottery and token events into SQLite",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return selectLottery(selection)
+		},
+	}
+	command.PersistentFlags().StringVar(&database, "db", getIndexerDatabase(), "SQLite database file (defaults to INDEXER_DB)")
+	command.PersistentFlags().StringVar(&selection, "lottery", "", "lottery address or factory id to index (defaults to LOTTERY_CONTRACT_ADDRESS, then the registry)")
+	command.AddCommand(indexerRunCommand(&database))
+	command.AddCommand(indexerPlayerCommand(&database))
+	command.AddCommand(indexerRoundsCommand(&database))
+	return command
+}
+
+func indexerRunCommand(database *string) *cobra.Command {
+	var fromBlock int64
+	var batchSize uint64
+	var confirmations uint64
+	var poll time.Duration
+	var metrics *metricsOptions
+
+	command := &cobra.Command{
+		Use:   "run",
+		Short: "backfill from the deployment block, then follow new blocks",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := metrics.start(); err != nil {
+				log.Fatal(err)
+			}
+
+			store, err := OpenIndexerStore(*database)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer store.Close()
+
+			client, err := GetClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var start *uint64
+			if fromBlock >= 0 {
+				block := uint64(fromBlock)
+				start = &block
+			}
+			indexer, err := NewIndexer(client, store, start, batchSize, confirmations, poll)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if err := indexer.Run(ctx); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	command.Flags().Int64Var(&fromBlock, "from-block", -1, "block to start from instead of the deployment block")
+	command.Flags().Uint64Var(&batchSize, "batch", defaultIndexerBatch, "blocks per log query while backfilling")
+	command.Flags().Uint64Var(&confirmations, "confirmations", defaultConfirmations, "blocks after which a block is final and no longer checked for reorgs")
+	command.Flags().DurationVar(&poll, "poll", 5*time.Second, "interval to check for new blocks when the node has no head subscription")
+	metrics = addMetricsFlags(command, true)
+	return command
+}
+
+func indexerPlayerCommand(database *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "player <address>",
+		Short: "show how often an address entered and won",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			store, err := OpenIndexerStore(*database)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer store.Close()
+
+			stats, err := store.PlayerStats(parseAddressArg(args[0]))
+			if err != nil {
+				log.Fatal(err)
+			}
+			amounts := newAmountFormatter()
+			log.Println("entries: ", stats.Entries, ", staked ", amounts.formatSums(stats.Staked))
+			log.Println("wins: ", stats.Wins, ", won ", amounts.formatSums(stats.Won))
+		},
+	}
+}
+
+func indexerRoundsCommand(database *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rounds",
+		Short: "list the ended rounds of the lottery, or of every indexed lottery",
+		Run: func(cmd *cobra.Command, args []string) {
+			store, err := OpenIndexerStore(*database)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer store.Close()
+
+			var lottery *common.Address
+			if selectedLottery != nil || os.Getenv("LOTTERY_CONTRACT_ADDRESS") != "" {
+				address := getLotteryAddress()
+				lottery = &address
+			}
+			rounds, err := store.RoundHistory(lottery)
+			if err != nil {
+				log.Fatal(err)
+			}
+			amounts := newAmountFormatter()
+			for _, round := range rounds {
+				token := common.Address{}
+				if round.Token != nil {
+					token = *round.Token
+				}
+				amount := amounts.format(token, round.Amount)
+				number := fmt.Sprint(" round ", round.Round)
+				if round.RoundsFrom > 0 {
+					number = fmt.Sprint(" round ", round.Round, " since block ", round.RoundsFrom)
+				}
+				if round.Winner != nil {
+					log.Println(round.Lottery, number, ": ", round.Entries, " entries, won by ", *round.Winner, " for ", amount, " in block ", round.BlockNumber)
+				} else {
+					log.Println(round.Lottery, number, ": ", round.Entries, " entries, cancelled and refunded ", amount, " in block ", round.BlockNumber)
+				}
+			}
+		},
+	}
+}
+
+// amountFormatter shows indexed amounts in the decimals of the token they
+// are counted in, read once per token. Without a node to read them from,
+// token amounts are shown in the token's base units.
+type amountFormatter struct {
+	client   *ethclient.Client
+	decimals map[common.Address]int
+}
+
+func newAmountFormatter() *amountFormatter {
+	client, err := GetClient()
+	if err != nil {
+		log.Println("showing token amounts in base units: ", err)
+	}
+	return &amountFormatter{client: client, decimals: map[common.Address]int{}}
+}
+
+// format shows the amount of the token, the zero address for ether.
+func (f *amountFormatter) format(tokenAddress common.Address, amount *big.Int) string {
+	if tokenAddress == (common.Address{}) {
+		return FormatUnits(amount, EtherDecimals) + " ether"
+	}
+	decimals, ok := f.decimals[tokenAddress]
+	if !ok {
+		decimals = -1
+		if f.client != nil {
+			read, err := readTokenDecimals(f.client, tokenAddress, nil)
+			if err != nil {
+				log.Println("showing amounts of token ", tokenAddress, " in base units: ", err)
+			} else {
+				decimals = read
+			}
+		}
+		f.decimals[tokenAddress] = decimals
+	}
+	if decimals < 0 {
+		return fmt.Sprint(amount, " base units of token ", tokenAddress)
+	}
+	return fmt.Sprint(FormatUnits(amount, decimals), " of token ", tokenAddress)
+}
+
+// formatSums shows amounts summed per token, ether first.
+func (f *amountFormatter) formatSums(sums map[common.Address]*big.Int) string {
+	if len(sums) == 0 {
+		return f.format(common.Address{}, new(big.Int))
+	}
+	tokens := make([]common.Address, 0, len(sums))
+	for tokenAddress := range sums {
+		tokens = append(tokens, tokenAddress)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return bytes.Compare(tokens[i][:], tokens[j][:]) < 0 })
+
+	formatted := make([]string, len(tokens))
+	for i, tokenAddress := range tokens {
+		formatted[i] = f.format(tokenAddress, sums[tokenAddress])
+	}
+	return strings.Join(formatted, " and ")
+}
+
+// indexedContract is a contract whose events the indexer stores.
+type indexedContract struct {
+	Name    string
+	Address common.Address
+	Abi     *abi.ABI
+}
+
+// Indexer copies Lottery and FredCoin events into an IndexerStore. It
+// keeps a cursor of the last processed block and the hashes of the blocks
+// that are not final yet, so it can resume after a restart and roll back
+// after a reorg. Both are kept per lottery, so that lotteries can share a
+// database.
+type Indexer struct {
+	client        *ethclient.Client
+	store         *IndexerStore
+	lottery       common.Address
+	contracts     map[common.Address]*indexedContract
+	roundsFrom    uint64
+	start         uint64
+	batchSize     uint64
+	confirmations uint64
+	poll          time.Duration
+}
+
+// NewIndexer indexes the lottery and token recorded in the registry, or
+// configured through the environment, starting from the block the earliest
+// of them was deployed in unless a start block is given. A lottery indexed
+// before resumes from its cursor instead. Rounds are numbered from the
+// start block when it is past the lottery's deployment, as earlier rounds
+// are not counted.
+func NewIndexer(client *ethclient.Client, store *IndexerStore, start *uint64, batchSize uint64, confirmations uint64, poll time.Duration) (*Indexer, error) {
+	if batchSize == 0 {
+		batchSize = defaultIndexerBatch
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	// an explicitly configured address wins over the registry, which is
+	// only used for the deployment block when it agrees
+	addresses := map[string]common.Address{}
+	if selectedLottery != nil || os.Getenv("LOTTERY_CONTRACT_ADDRESS") != "" {
+		addresses["Lottery"] = getLotteryAddress()
+	}
+	if os.Getenv("TOKEN_CONTRACT_ADDRESS") != "" {
+		addresses["FredCoin"] = getTokenAddress()
+	}
+
+	indexer := &Indexer{
+		client:        client,
+		store:         store,
+		contracts:     map[common.Address]*indexedContract{},
+		batchSize:     batchSize,
+		confirmations: confirmations,
+		poll:          poll,
+	}
+	var deployedAt, lotteryDeployedAt *uint64
+	for _, name := range []string{"Lottery", "FredCoin"} {
+		deployment, recorded := registry.Lookup(chainId, name)
+		address, configured := addresses[name]
+		switch {
+		case !configured && recorded:
+			address = deployment.Address
+		case !configured && name == "Lottery":
+			address = getLotteryAddress()
+		case !configured:
+			continue
+		}
+
+		if recorded && deployment.Address == address {
+			if block, err := deploymentBlock(client, deployment); err == nil {
+				if deployedAt == nil || block < *deployedAt {
+					deployedAt = &block
+				}
+				if name == "Lottery" {
+					lotteryDeployedAt = &block
+				}
+			}
+		}
+		if name == "Lottery" {
+			indexer.lottery = address
+		}
+
+		contractAbi, err := loadAbi(name)
+		if err != nil {
+			return nil, err
+		}
+		indexer.contracts[address] = &indexedContract{Name: name, Address: address, Abi: contractAbi}
+	}
+
+	switch {
+	case start != nil:
+		indexer.start = *start
+	case deployedAt != nil:
+		indexer.start = *deployedAt
+	}
+
+	roundsFrom := indexer.start
+	if lotteryDeployedAt != nil && roundsFrom <= *lotteryDeployedAt {
+		roundsFrom = 0
+	}
+	indexer.roundsFrom, err = store.Track(indexer.lottery, roundsFrom, func() (common.Address, error) {
+		return startPaymentToken(client, indexer.lottery, roundsFrom)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return indexer, nil
+}
+
+// startPaymentToken is the token the lottery was paid in before the block
+// its rounds are numbered from, the zero address for ether. A lottery
+// indexed from its deployment starts out in ether. A node that cannot call
+// at the block before gives the current token, which is only wrong when
+// the token changed since.
+func startPaymentToken(client *ethclient.Client, lotteryAddress common.Address, roundsFrom uint64) (common.Address, error) {
+	if roundsFrom == 0 {
+		return common.Address{}, nil
+	}
+	lotteryContract, err := lottery.NewLotteryCaller(lotteryAddress, client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to bind lottery: %w", err)
+	}
+	paymentToken, err := lotteryContract.PaymentToken(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(roundsFrom - 1)})
+	if err == nil {
+		return paymentToken, nil
+	}
+	log.Println("reading the payment token at the latest block, as block ", roundsFrom-1, " failed: ", err)
+	paymentToken, err = lotteryContract.PaymentToken(nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch lottery payment token: %w", err)
+	}
+	return paymentToken, nil
+}
+
+func deploymentBlock(client *ethclient.Client, deployment Deployment) (uint64, error) {
+	receipt, err := client.TransactionReceipt(context.Background(), deployment.TransactionHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch deployment receipt: %w", err)
+	}
+	return receipt.BlockNumber.Uint64(), nil
+}
+
+// Run indexes until the context is cancelled. A failed run is logged and
+// retried from what the store holds, so node errors are not fatal.
+func (ix *Indexer) Run(ctx context.Context) error {
+	for address, contract := range ix.contracts {
+		log.Println("indexing ", contract.Name, " at ", address)
+	}
+	if ix.roundsFrom > 0 {
+		log.Println("numbering rounds from block ", ix.roundsFrom)
+	}
+
+	for {
+		err := ix.run(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Println("indexer failed, retrying: ", err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(ix.poll):
+		}
+	}
+}
+
+// run catches up, then follows new blocks.
+func (ix *Indexer) run(ctx context.Context) error {
+	follower, handle, err := ix.catchUp(ctx)
+	if err != nil {
+		return err
+	}
+	return follower.Follow(ctx, handle)
+}
+
+// catchUp checks the recorded blocks for a reorg that happened while
+// stopped and backfills the final part of the chain in batches. It returns
+// a BlockFollower resumed from there for the rest, and the handler that
+// indexes what it reports.
+func (ix *Indexer) catchUp(ctx context.Context) (*BlockFollower, func(FollowerEvent) error, error) {
+	follower := NewBlockFollower(ix.client, ix.confirmations, ix.poll)
+	handle := func(event FollowerEvent) error {
+		switch event.Kind {
+		case NewBlock:
+			return ix.indexBlock(ctx, event.Block)
+		case Reorg:
+			log.Println("reorg orphaned ", len(event.Removed), " blocks, rolling back to block ", event.Block.Number)
+			return ix.store.Rollback(ix.lottery, ix.addresses(), event.Block)
+		case Finalized:
+			return ix.store.PruneBlocks(ix.lottery, event.Block.Number)
+		}
+		return nil
+	}
+
+	blocks, err := ix.store.BlockHashes(ix.lottery)
+	if err != nil {
+		return nil, nil, err
+	}
+	follower.Resume(blocks)
+	if err := follower.Reconcile(ctx, handle); err != nil {
+		return nil, nil, err
+	}
+
+	if err := ix.backfill(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	blocks, err = ix.store.BlockHashes(ix.lottery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(blocks) == 0 {
+		// follow from just before the start block, so it is indexed too
+		number := ix.start
+		if number > 0 {
+			number--
+		}
+		header, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch block %d: %w", number, err)
+		}
+		blocks = append(blocks, headerRef(header))
+	}
+	follower.Resume(blocks)
+	return follower, handle, nil
+}
+
+// backfill indexes from the cursor up to the confirmation depth, where
+// blocks are final and can be fetched in ranges without watching for
+// reorgs.
+func (ix *Indexer) backfill(ctx context.Context) error {
+	for {
+		cursor, err := ix.store.Cursor(ix.lottery)
+		if err != nil {
+			return err
+		}
+
+		head, err := ix.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch head: %w", err)
+		}
+		if head.Number.Uint64() < ix.confirmations {
+			return nil
+		}
+		final := head.Number.Uint64() - ix.confirmations
+
+		from := ix.start
+		if cursor != nil {
+			from = cursor.Number + 1
+		}
+		if from > final {
+			return nil
+		}
+		to := final
+		if to-from+1 > ix.batchSize {
+			to = from + ix.batchSize - 1
+		}
+
+		last, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %d: %w", to, err)
+		}
+		logs, err := ix.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: ix.addresses(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch logs for blocks %d to %d: %w", from, to, err)
+		}
+
+		if err := ix.store.Index(ctx, ix.lottery, headerRef(last), logs, ix.handleLog); err != nil {
+			return err
+		}
+		log.Println("indexed blocks ", from, " to ", to, ": ", len(logs), " events")
+	}
+}
+
+// indexBlock indexes a single block reported by the follower.
+func (ix *Indexer) indexBlock(ctx context.Context, block BlockRef) error {
+	hash := block.Hash
+	logs, err := ix.client.FilterLogs(ctx, ethereum.FilterQuery{
+		BlockHash: &hash,
+		Addresses: ix.addresses(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch logs for block %d: %w", block.Number, err)
+	}
+
+	if err := ix.store.Index(ctx, ix.lottery, block, logs, ix.handleLog); err != nil {
+		return err
+	}
+	if len(logs) > 0 {
+		log.Println("indexed block ", block.Number, ": ", len(logs), " events")
+	}
+	return nil
+}
+
+func (ix *Indexer) addresses() []common.Address {
+	addresses := make([]common.Address, 0, len(ix.contracts))
+	for address := range ix.contracts {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// handleLog stores every decoded event, and the lottery entries, round
+// outcomes and token transfers in their own tables for querying.
+func (ix *Indexer) handleLog(tx *sql.Tx, eventLog types.Log) error {
+	contract, ok := ix.contracts[eventLog.Address]
+	if !ok {
+		return nil
+	}
+
+	event, fields, err := decodeEvent(contract.Abi, &eventLog)
+	if event == nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode %s event in %v: %w", event.Name, eventLog.TxHash, err)
+	}
+
+	formatted := map[string]string{}
+	for name, value := range fields {
+		formatted[name] = FormatAbiValue(value)
+	}
+	encoded, err := json.Marshal(formatted)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Name, err)
+	}
+
+	position := []interface{}{eventLog.BlockNumber, eventLog.TxHash.Hex(), eventLog.Index}
+	_, err = tx.Exec(`INSERT OR REPLACE INTO events (block_number, tx_hash, log_index, contract, name, fields) VALUES (?, ?, ?, ?, ?, ?)`,
+		append(position, contract.Address.Hex(), event.Name, string(encoded))...)
+	if err != nil {
+		return fmt.Errorf("failed to store %s event: %w", event.Name, err)
+	}
+
+	switch contract.Name + "." + event.Name {
+	case "Lottery.PlayerEntered":
+		round, err := currentRound(tx, contract.Address, eventLog)
+		if err != nil {
+			return err
+		}
+		paymentToken, err := currentPaymentToken(tx, contract.Address, eventLog)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT OR REPLACE INTO lottery_entries (block_number, tx_hash, log_index, lottery, round, player, token, stake) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			append(position, contract.Address.Hex(), round, fields["player"].(common.Address).Hex(), paymentToken.Hex(), fields["stake"].(*big.Int).String())...)
+		if err != nil {
+			return fmt.Errorf("failed to store lottery entry: %w", err)
+		}
+
+	case "Lottery.WinnerPicked", "Lottery.RoundCancelled":
+		round, err := currentRound(tx, contract.Address, eventLog)
+		if err != nil {
+			return err
+		}
+		paymentToken, err := currentPaymentToken(tx, contract.Address, eventLog)
+		if err != nil {
+			return err
+		}
+		outcome, winner, amount := "cancelled", sql.NullString{}, fields["refunded"]
+		if event.Name == "WinnerPicked" {
+			outcome, amount = "won", fields["amount"]
+			winner = sql.NullString{String: fields["winner"].(common.Address).Hex(), Valid: true}
+		}
+		_, err = tx.Exec(`INSERT OR REPLACE INTO lottery_rounds (block_number, tx_hash, log_index, lottery, round, outcome, winner, token, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			append(position, contract.Address.Hex(), round, outcome, winner, paymentToken.Hex(), amount.(*big.Int).String())...)
+		if err != nil {
+			return fmt.Errorf("failed to store lottery round: %w", err)
+		}
+
+	case "FredCoin.Transfer":
+		_, err = tx.Exec(`INSERT OR REPLACE INTO token_transfers (block_number, tx_hash, log_index, token, sender, recipient, amount) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			append(position, contract.Address.Hex(), fields["from"].(common.Address).Hex(), fields["to"].(common.Address).Hex(), fields["value"].(*big.Int).String())...)
+		if err != nil {
+			return fmt.Errorf("failed to store token transfer: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const defaultIndexerDatabase = "./indexer.db"
+
+// indexerSchema keeps amounts as decimal text, since they overflow
+// SQLite's 64 bit integers, along with the token they are counted in, the
+// zero address for ether. Every row carries its block number so a reorg
+// can be rolled back by deleting everything above the common ancestor.
+// Several lotteries may share a database, so the cursor and the recorded
+// block hashes are kept per lottery, and so is the payment token the
+// lottery had at its start block.
+const indexerSchema = `
+CREATE TABLE IF NOT EXISTS cursors (
+	lottery       TEXT PRIMARY KEY,
+	rounds_from   INTEGER NOT NULL,
+	payment_token TEXT NOT NULL,
+	block_number  INTEGER,
+	block_hash    TEXT
+);
+CREATE TABLE IF NOT EXISTS indexed_blocks (
+	lottery TEXT NOT NULL,
+	number  INTEGER NOT NULL,
+	hash    TEXT NOT NULL,
+	PRIMARY KEY (lottery, number)
+);
+CREATE TABLE IF NOT EXISTS events (
+	block_number INTEGER NOT NULL,
+	tx_hash      TEXT NOT NULL,
+	log_index    INTEGER NOT NULL,
+	contract     TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	fields       TEXT NOT NULL,
+	PRIMARY KEY (tx_hash, log_index)
+);
+CREATE TABLE IF NOT EXISTS lottery_entries (
+	block_number INTEGER NOT NULL,
+	tx_hash      TEXT NOT NULL,
+	log_index    INTEGER NOT NULL,
+	lottery      TEXT NOT NULL,
+	round        INTEGER NOT NULL,
+	player       TEXT NOT NULL,
+	token        TEXT NOT NULL,
+	stake        TEXT NOT NULL,
+	PRIMARY KEY (tx_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS lottery_entries_player ON lottery_entries (player);
+CREATE TABLE IF NOT EXISTS lottery_rounds (
+	block_number INTEGER NOT NULL,
+	tx_hash      TEXT NOT NULL,
+	log_index    INTEGER NOT NULL,
+	lottery      TEXT NOT NULL,
+	round        INTEGER NOT NULL,
+	outcome      TEXT NOT NULL,
+	winner       TEXT,
+	token        TEXT NOT NULL,
+	amount       TEXT NOT NULL,
+	PRIMARY KEY (tx_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS lottery_rounds_winner ON lottery_rounds (winner);
+CREATE TABLE IF NOT EXISTS token_transfers (
+	block_number INTEGER NOT NULL,
+	tx_hash      TEXT NOT NULL,
+	log_index    INTEGER NOT NULL,
+	token        TEXT NOT NULL,
+	sender       TEXT NOT NULL,
+	recipient    TEXT NOT NULL,
+	amount       TEXT NOT NULL,
+	PRIMARY KEY (tx_hash, log_index)
+);
+`
+
+// indexerSchemaVersion is kept in the database's user_version. A database
+// of an older version has its tables dropped, which re-indexes from the
+// start block, since what they hold cannot be completed in place.
+const indexerSchemaVersion = 1
+
+// indexerTables are every table of the schema and of earlier versions.
+var indexerTables = []string{"cursor", "blocks", "cursors", "indexed_blocks", "events", "lottery_entries", "lottery_rounds", "token_transfers"}
+
+// indexedTables are the tables rolled back on a reorg, with the column
+// holding the contract a row came from.
+var indexedTables = []struct {
+	name     string
+	contract string
+}{
+	{"events", "contract"},
+	{"lottery_entries", "lottery"},
+	{"lottery_rounds", "lottery"},
+	{"token_transfers", "token"},
+}
+
+// IndexerStore is the SQLite database the indexer writes to.
+type IndexerStore struct {
+	db *sql.DB
+}
+
+func getIndexerDatabase() string {
+	if file := os.Getenv("INDEXER_DB"); file != "" {
+		return file
+	}
+	return defaultIndexerDatabase
+}
+
+func OpenIndexerStore(file string) (*IndexerStore, error) {
+	db, err := sql.Open("sqlite3", file+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open indexer database: %w", err)
+	}
+
+	if err := migrateIndexerStore(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &IndexerStore{db: db}, nil
+}
+
+func migrateIndexerStore(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read indexer schema version: %w", err)
+	}
+	if version > indexerSchemaVersion {
+		return fmt.Errorf("indexer database is of schema version %d, newer than %d", version, indexerSchemaVersion)
+	}
+	if version < indexerSchemaVersion {
+		for _, table := range indexerTables {
+			if _, err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, table)); err != nil {
+				return fmt.Errorf("failed to drop indexer table %s: %w", table, err)
+			}
+		}
+	}
+
+	if _, err := db.Exec(indexerSchema); err != nil {
+		return fmt.Errorf("failed to create indexer tables: %w", err)
+	}
+	if _, err := db.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, indexerSchemaVersion)); err != nil {
+		return fmt.Errorf("failed to store indexer schema version: %w", err)
+	}
+	return nil
+}
+
+func (s *IndexerStore) Close() error {
+	return s.db.Close()
+}
+
+// Track records that the lottery is indexed, with its rounds numbered from
+// the given block, 0 when they are numbered from its deployment, and the
+// payment token it had at that block, which is only asked for a lottery
+// not indexed before. A lottery indexed before keeps the block it was
+// first indexed with, which is returned.
+func (s *IndexerStore) Track(lottery common.Address, roundsFrom uint64, paymentToken func() (common.Address, error)) (uint64, error) {
+	err := s.db.QueryRow(`SELECT rounds_from FROM cursors WHERE lottery = ?`, lottery.Hex()).Scan(&roundsFrom)
+	if err == nil {
+		return roundsFrom, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to read indexed lottery: %w", err)
+	}
+
+	token, err := paymentToken()
+	if err != nil {
+		return 0, err
+	}
+	_, err = s.db.Exec(`INSERT INTO cursors (lottery, rounds_from, payment_token) VALUES (?, ?, ?)`, lottery.Hex(), roundsFrom, token.Hex())
+	if err != nil {
+		return 0, fmt.Errorf("failed to store indexed lottery: %w", err)
+	}
+	return roundsFrom, nil
+}
+
+// Cursor is the last block the indexer of the lottery has fully processed.
+func (s *IndexerStore) Cursor(lottery common.Address) (*BlockRef, error) {
+	var number sql.NullInt64
+	var hash sql.NullString
+	err := s.db.QueryRow(`SELECT block_number, block_hash FROM cursors WHERE lottery = ?`, lottery.Hex()).Scan(&number, &hash)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !number.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexer cursor: %w", err)
+	}
+	return &BlockRef{Number: uint64(number.Int64), Hash: common.HexToHash(hash.String)}, nil
+}
+
+// BlockHashes returns the block hashes recorded for the lottery from the
+// newest down, the blocks that are not final yet and the last one that is.
+func (s *IndexerStore) BlockHashes(lottery common.Address) ([]BlockRef, error) {
+	rows, err := s.db.Query(`SELECT number, hash FROM indexed_blocks WHERE lottery = ? ORDER BY number DESC`, lottery.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexed blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []BlockRef
+	for rows.Next() {
+		var block BlockRef
+		var hash string
+		if err := rows.Scan(&block.Number, &hash); err != nil {
+			return nil, fmt.Errorf("failed to read indexed blocks: %w", err)
+		}
+		block.Hash = common.HexToHash(hash)
+		blocks = append(blocks, block)
+	}
+	return blocks, rows.Err()
+}
+
+// Rollback deletes what the indexer of the lottery stored above the
+// ancestor, the rows of the given contracts, and moves its cursor back to
+// it. Other lotteries in the database roll back on their own.
+func (s *IndexerStore) Rollback(lottery common.Address, contracts []common.Address, ancestor BlockRef) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start rollback: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range indexedTables {
+		for _, contract := range contracts {
+			query := fmt.Sprintf(`DELETE FROM %s WHERE block_number > ? AND %s = ?`, table.name, table.contract)
+			if _, err := tx.Exec(query, ancestor.Number, contract.Hex()); err != nil {
+				return fmt.Errorf("failed to roll back %s: %w", table.name, err)
+			}
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM indexed_blocks WHERE lottery = ? AND number > ?`, lottery.Hex(), ancestor.Number); err != nil {
+		return fmt.Errorf("failed to roll back indexed blocks: %w", err)
+	}
+	if err := setCursor(tx, lottery, ancestor); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollback: %w", err)
+	}
+	return nil
+}
+
+// Index stores a batch of logs up to and including block in one
+// transaction, and moves the lottery's cursor to that block.
+func (s *IndexerStore) Index(ctx context.Context, lottery common.Address, block BlockRef, logs []types.Log, handle func(*sql.Tx, types.Log) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start indexer transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, eventLog := range logs {
+		if eventLog.Removed {
+			continue
+		}
+		if err := handle(tx, eventLog); err != nil {
+			return err
+		}
+	}
+	if err := recordBlock(tx, lottery, block); err != nil {
+		return err
+	}
+	if err := setCursor(tx, lottery, block); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit indexed blocks: %w", err)
+	}
+	return nil
+}
+
+func setCursor(tx *sql.Tx, lottery common.Address, cursor BlockRef) error {
+	result, err := tx.Exec(`UPDATE cursors SET block_number = ?, block_hash = ? WHERE lottery = ?`, cursor.Number, cursor.Hash.Hex(), lottery.Hex())
+	if err == nil {
+		if updated, _ := result.RowsAffected(); updated == 0 {
+			err = fmt.Errorf("lottery %v is not tracked", lottery)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store indexer cursor: %w", err)
+	}
+	return nil
+}
+
+func recordBlock(tx *sql.Tx, lottery common.Address, block BlockRef) error {
+	_, err := tx.Exec(`INSERT OR REPLACE INTO indexed_blocks (lottery, number, hash) VALUES (?, ?, ?)`, lottery.Hex(), block.Number, block.Hash.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to record block %d: %w", block.Number, err)
+	}
+	return nil
+}
+
+// PruneBlocks forgets the hashes recorded for the lottery below a
+// finalized block, since they can no longer be reorged.
+func (s *IndexerStore) PruneBlocks(lottery common.Address, below uint64) error {
+	if _, err := s.db.Exec(`DELETE FROM indexed_blocks WHERE lottery = ? AND number < ?`, lottery.Hex(), below); err != nil {
+		return fmt.Errorf("failed to prune indexed blocks: %w", err)
+	}
+	return nil
+}
+
+// currentRound is the round a log of a lottery belongs to, one past the
+// number of rounds that ended before it, so that indexing a block again
+// numbers it the same.
+func currentRound(tx *sql.Tx, lottery common.Address, eventLog types.Log) (int64, error) {
+	var ended int64
+	err := tx.QueryRow(`SELECT COUNT(*) FROM lottery_rounds WHERE lottery = ? AND (block_number < ? OR (block_number = ? AND log_index < ?))`,
+		lottery.Hex(), eventLog.BlockNumber, eventLog.BlockNumber, eventLog.Index).Scan(&ended)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count lottery rounds: %w", err)
+	}
+	return ended + 1, nil
+}
+
+// currentPaymentToken is the token a log of a lottery was paid in: the one
+// of the last PaymentTokenChanged before it, or else the one the lottery
+// had at its start block.
+func currentPaymentToken(tx *sql.Tx, lottery common.Address, eventLog types.Log) (common.Address, error) {
+	var fields string
+	err := tx.QueryRow(`SELECT fields FROM events WHERE contract = ? AND name = 'PaymentTokenChanged' AND (block_number < ? OR (block_number = ? AND log_index < ?))
+		ORDER BY block_number DESC, log_index DESC LIMIT 1`,
+		lottery.Hex(), eventLog.BlockNumber, eventLog.BlockNumber, eventLog.Index).Scan(&fields)
+	if errors.Is(err, sql.ErrNoRows) {
+		var token string
+		if err := tx.QueryRow(`SELECT payment_token FROM cursors WHERE lottery = ?`, lottery.Hex()).Scan(&token); err != nil {
+			return common.Address{}, fmt.Errorf("failed to read lottery start token: %w", err)
+		}
+		return common.HexToAddress(token), nil
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read lottery payment token: %w", err)
+	}
+
+	var changed struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal([]byte(fields), &changed); err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode payment token change: %w", err)
+	}
+	return common.HexToAddress(changed.Token), nil
+}
+
+// PlayerStats summarises an address's lottery activity. Amounts are summed
+// per token they were paid in, the zero address for ether.
+type PlayerStats struct {
+	Entries int64
+	Staked  map[common.Address]*big.Int
+	Wins    int64
+	Won     map[common.Address]*big.Int
+}
+
+func (s *IndexerStore) PlayerStats(player common.Address) (*PlayerStats, error) {
+	stats := &PlayerStats{}
+
+	var err error
+	stats.Entries, stats.Staked, err = s.sumAmounts(`SELECT token, stake FROM lottery_entries WHERE player = ?`, player.Hex())
+	if err != nil {
+		return nil, err
+	}
+	stats.Wins, stats.Won, err = s.sumAmounts(`SELECT token, amount FROM lottery_rounds WHERE outcome = 'won' AND winner = ?`, player.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// LotteryRound is an ended round of a lottery. Rounds are numbered from the
+// lottery's deployment, or from RoundsFrom when indexing started at a later
+// block.
+type LotteryRound struct {
+	Lottery    common.Address  `json:"lottery"`
+	Round      int64           `json:"round"`
+	RoundsFrom uint64          `json:"roundsFromBlock,omitempty"`
+	Outcome    string          `json:"outcome"`
+	Winner     *common.Address `json:"winner,omitempty"`
+	// Token is what the amount is counted in, unset for ether
+	Token       *common.Address `json:"token,omitempty"`
+	Amount      *big.Int        `json:"amount"`
+	Entries     int64           `json:"entries"`
+	BlockNumber uint64          `json:"blockNumber"`
+	TxHash      common.Hash     `json:"transactionHash"`
+}
+
+// RoundHistory lists the ended rounds of a lottery, or of every indexed
+// lottery when none is given.
+func (s *IndexerStore) RoundHistory(lottery *common.Address) ([]LotteryRound, error) {
+	filter := ""
+	if lottery != nil {
+		filter = lottery.Hex()
+	}
+	rows, err := s.db.Query(`SELECT r.lottery, r.round, COALESCE(c.rounds_from, 0), r.outcome, r.winner, r.token, r.amount, r.block_number, r.tx_hash,
+			(SELECT COUNT(*) FROM lottery_entries e WHERE e.lottery = r.lottery AND e.round = r.round)
+		FROM lottery_rounds r LEFT JOIN cursors c ON c.lottery = r.lottery
+		WHERE ? = '' OR r.lottery = ? ORDER BY r.block_number, r.log_index`, filter, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lottery rounds: %w", err)
+	}
+	defer rows.Close()
+
+	var rounds []LotteryRound
+	for rows.Next() {
+		var round LotteryRound
+		var winner sql.NullString
+		var lotteryAddress, token, amount, txHash string
+		if err := rows.Scan(&lotteryAddress, &round.Round, &round.RoundsFrom, &round.Outcome, &winner, &token, &amount, &round.BlockNumber, &txHash, &round.Entries); err != nil {
+			return nil, fmt.Errorf("failed to read lottery round: %w", err)
+		}
+		round.Lottery = common.HexToAddress(lotteryAddress)
+		if winner.Valid {
+			address := common.HexToAddress(winner.String)
+			round.Winner = &address
+		}
+		if address := common.HexToAddress(token); address != (common.Address{}) {
+			round.Token = &address
+		}
+		round.Amount, _ = new(big.Int).SetString(amount, 10)
+		round.TxHash = common.HexToHash(txHash)
+		rounds = append(rounds, round)
+	}
+	return rounds, rows.Err()
+}
+
+// sumAmounts counts the rows of a query selecting a token and an amount,
+// and sums the amounts per token.
+func (s *IndexerStore) sumAmounts(query string, args ...interface{}) (int64, map[common.Address]*big.Int, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query indexer: %w", err)
+	}
+	defer rows.Close()
+
+	var count int64
+	sums := map[common.Address]*big.Int{}
+	for rows.Next() {
+		var token, text string
+		if err := rows.Scan(&token, &text); err != nil {
+			return 0, nil, fmt.Errorf("failed to read indexer row: %w", err)
+		}
+		amount, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return 0, nil, fmt.Errorf("invalid amount %q in indexer", text)
+		}
+		count++
+		address := common.HexToAddress(token)
+		if sums[address] == nil {
+			sums[address] = new(big.Int)
+		}
+		sums[address].Add(sums[address], amount)
+	}
+	return count, sums, rows.Err()
+}
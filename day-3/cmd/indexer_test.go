@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"day-3/lottery"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// indexerTest is a served chain with an indexer database shared by the
+// indexers of the test, which read the ABIs from the module root.
+type indexerTest struct {
+	*testChain
+	store *IndexerStore
+}
+
+func newIndexerTest(t *testing.T) *indexerTest {
+	t.Helper()
+	chain := newTestChain(t, 3)
+	chain.serve()
+	inModuleRoot(t)
+	t.Setenv("DEPLOYMENTS_FILE", filepath.Join(t.TempDir(), "deployments.json"))
+	t.Setenv("TOKEN_CONTRACT_ADDRESS", "")
+
+	store, err := OpenIndexerStore(filepath.Join(t.TempDir(), "indexer.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return &indexerTest{testChain: chain, store: store}
+}
+
+// indexer indexes the lottery, as a fresh run of 'indexer run' would.
+func (i *indexerTest) indexer(lotteryAddress common.Address, start *uint64, batchSize uint64, confirmations uint64) *Indexer {
+	i.t.Helper()
+	useLottery(i.t, lotteryAddress)
+	client, err := GetClient()
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	indexer, err := NewIndexer(client, i.store, start, batchSize, confirmations, 0)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	return indexer
+}
+
+// playRound has the players enter with the stakes, then the manager draw.
+func (i *indexerTest) playRound(contract *lottery.Lottery, stakes map[int]string) {
+	i.t.Helper()
+	for account, stake := range stakes {
+		i.enter(contract, account, stake)
+	}
+	i.mine(contract.PickWinner(i.transactor(0)))
+}
+
+func (i *indexerTest) expectCursor(lotteryAddress common.Address, want BlockRef) {
+	i.t.Helper()
+	cursor, err := i.store.Cursor(lotteryAddress)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	if cursor == nil || *cursor != want {
+		i.t.Fatalf("cursor of %v is %v, want %v", lotteryAddress, cursor, want)
+	}
+}
+
+func (i *indexerTest) rounds(lotteryAddress common.Address) []LotteryRound {
+	i.t.Helper()
+	rounds, err := i.store.RoundHistory(&lotteryAddress)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	return rounds
+}
+
+func (i *indexerTest) entries(player common.Address) int64 {
+	i.t.Helper()
+	stats, err := i.store.PlayerStats(player)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	return stats.Entries
+}
+
+func TestIndexerBackfillsAndResumesFromTheCursor(t *testing.T) {
+	test := newIndexerTest(t)
+	address, contract := test.deployLottery(0)
+	test.playRound(contract, map[int]string{1: "1", 2: "2"})
+	test.enter(contract, 1, "0.5")
+
+	if err := test.indexer(address, nil, 2, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	test.expectCursor(address, test.head())
+	rounds := test.rounds(address)
+	if len(rounds) != 1 || rounds[0].Round != 1 || rounds[0].Outcome != "won" || rounds[0].Entries != 2 || rounds[0].Amount.Cmp(mustParseEther(t, "3")) != 0 {
+		t.Fatalf("rounds are %+v", rounds)
+	}
+
+	test.enter(contract, 2, "0.5")
+	test.mine(contract.CancelRound(test.transactor(0)))
+
+	// a restart queries the two new blocks alone: the head, the batch's last
+	// block and its logs, then the head again to find nothing is left
+	indexer := test.indexer(address, nil, 2, 0)
+	before := test.requests.Load()
+	if err := indexer.backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if made := test.requests.Load() - before; made != 4 {
+		t.Fatalf("resuming took %d requests, want 4", made)
+	}
+	test.expectCursor(address, test.head())
+	rounds = test.rounds(address)
+	if len(rounds) != 2 || rounds[1].Round != 2 || rounds[1].Outcome != "cancelled" || rounds[1].Entries != 2 || rounds[1].Winner != nil {
+		t.Fatalf("rounds are %+v", rounds)
+	}
+	if entries := test.entries(test.accounts[1]); entries != 2 {
+		t.Fatalf("account 1 has %d entries, want 2", entries)
+	}
+}
+
+func TestIndexerRollsBackAReorgThatHappenedWhileStopped(t *testing.T) {
+	test := newIndexerTest(t)
+	address, contract := test.deployLottery(0)
+	test.enter(contract, 1, "1")
+	ctx := context.Background()
+
+	indexer := test.indexer(address, nil, defaultIndexerBatch, 2)
+	follower, handle, err := indexer.catchUp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := follower.Sync(ctx, handle); err != nil {
+		t.Fatal(err)
+	}
+	parent := test.head()
+
+	test.playRound(contract, map[int]string{2: "2"})
+	if err := follower.Sync(ctx, handle); err != nil {
+		t.Fatal(err)
+	}
+	if rounds := test.rounds(address); len(rounds) != 1 || rounds[0].Entries != 2 {
+		t.Fatalf("rounds are %+v", rounds)
+	}
+
+	// the round is orphaned while the indexer is stopped
+	test.reorg(parent.Number, 3)
+	follower, handle, err = test.indexer(address, nil, defaultIndexerBatch, 2).catchUp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := follower.Sync(ctx, handle); err != nil {
+		t.Fatal(err)
+	}
+
+	if rounds := test.rounds(address); len(rounds) != 0 {
+		t.Fatalf("orphaned rounds are left: %+v", rounds)
+	}
+	if entries := test.entries(test.accounts[2]); entries != 0 {
+		t.Fatalf("%d orphaned entries are left", entries)
+	}
+	if entries := test.entries(test.accounts[1]); entries != 1 {
+		t.Fatalf("the entry below the ancestor was rolled back")
+	}
+	test.expectCursor(address, test.head())
+	blocks, err := test.store.BlockHashes(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range blocks {
+		if block != test.blockRef(block.Number) {
+			t.Fatalf("orphaned block %v is still recorded", block)
+		}
+	}
+}
+
+func TestIndexerKeepsLotteriesSharingADatabaseApart(t *testing.T) {
+	test := newIndexerTest(t)
+	first, firstContract := test.deployLottery(0)
+	second, secondContract := test.deployLottery(0)
+	test.playRound(secondContract, map[int]string{2: "2"})
+	test.playRound(firstContract, map[int]string{1: "1"})
+
+	if err := test.indexer(first, nil, defaultIndexerBatch, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	firstCursor := test.head()
+
+	// the second lottery's round is below the first one's cursor, and must
+	// not be skipped
+	test.playRound(secondContract, map[int]string{1: "1"})
+	if err := test.indexer(second, nil, defaultIndexerBatch, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	test.expectCursor(first, firstCursor)
+	test.expectCursor(second, test.head())
+	if rounds := test.rounds(first); len(rounds) != 1 || rounds[0].Round != 1 || *rounds[0].Winner != test.accounts[1] {
+		t.Fatalf("rounds of the first lottery are %+v", rounds)
+	}
+	rounds := test.rounds(second)
+	if len(rounds) != 2 || rounds[0].Round != 1 || *rounds[0].Winner != test.accounts[2] || rounds[1].Round != 2 || *rounds[1].Winner != test.accounts[1] {
+		t.Fatalf("rounds of the second lottery are %+v", rounds)
+	}
+	if all, err := test.store.RoundHistory(nil); err != nil || len(all) != 3 {
+		t.Fatalf("rounds of every lottery are %+v: %v", all, err)
+	}
+
+	// rolling one lottery back leaves the other one alone
+	if err := test.store.Rollback(second, []common.Address{second}, test.blockRef(firstCursor.Number)); err != nil {
+		t.Fatal(err)
+	}
+	if rounds := test.rounds(second); len(rounds) != 1 {
+		t.Fatalf("rounds of the second lottery are %+v", rounds)
+	}
+	if rounds := test.rounds(first); len(rounds) != 1 {
+		t.Fatalf("rounds of the first lottery are %+v", rounds)
+	}
+	test.expectCursor(first, firstCursor)
+}
+
+func TestIndexerNumbersRoundsFromALaterStartBlock(t *testing.T) {
+	test := newIndexerTest(t)
+	address, contract := test.deployLottery(0)
+	test.playRound(contract, map[int]string{1: "1"})
+	start := test.head().Number + 1
+	test.playRound(contract, map[int]string{2: "1"})
+
+	if err := test.indexer(address, &start, defaultIndexerBatch, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	rounds := test.rounds(address)
+	if len(rounds) != 1 || rounds[0].Round != 1 || rounds[0].RoundsFrom != start {
+		t.Fatalf("rounds are %+v, want one numbered from block %d", rounds, start)
+	}
+
+	// a restart without the start block keeps numbering from it
+	if indexer := test.indexer(address, nil, defaultIndexerBatch, 0); indexer.roundsFrom != start {
+		t.Fatalf("restarted indexer numbers rounds from block %d", indexer.roundsFrom)
+	}
+}
+
+func TestIndexerKeepsTheTokenAmountsArePaidIn(t *testing.T) {
+	test := newIndexerTest(t)
+	address, contract := test.deployLottery(0)
+	test.playRound(contract, map[int]string{1: "1"})
+
+	// the next round is priced at 2.5 tokens of a token with 6 decimals
+	tokenAddress, tokenContract := test.deployTestContract("SixDecimalToken", 1, big.NewInt(1_000_000_000))
+	test.mine(contract.SetPaymentToken(test.transactor(0), tokenAddress, big.NewInt(2_500_000)))
+	test.mine(tokenContract.Transact(test.transactor(1), "approve", address, big.NewInt(2_500_000)))
+	test.mine(contract.EnterWithToken(test.transactor(1)))
+	test.mine(contract.PickWinner(test.transactor(0)))
+
+	if err := test.indexer(address, nil, defaultIndexerBatch, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	rounds := test.rounds(address)
+	if len(rounds) != 2 || rounds[0].Token != nil || rounds[1].Token == nil || *rounds[1].Token != tokenAddress {
+		t.Fatalf("rounds are %+v", rounds)
+	}
+
+	stats, err := test.store.PlayerStats(test.accounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 2 || len(stats.Staked) != 2 || stats.Staked[common.Address{}].Cmp(mustParseEther(t, "1")) != 0 || stats.Staked[tokenAddress].Cmp(big.NewInt(2_500_000)) != 0 {
+		t.Fatalf("account 1 staked %v in %d entries", stats.Staked, stats.Entries)
+	}
+
+	amounts := newAmountFormatter()
+	want := "1 ether and 2.5 of token " + tokenAddress.Hex()
+	if staked := amounts.formatSums(stats.Staked); staked != want {
+		t.Fatalf("stakes are shown as %q, want %q", staked, want)
+	}
+}
+
+func TestIndexerStartsAfterAPaymentTokenChangeInTheToken(t *testing.T) {
+	test := newIndexerTest(t)
+	address, contract := test.deployLottery(0)
+	tokenAddress, tokenContract := test.deployTestContract("SixDecimalToken", 1, big.NewInt(1_000_000_000))
+	test.mine(contract.SetPaymentToken(test.transactor(0), tokenAddress, big.NewInt(2_500_000)))
+	start := test.head().Number + 1
+	test.mine(tokenContract.Transact(test.transactor(1), "approve", address, big.NewInt(2_500_000)))
+	test.mine(contract.EnterWithToken(test.transactor(1)))
+	test.mine(contract.CancelRound(test.transactor(0)))
+
+	if err := test.indexer(address, &start, defaultIndexerBatch, 0).backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if rounds := test.rounds(address); len(rounds) != 1 || rounds[0].Token == nil || *rounds[0].Token != tokenAddress {
+		t.Fatalf("rounds are %+v", rounds)
+	}
+}
+
+func TestIndexerStoreDropsAnOlderSchema(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "indexer.db")
+	db, err := sql.Open("sqlite3", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE lottery_entries (block_number INTEGER NOT NULL, tx_hash TEXT NOT NULL, log_index INTEGER NOT NULL,
+		lottery TEXT NOT NULL, round INTEGER NOT NULL, player TEXT NOT NULL, stake TEXT NOT NULL, PRIMARY KEY (tx_hash, log_index));
+		INSERT INTO lottery_entries VALUES (1, '0x01', 0, '0x02', 1, '0x03', '1')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := OpenIndexerStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	stats, err := store.PlayerStats(common.HexToAddress("0x03"))
+	if err != nil || stats.Entries != 0 {
+		t.Fatalf("old entries are kept: %+v, %v", stats, err)
+	}
+}
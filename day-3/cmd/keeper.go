@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	defaultKeeperLock = "./keeper.lock"
+
+	// maxKeeperBackoff caps the wait between attempts on a lottery whose
+	// draws keep failing.
+	maxKeeperBackoff = 10 * time.Minute
+)
+
+func keeperCommand() *cobra.Command {
+	var selections []string
+	var schedules []string
+	var maxPlayers uint64
+	var minPlayers uint64
+	var interval time.Duration
+	var backoff time.Duration
+	var lockFile string
+	var leaseTtl time.Duration
+	var dryRun bool
+	var metrics *metricsOptions
+
+	command := &cobra.Command{
+		Use:   "keeper",
+		Short: "pick winners when rounds expire, fill up or are scheduled to draw",
+		Run: func(cmd *cobra.Command, args []string) {
+			config := KeeperConfig{
+				MaxPlayers: maxPlayers,
+				MinPlayers: minPlayers,
+				Interval:   interval,
+				Backoff:    backoff,
+				DryRun:     dryRun,
+			}
+
+			if len(selections) == 0 {
+				config.Lotteries = []common.Address{getLotteryAddress()}
+			}
+			for _, selection := range selections {
+				if err := selectLottery(selection); err != nil {
+					log.Fatal(err)
+				}
+				config.Lotteries = append(config.Lotteries, getLotteryAddress())
+			}
+			// the metrics report the first lottery
+			if len(selections) > 0 {
+				selectLottery(selections[0])
+			}
+
+			for _, expression := range schedules {
+				schedule, err := cron.ParseStandard(expression)
+				if err != nil {
+					log.Fatal(fmt.Errorf("invalid schedule %q: %w", expression, err))
+				}
+				config.Schedules = append(config.Schedules, schedule)
+			}
+
+			if err := metrics.start(); err != nil {
+				log.Fatal(err)
+			}
+
+			if leaseTtl == 0 {
+				leaseTtl = 3 * interval
+			}
+			lease := NewLease(lockFile, leaseTtl)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if err := NewKeeper(config, lease).Run(ctx); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	command.Flags().StringSliceVar(&selections, "lottery", nil, "lottery address or factory id to keep, repeat for several (defaults to LOTTERY_CONTRACT_ADDRESS)")
+	command.Flags().StringArrayVar(&schedules, "schedule", nil, "cron schedule to draw on, such as \"0 20 * * FRI\" or \"@daily\", repeat for several")
+	command.Flags().Uint64Var(&maxPlayers, "max-players", 0, "draw as soon as this many players entered (0 disables)")
+	command.Flags().Uint64Var(&minPlayers, "min-players", 1, "players a scheduled draw needs, it is skipped with fewer")
+	command.Flags().DurationVar(&interval, "interval", time.Minute, "how often the lotteries are checked")
+	command.Flags().DurationVar(&backoff, "backoff", 30*time.Second, "wait after a failed draw, doubled after each next failure")
+	command.Flags().StringVar(&lockFile, "lock", getKeeperLock(), "lock file electing the keeper that acts, shared by standbys (defaults to KEEPER_LOCK)")
+	command.Flags().DurationVar(&leaseTtl, "lease", 0, "how long the lock stays held without renewal (defaults to 3 intervals)")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "simulate and sign the draws without sending them")
+	metrics = addMetricsFlags(command, true)
+	return command
+}
+
+func getKeeperLock() string {
+	if file := os.Getenv("KEEPER_LOCK"); file != "" {
+		return file
+	}
+	return defaultKeeperLock
+}
+
+// KeeperConfig chooses when the keeper draws. A round is drawn once its
+// deadline passed, once it has MaxPlayers players, or on a schedule when
+// it has at least MinPlayers players.
+type KeeperConfig struct {
+	Lotteries  []common.Address
+	Schedules  []cron.Schedule
+	MaxPlayers uint64
+	MinPlayers uint64
+	Interval   time.Duration
+	Backoff    time.Duration
+	DryRun     bool
+}
+
+// Keeper picks the winners of the configured lotteries, as the account
+// of the selected signer, which needs their operator role. Only the keeper
+// holding the lease acts, so standbys can share its lock file.
+type Keeper struct {
+	config    KeeperConfig
+	lease     *Lease
+	lotteries []*keptLottery
+	leading   bool
+}
+
+// keptLottery is the keeper's view of one lottery between checks.
+type keptLottery struct {
+	address  common.Address
+	nextRuns []time.Time
+
+	// due is set by a schedule, and cleared by the next draw
+	due bool
+	// pending is a sent draw waiting to be mined
+	pending  *common.Hash
+	failures int
+	retryAt  time.Time
+}
+
+func NewKeeper(config KeeperConfig, lease *Lease) *Keeper {
+	keeper := &Keeper{config: config, lease: lease}
+	now := time.Now()
+	for _, address := range config.Lotteries {
+		kept := &keptLottery{address: address}
+		for _, schedule := range config.Schedules {
+			kept.nextRuns = append(kept.nextRuns, schedule.Next(now))
+		}
+		keeper.lotteries = append(keeper.lotteries, kept)
+	}
+	return keeper
+}
+
+// Run checks the lotteries every interval until the context is cancelled,
+// then gives up the lease. Failures are logged and retried with backoff.
+func (k *Keeper) Run(ctx context.Context) error {
+	defer func() {
+		if err := k.lease.Release(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	if k.config.DryRun {
+		log.Println("dry run, draws are simulated and not sent")
+	}
+	log.Println("keeping ", len(k.lotteries), " lotteries as ", k.lease.Owner())
+
+	ticker := time.NewTicker(k.config.Interval)
+	defer ticker.Stop()
+	for {
+		k.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (k *Keeper) tick(ctx context.Context) {
+	now := time.Now()
+	for _, kept := range k.lotteries {
+		for i, schedule := range k.config.Schedules {
+			if !now.Before(kept.nextRuns[i]) {
+				kept.due = true
+				kept.nextRuns[i] = schedule.Next(now)
+			}
+		}
+	}
+
+	leading, err := k.lease.Acquire()
+	if err != nil {
+		log.Println(err)
+		leading = false
+	}
+	if leading != k.leading {
+		if leading {
+			log.Println("acquired the keeper lock, keeping the lotteries")
+			k.checkRoles()
+		} else {
+			log.Println("another keeper holds the lock, standing by")
+		}
+		k.leading = leading
+	}
+	if !leading {
+		return
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, kept := range k.lotteries {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := k.check(ctx, client, kept); err != nil {
+			kept.failed(k.config.Backoff)
+			log.Println(kept.address, ": ", err, ", retrying after ", kept.retryAt.Format(time.RFC3339))
+		}
+	}
+}
+
+// checkRoles warns about lotteries the account cannot draw.
+func (k *Keeper) checkRoles() {
+	client, err := GetClient()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	signer, err := GetSigner()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	account := signer.Address()
+	for _, kept := range k.lotteries {
+		lotteryContract, err := lottery.NewLottery(kept.address, client)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		operator, err := lotteryContract.HasRole(nil, LotteryRoles["operator"], account)
+		if err != nil {
+			log.Println(kept.address, ": failed to fetch roles: ", err)
+			continue
+		}
+		if !operator {
+			log.Println(kept.address, ": ", account, " lacks the operator role, its draws will fail")
+		}
+	}
+}
+
+func (k *Keeper) check(ctx context.Context, client *ethclient.Client, kept *keptLottery) error {
+	if time.Now().Before(kept.retryAt) {
+		return nil
+	}
+	if kept.pending != nil {
+		return k.checkPending(ctx, client, kept)
+	}
+
+	lotteryContract, err := lottery.NewLottery(kept.address, client)
+	if err != nil {
+		return fmt.Errorf("failed to bind lottery contract: %w", err)
+	}
+
+	paused, err := lotteryContract.Paused(nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery pause state: %w", err)
+	}
+	players, err := lotteryContract.GetPlayers(nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery players: %w", err)
+	}
+	deadline, err := lotteryContract.RoundDeadline(nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch round deadline: %w", err)
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	if paused {
+		return nil
+	}
+	if kept.due && uint64(len(players)) < k.config.MinPlayers {
+		log.Println(kept.address, ": skipping the scheduled draw, ", len(players), " players is below ", k.config.MinPlayers)
+		kept.due = false
+		return nil
+	}
+
+	var reasons []string
+	// past the deadline anyone may refund the round instead, so the draw
+	// races refundExpiredRound and may find the round already ended
+	if deadline.Sign() > 0 && header.Time >= deadline.Uint64() {
+		reasons = append(reasons, "the round deadline passed")
+	}
+	if k.config.MaxPlayers > 0 && uint64(len(players)) >= k.config.MaxPlayers {
+		reasons = append(reasons, fmt.Sprint(len(players), " players entered"))
+	}
+	if kept.due {
+		reasons = append(reasons, "a draw is scheduled")
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	auth, err := newTransactionOptions(client)
+	if err != nil {
+		return err
+	}
+	auth.Context = ctx
+	auth.NoSend = k.config.DryRun
+
+	transaction, err := lotteryContract.PickWinner(auth)
+	if err != nil {
+		if ended, _ := roundEnded(ctx, lotteryContract); ended {
+			log.Println(kept.address, ": the round ended before it was drawn")
+			kept.due = false
+			return nil
+		}
+		return fmt.Errorf("failed to pick lottery winner: %w", err)
+	}
+
+	kept.due = false
+	kept.failures = 0
+	reason := strings.Join(reasons, " and ")
+	if k.config.DryRun {
+		log.Println(kept.address, ": would pick a winner among ", len(players), " players, as ", reason, ", using ", transaction.Gas(), " gas")
+		return nil
+	}
+	hash := transaction.Hash()
+	kept.pending = &hash
+	log.Println(kept.address, ": picking a winner among ", len(players), " players, as ", reason, ", in transaction hash: ", hash)
+	return nil
+}
+
+// checkPending waits for a sent draw, so it is never sent twice. A draw
+// the node dropped is tried again.
+func (k *Keeper) checkPending(ctx context.Context, client *ethclient.Client, kept *keptLottery) error {
+	receipt, err := client.TransactionReceipt(ctx, *kept.pending)
+	if errors.Is(err, ethereum.NotFound) {
+		_, _, err := client.TransactionByHash(ctx, *kept.pending)
+		if errors.Is(err, ethereum.NotFound) {
+			log.Println(kept.address, ": draw ", *kept.pending, " was dropped by the node")
+			kept.pending = nil
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch transaction %v: %w", *kept.pending, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch receipt of %v: %w", *kept.pending, err)
+	}
+
+	hash := *kept.pending
+	kept.pending = nil
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		lotteryContract, err := lottery.NewLottery(kept.address, client)
+		if err != nil {
+			return fmt.Errorf("failed to bind lottery contract: %w", err)
+		}
+		if ended, _ := roundEnded(ctx, lotteryContract); ended {
+			log.Println(kept.address, ": draw ", hash, " reverted, the round ended before it was mined")
+			return nil
+		}
+		return fmt.Errorf("draw %v reverted in block %v", hash, receipt.BlockNumber)
+	}
+	log.Println(kept.address, ": winner picked in block ", receipt.BlockNumber)
+	kept.failures = 0
+	return nil
+}
+
+// roundEnded tells whether the round has no players left, as after a
+// refundExpiredRound or a draw by another operator that the keeper's draw
+// lost to.
+func roundEnded(ctx context.Context, lotteryContract *lottery.Lottery) (bool, error) {
+	players, err := lotteryContract.GetPlayers(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch lottery players: %w", err)
+	}
+	return len(players) == 0, nil
+}
+
+func (l *keptLottery) failed(backoff time.Duration) {
+	l.failures++
+	delay := backoff
+	for i := 1; i < l.failures && delay < maxKeeperBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxKeeperBackoff {
+		delay = maxKeeperBackoff
+	}
+	l.retryAt = time.Now().Add(delay)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// keptTestLottery is a lottery managed by account 0, kept by a keeper
+// signing as account 0.
+type keptTestLottery struct {
+	*testChain
+	contract *lottery.Lottery
+	keeper   *Keeper
+	view     *keptLottery
+	client   *ethclient.Client
+}
+
+func newKeptTestLottery(t *testing.T, config KeeperConfig) *keptTestLottery {
+	t.Helper()
+	chain := newTestChain(t, 3)
+	address, contract := chain.deployLottery(0)
+	chain.serve()
+	useSigner(t, &keySigner{key: chain.keys[0]})
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Lotteries = []common.Address{address}
+	config.Backoff = time.Minute
+	keeper := NewKeeper(config, NewLease(filepath.Join(t.TempDir(), "keeper.lock"), time.Minute))
+	return &keptTestLottery{testChain: chain, contract: contract, keeper: keeper, view: keeper.lotteries[0], client: client}
+}
+
+func (l *keptTestLottery) check() {
+	l.t.Helper()
+	if err := l.keeper.check(context.Background(), l.client, l.view); err != nil {
+		l.t.Fatal(err)
+	}
+}
+
+func (l *keptTestLottery) expire() {
+	l.t.Helper()
+	if err := l.AdjustTime(7*24*time.Hour + time.Second); err != nil {
+		l.t.Fatal(err)
+	}
+	l.Commit()
+}
+
+func (l *keptTestLottery) players() []common.Address {
+	l.t.Helper()
+	players, err := l.contract.GetPlayers(nil)
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return players
+}
+
+func TestKeeperDrawsOnceTheDeadlinePassed(t *testing.T) {
+	kept := newKeptTestLottery(t, KeeperConfig{MinPlayers: 1})
+	kept.enter(kept.contract, 1, "1")
+
+	kept.check()
+	if kept.view.pending != nil || len(kept.players()) != 1 {
+		t.Fatal("the keeper drew before the deadline")
+	}
+
+	kept.expire()
+	kept.check()
+	if kept.view.pending == nil {
+		t.Fatal("the keeper did not draw after the deadline")
+	}
+	kept.check()
+	if kept.view.pending != nil || kept.view.failures != 0 || len(kept.players()) != 0 {
+		t.Fatalf("draw was not confirmed: %+v", kept.view)
+	}
+	winnings, err := kept.contract.PendingWithdrawals(nil, kept.accounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if winnings.Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("winner was credited %v", winnings)
+	}
+}
+
+func TestKeeperDrawLosingToARefundIsNoFailure(t *testing.T) {
+	kept := newKeptTestLottery(t, KeeperConfig{MinPlayers: 1})
+	kept.enter(kept.contract, 1, "1")
+	kept.expire()
+
+	// a player refunds the round first, and the keeper's draw, sent with
+	// the players it read before, is mined after it and reverts
+	kept.mine(kept.contract.RefundExpiredRound(kept.transactor(2)))
+	draw := kept.transactor(0)
+	draw.GasLimit = 100_000
+	transaction, err := kept.contract.PickWinner(draw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept.Commit()
+	receipt, err := kept.TransactionReceipt(context.Background(), transaction.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusFailed {
+		t.Fatalf("the late draw did not revert: %v", err)
+	}
+
+	hash := transaction.Hash()
+	kept.view.pending = &hash
+	kept.check()
+	if kept.view.pending != nil || kept.view.failures != 0 {
+		t.Fatalf("the lost draw was taken as a failure: %+v", kept.view)
+	}
+}
+
+func TestKeeperScheduledDrawOfAnEndedRoundIsNoFailure(t *testing.T) {
+	// without a minimum the schedule draws even an empty round, which
+	// fails in estimation just like a draw that lost the deadline race
+	kept := newKeptTestLottery(t, KeeperConfig{MinPlayers: 0})
+	kept.view.due = true
+
+	kept.check()
+	if kept.view.pending != nil || kept.view.due {
+		t.Fatalf("the keeper drew an ended round: %+v", kept.view)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// StorageLayout is solc's storageLayout output for a single contract.
+type StorageLayout struct {
+	Storage []StorageVariable      `json:"storage"`
+	Types   map[string]StorageType `json:"types"`
+}
+
+type StorageVariable struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+type StorageType struct {
+	Encoding      string `json:"encoding"`
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+}
+
+// getStorageLayout reads the layout shipped in build, which the build
+// command rewrites along with the bytecode.
+func getStorageLayout(name string) (*StorageLayout, error) {
+	content, err := os.ReadFile(fmt.Sprintf("./build/%s_storage.json", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s storage layout, run the build command first: %w", name, err)
+	}
+
+	layout := &StorageLayout{}
+	if err := json.Unmarshal(content, layout); err != nil {
+		return nil, fmt.Errorf("failed to parse %s storage layout: %w", name, err)
+	}
+	return layout, nil
+}
+
+// describe compares types by their label and size, since the type ids solc
+// generates embed AST ids that change between compilations.
+func (l *StorageLayout) describe(variable StorageVariable) string {
+	storageType, ok := l.Types[variable.Type]
+	if !ok {
+		return variable.Type
+	}
+	return fmt.Sprintf("%s (%s bytes)", storageType.Label, storageType.NumberOfBytes)
+}
+
+// span returns the first byte the variable occupies, counting from the start
+// of slot 0, and the byte after its last, so that variables spanning several
+// slots can be compared with packed ones.
+func (l *StorageLayout) span(variable StorageVariable) (*big.Int, *big.Int) {
+	start, ok := new(big.Int).SetString(variable.Slot, 10)
+	if !ok {
+		start = new(big.Int)
+	}
+	start.Mul(start, big.NewInt(32))
+	start.Add(start, big.NewInt(int64(variable.Offset)))
+
+	// a type missing from the layout is assumed to fill its slot
+	size := big.NewInt(int64(32 - variable.Offset))
+	if storageType, ok := l.Types[variable.Type]; ok {
+		if numberOfBytes, ok := new(big.Int).SetString(storageType.NumberOfBytes, 10); ok {
+			size = numberOfBytes
+		}
+	}
+	return start, new(big.Int).Add(start, size)
+}
+
+// CheckStorageLayoutUpgrade lists every way in which upgrading from the
+// previous layout to the next would corrupt the proxy's storage. Each
+// previous variable must keep its name, slot, offset and type; new
+// variables may only go into slots the previous version did not use.
+func CheckStorageLayoutUpgrade(previous *StorageLayout, next *StorageLayout) []string {
+	type position struct {
+		slot   string
+		offset int
+	}
+
+	nextVariables := map[position]StorageVariable{}
+	for _, variable := range next.Storage {
+		nextVariables[position{variable.Slot, variable.Offset}] = variable
+	}
+
+	var problems []string
+	for _, variable := range previous.Storage {
+		at := position{variable.Slot, variable.Offset}
+		replacement, ok := nextVariables[at]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s at slot %s offset %d was removed or moved", variable.Label, variable.Slot, variable.Offset))
+			continue
+		}
+		if replacement.Label != variable.Label {
+			problems = append(problems, fmt.Sprintf("slot %s offset %d was %s and is now %s", variable.Slot, variable.Offset, variable.Label, replacement.Label))
+		}
+		if previousType, nextType := previous.describe(variable), next.describe(replacement); previousType != nextType {
+			problems = append(problems, fmt.Sprintf("%s changed type from %s to %s", variable.Label, previousType, nextType))
+		}
+	}
+
+	previousVariables := map[position]bool{}
+	for _, variable := range previous.Storage {
+		previousVariables[position{variable.Slot, variable.Offset}] = true
+	}
+	for _, variable := range next.Storage {
+		if previousVariables[position{variable.Slot, variable.Offset}] {
+			continue
+		}
+		start, end := next.span(variable)
+		for _, existing := range previous.Storage {
+			existingStart, existingEnd := previous.span(existing)
+			if start.Cmp(existingEnd) < 0 && existingStart.Cmp(end) < 0 {
+				problems = append(problems, fmt.Sprintf("new variable %s at slot %s offset %d overlaps %s at slot %s offset %d", variable.Label, variable.Slot, variable.Offset, existing.Label, existing.Slot, existing.Offset))
+			}
+		}
+	}
+
+	return problems
+}
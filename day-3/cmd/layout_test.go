@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+var layoutTestTypes = map[string]StorageType{
+	"t_address":          {Encoding: "inplace", Label: "address", NumberOfBytes: "20"},
+	"t_bool":             {Encoding: "inplace", Label: "bool", NumberOfBytes: "1"},
+	"t_uint256":          {Encoding: "inplace", Label: "uint256", NumberOfBytes: "32"},
+	"t_uint64":           {Encoding: "inplace", Label: "uint64", NumberOfBytes: "8"},
+	"t_array_uint256_2":  {Encoding: "inplace", Label: "uint256[2]", NumberOfBytes: "64"},
+	"t_mapping_address":  {Encoding: "mapping", Label: "mapping(address => uint256)", NumberOfBytes: "32"},
+	"t_array_address_dy": {Encoding: "dynamic_array", Label: "address[]", NumberOfBytes: "32"},
+}
+
+func testLayout(variables ...StorageVariable) *StorageLayout {
+	return &StorageLayout{Storage: variables, Types: layoutTestTypes}
+}
+
+func TestCheckStorageLayoutUpgrade(t *testing.T) {
+	previous := testLayout(
+		StorageVariable{Label: "manager", Slot: "0", Offset: 0, Type: "t_address"},
+		StorageVariable{Label: "paused", Slot: "0", Offset: 20, Type: "t_bool"},
+		StorageVariable{Label: "players", Slot: "1", Offset: 0, Type: "t_array_address_dy"},
+		StorageVariable{Label: "limits", Slot: "2", Offset: 0, Type: "t_array_uint256_2"},
+		StorageVariable{Label: "pot", Slot: "4", Offset: 0, Type: "t_uint256"},
+	)
+
+	tests := []struct {
+		name     string
+		next     *StorageLayout
+		problems []string
+	}{
+		{"unchanged", previous, nil},
+		{
+			"appended",
+			testLayout(append(previous.Storage,
+				StorageVariable{Label: "treasury", Slot: "5", Offset: 0, Type: "t_address"},
+				StorageVariable{Label: "fees", Slot: "6", Offset: 0, Type: "t_mapping_address"},
+			)...),
+			nil,
+		},
+		{
+			"packed into a free tail",
+			testLayout(append(previous.Storage,
+				StorageVariable{Label: "epoch", Slot: "0", Offset: 21, Type: "t_uint64"},
+			)...),
+			nil,
+		},
+		{
+			"removed",
+			testLayout(previous.Storage[0], previous.Storage[1], previous.Storage[3], previous.Storage[4]),
+			[]string{"players at slot 1 offset 0 was removed or moved"},
+		},
+		{
+			"renamed",
+			testLayout(previous.Storage[0], previous.Storage[1], previous.Storage[2], previous.Storage[3],
+				StorageVariable{Label: "jackpot", Slot: "4", Offset: 0, Type: "t_uint256"},
+			),
+			[]string{"slot 4 offset 0 was pot and is now jackpot"},
+		},
+		{
+			"retyped",
+			testLayout(previous.Storage[0],
+				StorageVariable{Label: "paused", Slot: "0", Offset: 20, Type: "t_uint64"},
+				previous.Storage[2], previous.Storage[3], previous.Storage[4],
+			),
+			[]string{"paused changed type from bool (1 bytes) to uint64 (8 bytes)"},
+		},
+		{
+			"new variable inside a packed slot",
+			testLayout(append(previous.Storage,
+				StorageVariable{Label: "epoch", Slot: "0", Offset: 16, Type: "t_uint64"},
+			)...),
+			[]string{
+				"new variable epoch at slot 0 offset 16 overlaps manager at slot 0 offset 0",
+				"new variable epoch at slot 0 offset 16 overlaps paused at slot 0 offset 20",
+			},
+		},
+		{
+			"new variable in the second slot of an array",
+			testLayout(append(previous.Storage,
+				StorageVariable{Label: "round", Slot: "3", Offset: 0, Type: "t_uint256"},
+			)...),
+			[]string{"new variable round at slot 3 offset 0 overlaps limits at slot 2 offset 0"},
+		},
+		{
+			"new array running into a previous slot",
+			testLayout(previous.Storage[0], previous.Storage[1], previous.Storage[2], previous.Storage[4],
+				StorageVariable{Label: "bounds", Slot: "3", Offset: 0, Type: "t_array_uint256_2"},
+			),
+			[]string{
+				"limits at slot 2 offset 0 was removed or moved",
+				"new variable bounds at slot 3 offset 0 overlaps limits at slot 2 offset 0",
+				"new variable bounds at slot 3 offset 0 overlaps pot at slot 4 offset 0",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := CheckStorageLayoutUpgrade(previous, test.next)
+			if strings.Join(problems, "\n") != strings.Join(test.problems, "\n") {
+				t.Fatalf("got problems:\n%s\nwant:\n%s", strings.Join(problems, "\n"), strings.Join(test.problems, "\n"))
+			}
+		})
+	}
+}
+
+func TestLotteryStorageLayoutIsShipped(t *testing.T) {
+	inModuleRoot(t)
+	layout, err := getStorageLayout("Lottery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout.Storage) == 0 || layout.Storage[0].Label != "manager" {
+		t.Fatalf("shipped layout starts with %+v", layout.Storage)
+	}
+	if problems := CheckStorageLayoutUpgrade(layout, layout); len(problems) != 0 {
+		t.Fatalf("the layout conflicts with itself: %v", problems)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// leaseSettle is how long a takeover waits before reading the lock file
+// back, so two processes racing for an expired lease settle on one.
+const leaseSettle = 200 * time.Millisecond
+
+// Lease is leadership held through a lock file, so that only one of
+// several processes sharing the file acts at a time. The holder renews it
+// well within its ttl, and any other process takes over once it expired,
+// such as after a crash.
+type Lease struct {
+	path  string
+	owner string
+	ttl   time.Duration
+}
+
+type leaseRecord struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+func NewLease(path string, ttl time.Duration) *Lease {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return &Lease{path: path, owner: fmt.Sprintf("%s/%d/%s", host, os.Getpid(), hex.EncodeToString(suffix)), ttl: ttl}
+}
+
+func (l *Lease) Owner() string {
+	return l.owner
+}
+
+// Acquire takes or renews the lease, and reports whether this process
+// holds it.
+func (l *Lease) Acquire() (bool, error) {
+	record, err := l.read()
+	if errors.Is(err, os.ErrNotExist) {
+		return l.create()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if record.Owner == l.owner {
+		return true, l.write()
+	}
+	if time.Now().Before(record.Expires) {
+		return false, nil
+	}
+
+	if err := l.write(); err != nil {
+		return false, err
+	}
+	time.Sleep(leaseSettle)
+	record, err = l.read()
+	if err != nil {
+		return false, err
+	}
+	return record.Owner == l.owner, nil
+}
+
+// Release gives up the lease if this process holds it, so a standby can
+// take over without waiting for it to expire.
+func (l *Lease) Release() error {
+	record, err := l.read()
+	if err != nil || record.Owner != l.owner {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
+
+// read treats an unreadable record as expired, so a torn write cannot
+// block every process.
+func (l *Lease) read() (leaseRecord, error) {
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return leaseRecord{}, err
+		}
+		return leaseRecord{}, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var record leaseRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		return leaseRecord{}, nil
+	}
+	return record, nil
+}
+
+func (l *Lease) record() ([]byte, error) {
+	content, err := json.Marshal(leaseRecord{Owner: l.owner, Expires: time.Now().Add(l.ttl).UTC()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	return append(content, '\n'), nil
+}
+
+// create only succeeds for the first process, when there is no lock file.
+func (l *Lease) create() (bool, error) {
+	content, err := l.record()
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(content); err != nil {
+		return false, fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return true, nil
+}
+
+// write replaces the lock file through a rename, so readers never see a
+// partial record.
+func (l *Lease) write() error {
+	content, err := l.record()
+	if err != nil {
+		return err
+	}
+
+	temporary, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	defer os.Remove(temporary.Name())
+	if _, err := temporary.Write(content); err != nil {
+		temporary.Close()
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	if err := temporary.Close(); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	if err := os.Rename(temporary.Name(), l.path); err != nil {
+		return fmt.Errorf("failed to replace lock file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// selectedLottery is set from the --lottery flag and takes precedence
+// over LOTTERY_CONTRACT_ADDRESS.
+var selectedLottery *common.Address
+
+func lotteryCommand() *cobra.Command {
+	var selection string
+
+	command := &cobra.Command{
+		Use: "lottery",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return selectLottery(selection)
+		},
+	}
+	command.PersistentFlags().StringVar(&selection, "lottery", "", "lottery address or factory id to act on (defaults to LOTTERY_CONTRACT_ADDRESS)")
+	command.AddCommand(lotteryDeployFactoryCommand())
+	command.AddCommand(lotteryCreateCommand())
+	command.AddCommand(lotteryListCommand())
+	command.AddCommand(lotteryResolveCommand())
+	command.AddCommand(lotteryStatusCommand())
+	command.AddCommand(lotteryEnterCommand())
+	command.AddCommand(lotteryPickWinnerCommand())
+	command.AddCommand(lotterySetPaymentTokenCommand())
+	command.AddCommand(lotteryClaimCommand())
+	command.AddCommand(lotteryFeesCommand())
+	command.AddCommand(lotteryRolesCommand())
+	command.AddCommand(lotteryTransferManagerCommand())
+	command.AddCommand(lotteryPauseCommand())
+	command.AddCommand(lotteryUnpauseCommand())
+	command.AddCommand(lotteryCancelRoundCommand())
+	command.AddCommand(lotteryRefundExpiredCommand())
+	command.AddCommand(lotterySetRoundDurationCommand())
+	return command
+}
+
+func lotteryStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "status",
+		Run: func(cmd *cobra.Command, args []string) {
+			snapshot, err := GetLotterySnapshot([]common.Address{getAccountAddress()})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if snapshot.Multicall != nil {
+				log.Println("as of block ", snapshot.BlockNumber, ", read through Multicall3 at ", *snapshot.Multicall)
+			} else {
+				log.Println("as of block ", snapshot.BlockNumber)
+			}
+
+			log.Println("lottery manager is: ", snapshot.Manager)
+			log.Println("lottery paused: ", snapshot.Paused)
+			if snapshot.PaymentToken == (common.Address{}) {
+				log.Println("current pot is: ", FormatUnits(snapshot.Pot, EtherDecimals), " ether")
+			} else {
+				log.Println("current pot is: ", FormatUnits(snapshot.Pot, snapshot.TokenDecimals), " of token ", snapshot.PaymentToken)
+			}
+			log.Println("lottery balance is: ", FormatUnits(snapshot.Balance, EtherDecimals), " ether")
+			if snapshot.PaymentToken == (common.Address{}) {
+				log.Println("tickets are paid in ether")
+			} else {
+				log.Println("tickets cost ", FormatUnits(snapshot.TicketPrice, snapshot.TokenDecimals), " of token ", snapshot.PaymentToken)
+			}
+			log.Println("current lottery players are: ", snapshot.Players)
+			logRoundDeadline(snapshot.Deadline)
+
+			for address, amount := range snapshot.Unclaimed {
+				log.Println("unclaimed winnings for ", address, ": ", FormatUnits(amount, EtherDecimals), " ether")
+			}
+			for address, amount := range snapshot.UnclaimedTokens {
+				log.Println("unclaimed token winnings for ", address, ": ", FormatUnits(amount, snapshot.TokenDecimals))
+			}
+		},
+	}
+}
+
+func lotteryPickWinnerCommand() *cobra.Command {
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use: "pick-winner",
+		Run: func(cmd *cobra.Command, args []string) {
+			if dryRun {
+				simulation, err := SimulateLotteryCall(nil, "pickWinner")
+				if err != nil {
+					log.Fatal(err)
+				}
+				logLotterySimulation(simulation)
+				return
+			}
+
+			log.Println("picking the lottery winner...")
+			transaction, err := PickLotteryWinner()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery winner described in transaction hash: ", transaction.Hash())
+		},
+	}
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the draw against the pending block without sending it")
+	return command
+}
+
+func lotteryClaimCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "claim",
+		Run: func(cmd *cobra.Command, args []string) {
+			account := []common.Address{getAccountAddress()}
+			unclaimed, err := GetUnclaimedWinnings(account)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(unclaimed) > 0 {
+				log.Println("claiming winnings...")
+				transaction, err := ClaimWinnings()
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println("winnings claimed in transaction hash: ", transaction.Hash())
+			}
+
+			paymentToken, _, err := GetLotteryPaymentToken()
+			if err != nil {
+				log.Fatal(err)
+			}
+			unclaimedTokens := map[common.Address]*big.Int{}
+			if paymentToken != (common.Address{}) {
+				unclaimedTokens, err = GetUnclaimedTokenWinnings(paymentToken, account)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+			if len(unclaimedTokens) > 0 {
+				log.Println("claiming token winnings...")
+				transaction, err := ClaimTokenWinnings(paymentToken)
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println("token winnings claimed in transaction hash: ", transaction.Hash())
+			}
+
+			if len(unclaimed) == 0 && len(unclaimedTokens) == 0 {
+				log.Println("nothing to claim.")
+			}
+		},
+	}
+}
+
+func GetLotteryManager() (common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	manager, err := lotteryContract.Manager(nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch lottery manager: %w", err)
+	}
+
+	return manager, nil
+}
+
+func GetLotteryPot() (*big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	pot, err := lotteryContract.Pot(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lottery pot: %w", err)
+	}
+
+	return pot, nil
+}
+
+// LotteryState is a snapshot of a lottery, as served to the frontend.
+type LotteryState struct {
+	Address     common.Address   `json:"address"`
+	Manager     common.Address   `json:"manager"`
+	Paused      bool             `json:"paused"`
+	Pot         *big.Int         `json:"pot"`
+	Players     []common.Address `json:"players"`
+	RoundOpen   bool             `json:"roundOpen"`
+	Deadline    *time.Time       `json:"roundDeadline,omitempty"`
+	FeeBasis    *big.Int         `json:"feeBasisPoints"`
+	TicketPrice *big.Int         `json:"ticketPrice,omitempty"`
+
+	// nil while tickets are paid in ether
+	PaymentToken *common.Address `json:"paymentToken,omitempty"`
+}
+
+func GetLotteryState() (*LotteryState, error) {
+	manager, err := GetLotteryManager()
+	if err != nil {
+		return nil, err
+	}
+
+	paused, err := GetLotteryPaused()
+	if err != nil {
+		return nil, err
+	}
+
+	pot, err := GetLotteryPot()
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := GetLotteryPlayers()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, err := GetRoundDeadline()
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := GetLotteryFees()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &LotteryState{
+		Address:   getLotteryAddress(),
+		Manager:   manager,
+		Paused:    paused,
+		Pot:       pot,
+		Players:   players,
+		RoundOpen: deadline.Open(),
+		FeeBasis:  fees.FeeBasisPoints,
+	}
+	if deadline.Open() {
+		state.Deadline = &deadline.Deadline
+	}
+	if fees.PaymentToken != (common.Address{}) {
+		_, ticketPrice, err := GetLotteryPaymentToken()
+		if err != nil {
+			return nil, err
+		}
+		state.PaymentToken = &fees.PaymentToken
+		state.TicketPrice = ticketPrice
+	}
+	return state, nil
+}
+
+// AccountBalances are an account's ether and token holdings, and its
+// winnings still held by the lottery.
+type AccountBalances struct {
+	Address            common.Address `json:"address"`
+	Ether              *big.Int       `json:"ether"`
+	Token              *big.Int       `json:"token,omitempty"`
+	UnclaimedWinnings  *big.Int       `json:"unclaimedWinnings"`
+	UnclaimedTokenWins *big.Int       `json:"unclaimedTokenWinnings,omitempty"`
+}
+
+// GetAccountBalances only includes the token balance when
+// TOKEN_CONTRACT_ADDRESS is set, and token winnings while the lottery is
+// priced in a token.
+func GetAccountBalances(address common.Address) (*AccountBalances, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ether, err := client.BalanceAt(context.Background(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance of %v: %w", address, err)
+	}
+	balances := &AccountBalances{Address: address, Ether: ether, UnclaimedWinnings: new(big.Int)}
+
+	if os.Getenv("TOKEN_CONTRACT_ADDRESS") != "" {
+		balances.Token, err = GetTokenBalance(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unclaimed, err := GetUnclaimedWinnings([]common.Address{address})
+	if err != nil {
+		return nil, err
+	}
+	if amount, ok := unclaimed[address]; ok {
+		balances.UnclaimedWinnings = amount
+	}
+
+	paymentToken, _, err := GetLotteryPaymentToken()
+	if err != nil {
+		return nil, err
+	}
+	if paymentToken != (common.Address{}) {
+		unclaimedTokens, err := GetUnclaimedTokenWinnings(paymentToken, []common.Address{address})
+		if err != nil {
+			return nil, err
+		}
+		balances.UnclaimedTokenWins = new(big.Int)
+		if amount, ok := unclaimedTokens[address]; ok {
+			balances.UnclaimedTokenWins = amount
+		}
+	}
+
+	return balances, nil
+}
+
+// GetUnclaimedWinnings returns the pending withdrawal of every given address
+// that still has winnings to claim.
+func GetUnclaimedWinnings(addresses []common.Address) (map[common.Address]*big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	unclaimed := make(map[common.Address]*big.Int)
+	for _, address := range addresses {
+		amount, err := lotteryContract.PendingWithdrawals(nil, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pending withdrawal for %v: %w", address, err)
+		}
+		if amount.Sign() > 0 {
+			unclaimed[address] = amount
+		}
+	}
+
+	return unclaimed, nil
+}
+
+func ClaimWinnings() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.Withdraw(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim lottery winnings: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// GetUnclaimedTokenWinnings is GetUnclaimedWinnings for rounds played in
+// the given payment token.
+func GetUnclaimedTokenWinnings(paymentToken common.Address, addresses []common.Address) (map[common.Address]*big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	unclaimed := make(map[common.Address]*big.Int)
+	for _, address := range addresses {
+		amount, err := lotteryContract.PendingTokenWithdrawals(nil, paymentToken, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pending token withdrawal for %v: %w", address, err)
+		}
+		if amount.Sign() > 0 {
+			unclaimed[address] = amount
+		}
+	}
+
+	return unclaimed, nil
+}
+
+func ClaimTokenWinnings(paymentToken common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.WithdrawToken(transactionOptions, paymentToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim lottery token winnings: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// newTransactionOptions returns signing options for the signer's account
+// with its pending nonce filled in. The gas limit is left to estimation, so
+// a transaction that would revert fails before it is sent.
+func newTransactionOptions(client *ethclient.Client) (*bind.TransactOpts, error) {
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	// the nonce must be the signer's, which ACCOUNT_ADDRESS need not name
+	nonce, err := client.PendingNonceAt(context.Background(), transactionOptions.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending nonce: %w", err)
+	}
+
+	transactionOptions.Nonce = big.NewInt(int64(nonce))
+	return transactionOptions, nil
+}
+
+func getLotteryContract(client *ethclient.Client) (*lottery.Lottery, error) {
+	lotteryContract, err := lottery.NewLottery(getLotteryAddress(), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind lottery contract: %w", err)
+	}
+	return lotteryContract, nil
+}
+
+func getLotteryAddress() common.Address {
+	if selectedLottery != nil {
+		return *selectedLottery
+	}
+	if address := os.Getenv("LOTTERY_CONTRACT_ADDRESS"); address != "" {
+		return common.HexToAddress(address)
+	}
+	return common.HexToAddress(TestContractAddress)
+}
+
+// getAccountAddress prefers ACCOUNT_ADDRESS, so read-only commands work
+// without unlocking a signer, and falls back to the signer's address.
+func getAccountAddress() common.Address {
+	if address := os.Getenv("ACCOUNT_ADDRESS"); address != "" {
+		return common.HexToAddress(address)
+	}
+	if signer, err := GetSigner(); err == nil {
+		return signer.Address()
+	}
+	return common.Address{}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPickWinnerCreditsContractWalletThatRefusesEther(t *testing.T) {
+	chain := newTestChain(t, 2)
+	lotteryAddress, lotteryContract := chain.deployLottery(0)
+	walletAddress, wallet := chain.deployTestContract("RevertingWallet", 1)
+
+	stake := mustParseEther(t, "1")
+	chain.mine(wallet.Transact(chain.paying(1, stake), "enter", lotteryAddress))
+
+	// the wallet is the only player, so it wins, and the round must not be
+	// bricked by it refusing the payout
+	chain.mine(lotteryContract.PickWinner(chain.transactor(0)))
+
+	pending, err := lotteryContract.PendingWithdrawals(nil, walletAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Cmp(stake) != 0 {
+		t.Fatalf("wallet was credited %v, want %v", pending, stake)
+	}
+	if balance := chain.balance(lotteryAddress); balance.Cmp(stake) != 0 {
+		t.Fatalf("lottery holds %v, want the unclaimed %v", balance, stake)
+	}
+
+	// claiming fails in the wallet's receive hook, which leaves the credit
+	// in place instead of losing it
+	if _, err := wallet.Transact(chain.transactor(1), "withdraw", lotteryAddress); err == nil {
+		t.Fatal("withdrawing to a wallet that refuses ether succeeded")
+	}
+	pending, err = lotteryContract.PendingWithdrawals(nil, walletAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Cmp(stake) != 0 {
+		t.Fatalf("failed withdrawal left %v credited, want %v", pending, stake)
+	}
+
+	// and the next round plays as usual
+	chain.enter(lotteryContract, 1, "0.5")
+	chain.mine(lotteryContract.PickWinner(chain.transactor(0)))
+	before := chain.balance(chain.accounts[1])
+	receipt := chain.mine(lotteryContract.Withdraw(chain.transactor(1)))
+	after := chain.balance(chain.accounts[1])
+	gained := after.Sub(after, before)
+	gained.Add(gained, chain.gasCost(receipt))
+	if want := mustParseEther(t, "0.5"); gained.Cmp(want) != 0 {
+		t.Fatalf("winner withdrew %v, want %v", gained, want)
+	}
+}
+
+func TestWithdrawWithoutWinningsReverts(t *testing.T) {
+	chain := newTestChain(t, 2)
+	_, lotteryContract := chain.deployLottery(0)
+
+	if _, err := lotteryContract.Withdraw(chain.transactor(1)); err == nil {
+		t.Fatal("withdrawing nothing succeeded")
+	}
+}
+
+func TestEnterLotteryEntersTheSelectedLottery(t *testing.T) {
+	chain := newTestChain(t, 2)
+	lotteryAddress, lotteryContract := chain.deployLottery(0)
+	chain.serve()
+	t.Setenv("LOTTERY_CONTRACT_ADDRESS", lotteryAddress.Hex())
+	useSigner(t, &keySigner{key: chain.keys[1]})
+
+	if _, err := EnterLottery(); err != nil {
+		t.Fatal(err)
+	}
+	players, err := lotteryContract.GetPlayers(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 1 || players[0] != chain.accounts[1] {
+		t.Fatalf("players of the selected lottery are %v, want the signer", players)
+	}
+}
@@ -0,0 +1,460 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"day-3/lottery"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "fredcoin"
+
+// maxTrackedTransactions bounds the sent transactions waiting for a
+// receipt, in case the node drops some of them.
+const maxTrackedTransactions = 1000
+
+// metricsScrapeTimeout bounds the node requests of a scrape, below the
+// scrape timeout Prometheus uses by default.
+const metricsScrapeTimeout = 8 * time.Second
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of JSON-RPC requests to the node, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "rpc",
+		Name:      "errors_total",
+		Help:      "Failed JSON-RPC requests, by method and by whether the transport, the HTTP status or the node's answer failed.",
+	}, []string{"method", "kind"})
+
+	rpcEndpointUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "rpc",
+		Name:      "endpoint_up",
+		Help:      "Whether the node endpoint is in use, 0 while its circuit breaker is open, by host.",
+	}, []string{"endpoint"})
+
+	sentTransactions = &transactionTracker{pending: map[common.Hash]struct{}{}}
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcDuration,
+		rpcErrors,
+		rpcEndpointUp,
+		sentTransactions,
+	)
+}
+
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+// metricsOptions are the flags of the long-lived commands that export
+// metrics.
+type metricsOptions struct {
+	listen            string
+	minManagerBalance string
+	maxRoundAge       time.Duration
+}
+
+// addMetricsFlags adds the alert thresholds, and with listen, the address
+// to serve /metrics on for commands without an HTTP server of their own.
+func addMetricsFlags(command *cobra.Command, listen bool) *metricsOptions {
+	options := &metricsOptions{}
+	if listen {
+		command.Flags().StringVar(&options.listen, "metrics", "", "address to serve Prometheus metrics on, such as :9100")
+	}
+	command.Flags().StringVar(&options.minManagerBalance, "min-manager-balance", "0.1", "ether balance below which the manager is reported as low")
+	command.Flags().DurationVar(&options.maxRoundAge, "max-round-age", 24*time.Hour, "age after which an open round is reported as stale")
+	return options
+}
+
+// start registers the lottery metrics, and serves them when an address was
+// given. Metrics are read from the node on every scrape.
+func (o *metricsOptions) start() error {
+	minManagerBalance, err := ParseUnits(o.minManagerBalance, EtherDecimals)
+	if err != nil {
+		return fmt.Errorf("invalid --min-manager-balance: %w", err)
+	}
+	if err := metricsRegistry.Register(newLotteryCollector(minManagerBalance, o.maxRoundAge)); err != nil {
+		return fmt.Errorf("failed to register lottery metrics: %w", err)
+	}
+
+	if o.listen == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler())
+	server := &http.Server{Addr: o.listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Println("serving metrics on ", o.listen)
+		if err := server.ListenAndServe(); err != nil {
+			log.Println("metrics server stopped: ", err)
+		}
+	}()
+	return nil
+}
+
+// metricsTransport times the JSON-RPC requests of HTTP endpoints, and
+// tracks the transactions they send.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+type rpcMessage struct {
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+func (t *metricsTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	method := "unknown"
+	if request.Body != nil {
+		body, err := io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var message rpcMessage
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			method = "batch"
+		} else if json.Unmarshal(body, &message) == nil && message.Method != "" {
+			method = message.Method
+		}
+	}
+
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	defer func() {
+		rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}()
+	if err != nil {
+		rpcErrors.WithLabelValues(method, "transport").Inc()
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		rpcErrors.WithLabelValues(method, "http").Inc()
+		return response, nil
+	}
+	if method == "batch" {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		rpcErrors.WithLabelValues(method, "transport").Inc()
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	var message rpcMessage
+	if err := json.Unmarshal(body, &message); err == nil {
+		if len(message.Error) > 0 && string(message.Error) != "null" {
+			rpcErrors.WithLabelValues(method, "rpc").Inc()
+		} else if method == "eth_sendRawTransaction" {
+			var hash common.Hash
+			if json.Unmarshal(message.Result, &hash) == nil {
+				sentTransactions.sent(hash)
+			}
+		}
+	}
+	return response, nil
+}
+
+// transactionTracker counts sent transactions, and how the ones it saw a
+// receipt for ended. Receipts are looked up on scrape.
+type transactionTracker struct {
+	lock      sync.Mutex
+	pending   map[common.Hash]struct{}
+	sentCount uint64
+	confirmed uint64
+	reverted  uint64
+	gasUsed   uint64
+}
+
+var (
+	transactionsDesc = prometheus.NewDesc(metricsNamespace+"_transactions_total", "Transactions sent, and how many of them were confirmed or reverted.", []string{"status"}, nil)
+	pendingDesc      = prometheus.NewDesc(metricsNamespace+"_transactions_pending", "Sent transactions without a receipt yet.", nil, nil)
+	gasUsedDesc      = prometheus.NewDesc(metricsNamespace+"_gas_used_total", "Gas used by the mined transactions this process sent.", nil, nil)
+)
+
+func (t *transactionTracker) sent(hash common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.sentCount++
+	if len(t.pending) < maxTrackedTransactions {
+		t.pending[hash] = struct{}{}
+	}
+}
+
+func (t *transactionTracker) Describe(descs chan<- *prometheus.Desc) {
+	descs <- transactionsDesc
+	descs <- pendingDesc
+	descs <- gasUsedDesc
+}
+
+func (t *transactionTracker) Collect(metrics chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsScrapeTimeout)
+	defer cancel()
+	t.resolve(ctx)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	metrics <- prometheus.MustNewConstMetric(transactionsDesc, prometheus.CounterValue, float64(t.sentCount), "sent")
+	metrics <- prometheus.MustNewConstMetric(transactionsDesc, prometheus.CounterValue, float64(t.confirmed), "confirmed")
+	metrics <- prometheus.MustNewConstMetric(transactionsDesc, prometheus.CounterValue, float64(t.reverted), "reverted")
+	metrics <- prometheus.MustNewConstMetric(pendingDesc, prometheus.GaugeValue, float64(len(t.pending)))
+	metrics <- prometheus.MustNewConstMetric(gasUsedDesc, prometheus.CounterValue, float64(t.gasUsed))
+}
+
+// resolve looks up the receipts of the pending transactions until the
+// context is done. The ones still unknown to the node, or not looked up in
+// time, stay pending for the next scrape.
+func (t *transactionTracker) resolve(ctx context.Context) {
+	t.lock.Lock()
+	hashes := make([]common.Hash, 0, len(t.pending))
+	for hash := range t.pending {
+		hashes = append(hashes, hash)
+	}
+	t.lock.Unlock()
+	if len(hashes) == 0 {
+		return
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for i, hash := range hashes {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if ctx.Err() != nil {
+			log.Println("looking up receipts timed out, ", len(hashes)-i, " transactions left to look up")
+			return
+		}
+		if err != nil {
+			log.Println("failed to fetch receipt of ", hash, ": ", err)
+			return
+		}
+
+		t.lock.Lock()
+		delete(t.pending, hash)
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			t.confirmed++
+		} else {
+			t.reverted++
+		}
+		t.gasUsed += receipt.GasUsed
+		t.lock.Unlock()
+	}
+}
+
+// lotteryCollector reads the lottery and the accounts running it on
+// every scrape, and derives the alert gauges from them.
+type lotteryCollector struct {
+	minManagerBalance *big.Int
+	maxRoundAge       time.Duration
+
+	up             *prometheus.Desc
+	pot            *prometheus.Desc
+	players        *prometheus.Desc
+	paused         *prometheus.Desc
+	roundAge       *prometheus.Desc
+	balance        *prometheus.Desc
+	nonceGap       *prometheus.Desc
+	managerLow     *prometheus.Desc
+	roundStale     *prometheus.Desc
+	scrapeDuration *prometheus.Desc
+}
+
+func newLotteryCollector(minManagerBalance *big.Int, maxRoundAge time.Duration) *lotteryCollector {
+	lottery := []string{"lottery"}
+	return &lotteryCollector{
+		minManagerBalance: minManagerBalance,
+		maxRoundAge:       maxRoundAge,
+		up:                prometheus.NewDesc(metricsNamespace+"_lottery_up", "Whether the lottery could be read from the node.", lottery, nil),
+		pot:               prometheus.NewDesc(metricsNamespace+"_lottery_pot", "Pot of the open round, in ether, or in whole tokens of the payment token's decimals when priced in a token.", lottery, nil),
+		players:           prometheus.NewDesc(metricsNamespace+"_lottery_players", "Players in the open round.", lottery, nil),
+		paused:            prometheus.NewDesc(metricsNamespace+"_lottery_paused", "Whether the lottery is paused.", lottery, nil),
+		roundAge:          prometheus.NewDesc(metricsNamespace+"_lottery_round_age_seconds", "Chain time since the first entry of the open round, 0 without players.", lottery, nil),
+		balance:           prometheus.NewDesc(metricsNamespace+"_account_balance_ether", "Ether balance of the lottery manager and of the configured account.", []string{"account", "role"}, nil),
+		nonceGap:          prometheus.NewDesc(metricsNamespace+"_account_nonce_gap", "Transactions of the configured account that are pending but not mined.", []string{"account"}, nil),
+		managerLow:        prometheus.NewDesc(metricsNamespace+"_manager_balance_low", "1 while the manager has less ether than --min-manager-balance.", lottery, nil),
+		roundStale:        prometheus.NewDesc(metricsNamespace+"_round_stale", "1 while the open round is older than --max-round-age.", lottery, nil),
+		scrapeDuration:    prometheus.NewDesc(metricsNamespace+"_lottery_scrape_duration_seconds", "Time taken to read the lottery state.", lottery, nil),
+	}
+}
+
+func (c *lotteryCollector) Describe(descs chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{c.up, c.pot, c.players, c.paused, c.roundAge, c.balance, c.nonceGap, c.managerLow, c.roundStale, c.scrapeDuration} {
+		descs <- desc
+	}
+}
+
+func (c *lotteryCollector) Collect(metrics chan<- prometheus.Metric) {
+	address := getLotteryAddress().Hex()
+	start := time.Now()
+
+	client, err := GetClient()
+	if err == nil {
+		err = c.collect(client, address, metrics)
+	}
+	if err != nil {
+		log.Println("failed to collect lottery metrics: ", err)
+	}
+
+	metrics <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, boolValue(err == nil), address)
+	metrics <- prometheus.MustNewConstMetric(c.scrapeDuration, prometheus.GaugeValue, time.Since(start).Seconds(), address)
+}
+
+func (c *lotteryCollector) collect(client *ethclient.Client, address string, metrics chan<- prometheus.Metric) error {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsScrapeTimeout)
+	defer cancel()
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return err
+	}
+	// every read is made at the same block, so the gauges agree
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	options := &bind.CallOpts{Context: ctx, BlockNumber: header.Number}
+
+	manager, err := lotteryContract.Manager(options)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery manager: %w", err)
+	}
+	paused, err := lotteryContract.Paused(options)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery pause state: %w", err)
+	}
+	pot, err := lotteryContract.Pot(options)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery pot: %w", err)
+	}
+	players, err := lotteryContract.GetPlayers(options)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lottery players: %w", err)
+	}
+	potDecimals, err := getPotDecimals(lotteryContract, client, options)
+	if err != nil {
+		return err
+	}
+
+	var roundAge time.Duration
+	if len(players) > 0 {
+		startedAt, err := lotteryContract.RoundStartedAt(options)
+		if err != nil {
+			return fmt.Errorf("failed to fetch round start: %w", err)
+		}
+		roundAge = time.Unix(int64(header.Time), 0).Sub(time.Unix(startedAt.Int64(), 0))
+	}
+
+	managerBalance, err := client.BalanceAt(ctx, manager, header.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch manager balance: %w", err)
+	}
+
+	metrics <- prometheus.MustNewConstMetric(c.pot, prometheus.GaugeValue, unitsValue(pot, potDecimals), address)
+	metrics <- prometheus.MustNewConstMetric(c.players, prometheus.GaugeValue, float64(len(players)), address)
+	metrics <- prometheus.MustNewConstMetric(c.paused, prometheus.GaugeValue, boolValue(paused), address)
+	metrics <- prometheus.MustNewConstMetric(c.roundAge, prometheus.GaugeValue, roundAge.Seconds(), address)
+	metrics <- prometheus.MustNewConstMetric(c.balance, prometheus.GaugeValue, etherValue(managerBalance), manager.Hex(), "manager")
+	metrics <- prometheus.MustNewConstMetric(c.managerLow, prometheus.GaugeValue, boolValue(managerBalance.Cmp(c.minManagerBalance) < 0), address)
+	metrics <- prometheus.MustNewConstMetric(c.roundStale, prometheus.GaugeValue, boolValue(roundAge > c.maxRoundAge), address)
+
+	account := getAccountAddress()
+	if account == (common.Address{}) {
+		return nil
+	}
+	if account != manager {
+		balance, err := client.BalanceAt(ctx, account, header.Number)
+		if err != nil {
+			return fmt.Errorf("failed to fetch account balance: %w", err)
+		}
+		metrics <- prometheus.MustNewConstMetric(c.balance, prometheus.GaugeValue, etherValue(balance), account.Hex(), "account")
+	}
+	pending, err := client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pending nonce: %w", err)
+	}
+	mined, err := client.NonceAt(ctx, account, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nonce: %w", err)
+	}
+	gap := uint64(0)
+	if pending > mined {
+		gap = pending - mined
+	}
+	metrics <- prometheus.MustNewConstMetric(c.nonceGap, prometheus.GaugeValue, float64(gap), account.Hex())
+	return nil
+}
+
+// getPotDecimals returns the decimals the pot is counted in: the payment
+// token's, or ether's when tickets are paid in ether.
+func getPotDecimals(lotteryContract *lottery.Lottery, client *ethclient.Client, options *bind.CallOpts) (int, error) {
+	paymentToken, err := lotteryContract.PaymentToken(options)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch lottery payment token: %w", err)
+	}
+	if paymentToken == (common.Address{}) {
+		return EtherDecimals, nil
+	}
+	return readTokenDecimals(client, paymentToken, options)
+}
+
+func etherValue(amount *big.Int) float64 {
+	return unitsValue(amount, EtherDecimals)
+}
+
+// unitsValue is the amount in whole units of the given decimals.
+func unitsValue(amount *big.Int, decimals int) float64 {
+	unit := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), unit).Float64()
+	return value
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
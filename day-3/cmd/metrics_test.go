@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func expectPot(t *testing.T, pot float64) {
+	t.Helper()
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(newLotteryCollector(big.NewInt(0), time.Hour))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "fredcoin_lottery_pot" {
+			continue
+		}
+		if got := family.GetMetric()[0].GetGauge().GetValue(); got != pot {
+			t.Fatalf("pot gauge is %v, want %v", got, pot)
+		}
+		return
+	}
+	t.Fatal("no pot gauge was collected")
+}
+
+func TestLotteryCollectorCountsThePotInItsDecimals(t *testing.T) {
+	chain := newTestChain(t, 2)
+	lotteryAddress, lotteryContract := chain.deployLottery(0)
+	chain.serve()
+	t.Setenv("LOTTERY_CONTRACT_ADDRESS", lotteryAddress.Hex())
+	t.Setenv("ACCOUNT_ADDRESS", "")
+
+	chain.enter(lotteryContract, 1, "1.5")
+	expectPot(t, 1.5)
+	chain.mine(lotteryContract.CancelRound(chain.transactor(0)))
+
+	// tickets priced at 2.5 tokens of a token with 6 decimals
+	tokenAddress, tokenContract := chain.deployTestContract("SixDecimalToken", 1, big.NewInt(1_000_000_000))
+	chain.mine(lotteryContract.SetPaymentToken(chain.transactor(0), tokenAddress, big.NewInt(2_500_000)))
+	chain.mine(tokenContract.Transact(chain.transactor(1), "approve", lotteryAddress, big.NewInt(5_000_000)))
+	chain.mine(lotteryContract.EnterWithToken(chain.transactor(1)))
+	chain.mine(lotteryContract.EnterWithToken(chain.transactor(1)))
+	expectPot(t, 5)
+}
+
+func TestTransactionTrackerResolvesReceipts(t *testing.T) {
+	chain := newTestChain(t, 2)
+	_, lotteryContract := chain.deployLottery(0)
+	chain.serve()
+	entry, err := lotteryContract.Enter(chain.paying(1, mustParseEther(t, "1")))
+	chain.mine(entry, err)
+	// the manager alone may draw, so this one is mined reverted
+	player := chain.transactor(1)
+	player.GasLimit = 100_000
+	draw, err := lotteryContract.PickWinner(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Commit()
+
+	tracker := &transactionTracker{pending: map[common.Hash]struct{}{}}
+	unknown := common.HexToHash("0x01")
+	for _, hash := range []common.Hash{entry.Hash(), draw.Hash(), unknown} {
+		tracker.sent(hash)
+	}
+	tracker.resolve(context.Background())
+
+	if tracker.confirmed != 1 || tracker.reverted != 1 || tracker.sentCount != 3 {
+		t.Fatalf("tracker counted %d confirmed and %d reverted of %d", tracker.confirmed, tracker.reverted, tracker.sentCount)
+	}
+	if _, pending := tracker.pending[unknown]; !pending || len(tracker.pending) != 1 {
+		t.Fatalf("pending transactions are %v, want the unknown one", tracker.pending)
+	}
+}
+
+func TestTransactionTrackerGivesUpWithTheScrape(t *testing.T) {
+	// a node that does not answer until the test ends
+	released := make(chan struct{})
+	node := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-released:
+		}
+	}))
+	t.Cleanup(func() {
+		close(released)
+		node.Close()
+	})
+	useNodeEndpoint(t, node.URL)
+
+	tracker := &transactionTracker{pending: map[common.Hash]struct{}{}}
+	for i := int64(1); i <= 3; i++ {
+		tracker.sent(common.BigToHash(big.NewInt(i)))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	tracker.resolve(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("resolving took %v past the scrape's deadline", elapsed)
+	}
+	if len(tracker.pending) != 3 || tracker.confirmed+tracker.reverted != 0 {
+		t.Fatalf("unanswered lookups resolved transactions: %v", tracker.pending)
+	}
+}
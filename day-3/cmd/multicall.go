@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"day-3/multicall"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CanonicalMulticall3 is where Multicall3 is deployed on most networks.
+var CanonicalMulticall3 = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+func deployMulticallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deploy-multicall",
+		Short: "deploy the mock Multicall3, for devnets that lack the real one",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("deploying Multicall3...")
+			address, err := DeployMulticall()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("Multicall3 deployed to address: ", address)
+		},
+	}
+}
+
+func DeployMulticall() (common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	bytecode, err := getContractBinary("Multicall3")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(multicall.Multicall3ABI))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to parse multicall abi: %w", err)
+	}
+
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	_, transaction, _, err := bind.DeployContract(transactionOptions, parsed, bytecode, client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy Multicall3: %w", err)
+	}
+
+	address, err := bind.WaitDeployed(context.Background(), client, transaction)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error occured while waiting for Multicall3 to deploy: %w", err)
+	}
+
+	err = recordDeployment(client, "Multicall3", Deployment{Address: address, TransactionHash: transaction.Hash()})
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return address, nil
+}
+
+// resolveMulticall picks the Multicall3 to aggregate reads with:
+// MULTICALL_ADDRESS, or the one recorded in the registry for the chain, or
+// the canonical one when it has code. MULTICALL_ADDRESS=none disables
+// aggregation, and so does finding none.
+func resolveMulticall(chainId *big.Int, canonicalCode []byte) (*common.Address, error) {
+	if address := os.Getenv("MULTICALL_ADDRESS"); address != "" {
+		return configuredMulticall()
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	if deployment, ok := registry.Lookup(chainId, "Multicall3"); ok {
+		return &deployment.Address, nil
+	}
+
+	if len(canonicalCode) > 0 {
+		canonical := CanonicalMulticall3
+		return &canonical, nil
+	}
+	return nil, nil
+}
+
+// configuredMulticall is the Multicall3 of MULTICALL_ADDRESS, or nil when it
+// is unset or "none".
+func configuredMulticall() (*common.Address, error) {
+	switch address := os.Getenv("MULTICALL_ADDRESS"); {
+	case address == "" || strings.EqualFold(address, "none"):
+		return nil, nil
+	case !common.IsHexAddress(address):
+		return nil, fmt.Errorf("invalid MULTICALL_ADDRESS: %q", address)
+	default:
+		multicall := common.HexToAddress(address)
+		return &multicall, nil
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// serve exposes the chain over JSON-RPC and points NODE_ENDPOINT at it, so
+// the commands' own clients can be tested against it. Like a development
+// node, every transaction sent is mined into a block of its own. The HTTP
+// requests made to it are counted in requests.
+func (c *testChain) serve() string {
+	c.t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &testNode{chain: c}); err != nil {
+		c.t.Fatal(err)
+	}
+	listener := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		c.requests.Add(1)
+		server.ServeHTTP(writer, request)
+	}))
+	c.t.Cleanup(func() {
+		listener.Close()
+		server.Stop()
+	})
+
+	useNodeEndpoint(c.t, listener.URL)
+	return listener.URL
+}
+
+// useNodeEndpoint points the commands at the endpoint for the rest of the
+// test, dropping the shared pool and clients so that the next client dials
+// it.
+func useNodeEndpoint(t *testing.T, endpoint string) {
+	t.Helper()
+	t.Setenv("NODE_ENDPOINTS", "")
+	t.Setenv("NODE_ENDPOINT", endpoint)
+	resetSharedRpcClients()
+	t.Cleanup(resetSharedRpcClients)
+}
+
+func resetSharedRpcClients() {
+	sharedRpcPool, sharedRpcPoolErr, sharedRpcPoolOnce = nil, nil, sync.Once{}
+	sharedRpcClientsLock.Lock()
+	defer sharedRpcClientsLock.Unlock()
+	for endpoint, client := range sharedRpcClients {
+		client.Close()
+		delete(sharedRpcClients, endpoint)
+	}
+}
+
+// testNode is the eth namespace of the test node.
+type testNode struct {
+	chain *testChain
+	// mining serialises sending with committing, so that concurrent
+	// senders each get a block of their own
+	mining sync.Mutex
+}
+
+// testCallArgs are the transaction fields eth_call and eth_estimateGas take.
+type testCallArgs struct {
+	From                 *common.Address `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  *hexutil.Uint64 `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Data                 *hexutil.Bytes  `json:"data"`
+	Input                *hexutil.Bytes  `json:"input"`
+}
+
+func (a testCallArgs) message() ethereum.CallMsg {
+	var message ethereum.CallMsg
+	if a.From != nil {
+		message.From = *a.From
+	}
+	message.To = a.To
+	if a.Gas != nil {
+		message.Gas = uint64(*a.Gas)
+	}
+	message.GasPrice = (*big.Int)(a.GasPrice)
+	message.GasFeeCap = (*big.Int)(a.MaxFeePerGas)
+	message.GasTipCap = (*big.Int)(a.MaxPriorityFeePerGas)
+	message.Value = (*big.Int)(a.Value)
+	if a.Input != nil {
+		message.Data = *a.Input
+	} else if a.Data != nil {
+		message.Data = *a.Data
+	}
+	return message
+}
+
+// blockNumber maps the tags onto the head, the only state the simulated
+// backend keeps.
+func (n *testNode) blockNumber(number rpc.BlockNumber) *big.Int {
+	if number < 0 {
+		return nil
+	}
+	return big.NewInt(number.Int64())
+}
+
+func (n *testNode) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(testChainId)
+}
+
+func (n *testNode) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := n.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Number.Uint64()), nil
+}
+
+func (n *testNode) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, full bool) (map[string]interface{}, error) {
+	block, err := n.chain.BlockByNumber(ctx, n.blockNumber(number))
+	if err != nil {
+		return nil, nil
+	}
+	return marshalTestBlock(block, full)
+}
+
+func (n *testNode) GetBlockByHash(ctx context.Context, hash common.Hash, full bool) (map[string]interface{}, error) {
+	block, err := n.chain.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, nil
+	}
+	return marshalTestBlock(block, full)
+}
+
+func marshalTestBlock(block *types.Block, full bool) (map[string]interface{}, error) {
+	fields, err := toJSONMap(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	fields["uncles"] = []common.Hash{}
+
+	transactions := make([]interface{}, len(block.Transactions()))
+	for i, transaction := range block.Transactions() {
+		if !full {
+			transactions[i] = transaction.Hash()
+			continue
+		}
+		marshalled, err := marshalTestTransaction(transaction, block.Hash(), block.Number())
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = marshalled
+	}
+	fields["transactions"] = transactions
+	return fields, nil
+}
+
+func marshalTestTransaction(transaction *types.Transaction, blockHash common.Hash, blockNumber *big.Int) (map[string]interface{}, error) {
+	fields, err := toJSONMap(transaction)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(testChainId), transaction)
+	if err != nil {
+		return nil, err
+	}
+	fields["from"] = from
+	if blockNumber != nil {
+		fields["blockHash"] = blockHash
+		fields["blockNumber"] = (*hexutil.Big)(blockNumber)
+	}
+	return fields, nil
+}
+
+func toJSONMap(value interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	return fields, json.Unmarshal(encoded, &fields)
+}
+
+func (n *testNode) GetBalance(ctx context.Context, address common.Address, number rpc.BlockNumber) (*hexutil.Big, error) {
+	balance, err := n.chain.BalanceAt(ctx, address, n.blockNumber(number))
+	return (*hexutil.Big)(balance), err
+}
+
+func (n *testNode) GetCode(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	return n.chain.CodeAt(ctx, address, n.blockNumber(number))
+}
+
+func (n *testNode) GetStorageAt(ctx context.Context, address common.Address, key common.Hash, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	return n.chain.StorageAt(ctx, address, key, n.blockNumber(number))
+}
+
+func (n *testNode) GetTransactionCount(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Uint64, error) {
+	if number == rpc.PendingBlockNumber {
+		nonce, err := n.chain.PendingNonceAt(ctx, address)
+		return hexutil.Uint64(nonce), err
+	}
+	nonce, err := n.chain.NonceAt(ctx, address, n.blockNumber(number))
+	return hexutil.Uint64(nonce), err
+}
+
+func (n *testNode) Call(ctx context.Context, args testCallArgs, number *rpc.BlockNumber) (hexutil.Bytes, error) {
+	var at *big.Int
+	if number != nil {
+		at = n.blockNumber(*number)
+	}
+	return n.chain.CallContract(ctx, args.message(), at)
+}
+
+func (n *testNode) EstimateGas(ctx context.Context, args testCallArgs, number *rpc.BlockNumber) (hexutil.Uint64, error) {
+	gas, err := n.chain.SimulatedBackend.EstimateGas(ctx, args.message())
+	return hexutil.Uint64(gas), err
+}
+
+func (n *testNode) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	price, err := n.chain.SuggestGasPrice(ctx)
+	return (*hexutil.Big)(price), err
+}
+
+func (n *testNode) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tip, err := n.chain.SuggestGasTipCap(ctx)
+	return (*hexutil.Big)(tip), err
+}
+
+func (n *testNode) SendRawTransaction(ctx context.Context, encoded hexutil.Bytes) (common.Hash, error) {
+	transaction := new(types.Transaction)
+	if err := transaction.UnmarshalBinary(encoded); err != nil {
+		return common.Hash{}, err
+	}
+
+	n.mining.Lock()
+	defer n.mining.Unlock()
+	if err := n.chain.SendTransaction(ctx, transaction); err != nil {
+		return common.Hash{}, err
+	}
+	n.chain.Commit()
+	return transaction.Hash(), nil
+}
+
+func (n *testNode) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := n.chain.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+func (n *testNode) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	transaction, pending, err := n.chain.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return marshalTestTransaction(transaction, common.Hash{}, nil)
+	}
+	receipt, err := n.chain.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return marshalTestTransaction(transaction, receipt.BlockHash, receipt.BlockNumber)
+}
+
+func (n *testNode) GetLogs(ctx context.Context, criteria filters.FilterCriteria) ([]types.Log, error) {
+	logs, err := n.chain.FilterLogs(ctx, ethereum.FilterQuery(criteria))
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, err
+}
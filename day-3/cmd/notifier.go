@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDeadLetterFile = "./notify-dead-letter.jsonl"
+
+	// SignatureHeader carries the hex HMAC-SHA256 of the timestamp, a dot
+	// and the body, keyed with the webhook secret.
+	SignatureHeader = "X-Fred-Coin-Signature"
+	// TimestampHeader is the unix time the delivery was signed at, so
+	// receivers can reject replayed requests.
+	TimestampHeader = "X-Fred-Coin-Timestamp"
+
+	maxNotifyBackoff = 5 * time.Minute
+)
+
+type NotificationKind string
+
+const (
+	WinnerPickedNotification   NotificationKind = "WinnerPicked"
+	RoundCancelledNotification NotificationKind = "RoundCancelled"
+	LargeEntryNotification     NotificationKind = "LargeEntry"
+	TestNotification           NotificationKind = "Test"
+)
+
+// Notification is the JSON body posted to webhooks. Id stays the same
+// across retries, so receivers can drop duplicates.
+type Notification struct {
+	Id        string           `json:"id"`
+	Kind      NotificationKind `json:"kind"`
+	Event     *LotteryEvent    `json:"event,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// NewNotification picks the events worth a notification: ended rounds,
+// and entries staking at least largeEntry. Large entries are ignored when
+// largeEntry is nil.
+func NewNotification(event LotteryEvent, largeEntry *big.Int) (Notification, bool) {
+	var kind NotificationKind
+	switch event.Kind {
+	case WinnerPickedEvent:
+		kind = WinnerPickedNotification
+	case RoundCancelledEvent:
+		kind = RoundCancelledNotification
+	case PlayerEnteredEvent:
+		if largeEntry == nil || event.Amount == nil || event.Amount.Cmp(largeEntry) < 0 {
+			return Notification{}, false
+		}
+		kind = LargeEntryNotification
+	default:
+		return Notification{}, false
+	}
+
+	return Notification{
+		Id:        fmt.Sprintf("%s-%d", event.TxHash.Hex(), event.LogIndex),
+		Kind:      kind,
+		Event:     &event,
+		CreatedAt: time.Now().UTC(),
+	}, true
+}
+
+// Notifier posts signed notifications to webhooks. Failed deliveries are
+// retried with exponential backoff, and appended to the dead-letter file
+// once the attempts run out.
+type Notifier struct {
+	webhooks   []string
+	secret     []byte
+	attempts   int
+	backoff    time.Duration
+	deadLetter string
+	client     *http.Client
+
+	deadLetterLock sync.Mutex
+}
+
+// NewNotifier returns a notifier making up to attempts deliveries per
+// webhook, waiting backoff after the first failure and twice as long after
+// every next one. An empty deadLetter drops failed notifications.
+func NewNotifier(webhooks []string, secret string, attempts int, backoff time.Duration, deadLetter string) *Notifier {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &Notifier{
+		webhooks:   webhooks,
+		secret:     []byte(secret),
+		attempts:   attempts,
+		backoff:    backoff,
+		deadLetter: deadLetter,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// deadLetter is a line of the dead-letter file.
+type deadLetter struct {
+	Webhook      string       `json:"webhook"`
+	Notification Notification `json:"notification"`
+	Error        string       `json:"error"`
+	FailedAt     time.Time    `json:"failedAt"`
+}
+
+// Notify delivers the notification to every webhook in parallel, and
+// returns once each delivery succeeded or was dead-lettered.
+func (n *Notifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	errs := make([]error, len(n.webhooks))
+	var wait sync.WaitGroup
+	for i, webhook := range n.webhooks {
+		wait.Add(1)
+		go func(i int, webhook string) {
+			defer wait.Done()
+			if err := n.deliver(ctx, webhook, body); err != nil {
+				errs[i] = fmt.Errorf("failed to notify %s: %w", webhook, err)
+				if err := n.writeDeadLetter(webhook, notification, err); err != nil {
+					log.Println(err)
+				}
+			}
+		}(i, webhook)
+	}
+	wait.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 1 {
+		return fmt.Errorf("%w, and %d more webhooks failed", failed[0], len(failed)-1)
+	}
+	if len(failed) == 1 {
+		return failed[0]
+	}
+	return nil
+}
+
+func (n *Notifier) deliver(ctx context.Context, webhook string, body []byte) error {
+	backoff := n.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = n.post(ctx, webhook, body)
+		if err == nil || !retry || attempt == n.attempts {
+			return err
+		}
+
+		log.Println("notifying ", webhook, " failed, attempt ", attempt, " of ", n.attempts, ", retrying in ", backoff, ": ", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, gave up on shutdown", err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxNotifyBackoff {
+			backoff = maxNotifyBackoff
+		}
+	}
+}
+
+// post makes one delivery. Network errors, 429 and 5xx answers are worth
+// a retry, other answers are not.
+func (n *Notifier) post(ctx context.Context, webhook string, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, SignNotification(n.secret, timestamp, body))
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+	return retry, fmt.Errorf("webhook answered %s", response.Status)
+}
+
+// SignNotification is the signature receivers compare the signature
+// header with, after checking the timestamp is recent.
+func SignNotification(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (n *Notifier) writeDeadLetter(webhook string, notification Notification, failure error) error {
+	if n.deadLetter == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(deadLetter{
+		Webhook:      webhook,
+		Notification: notification,
+		Error:        failure.Error(),
+		FailedAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+
+	n.deadLetterLock.Lock()
+	defer n.deadLetterLock.Unlock()
+
+	file, err := os.OpenFile(n.deadLetter, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead-letter file: %w", err)
+	}
+	return nil
+}
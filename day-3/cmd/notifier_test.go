@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testReceiver is a webhook receiver checking deliveries the way the
+// documentation asks receivers to: a recent timestamp, and a signature
+// over it and the body made with the shared secret.
+type testReceiver struct {
+	secret string
+	// answers are the statuses given to the first deliveries, in order.
+	// Deliveries after them are accepted.
+	answers []int
+
+	lock       sync.Mutex
+	deliveries int
+	accepted   []Notification
+	rejected   []string
+}
+
+func newTestReceiver(t *testing.T, secret string, answers ...int) (*testReceiver, string) {
+	t.Helper()
+	receiver := &testReceiver{secret: secret, answers: answers}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+	return receiver, server.URL
+}
+
+func (r *testReceiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	timestamp := request.Header.Get(TimestampHeader)
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(signedAt, 0)) > time.Minute {
+		r.reject(writer, "stale timestamp "+timestamp)
+		return
+	}
+	mac := hmac.New(sha256.New, []byte(r.secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(request.Header.Get(SignatureHeader)), []byte(expected)) {
+		r.reject(writer, "bad signature "+request.Header.Get(SignatureHeader))
+		return
+	}
+
+	r.deliveries++
+	if r.deliveries <= len(r.answers) {
+		writer.WriteHeader(r.answers[r.deliveries-1])
+		return
+	}
+	var notification Notification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.accepted = append(r.accepted, notification)
+}
+
+// seen is what the receiver got so far.
+func (r *testReceiver) seen() (int, []Notification, []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.deliveries, r.accepted, r.rejected
+}
+
+func (r *testReceiver) reject(writer http.ResponseWriter, reason string) {
+	r.rejected = append(r.rejected, reason)
+	http.Error(writer, reason, http.StatusUnauthorized)
+}
+
+func testNotification(t *testing.T) Notification {
+	t.Helper()
+	player := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	notification, ok := NewNotification(LotteryEvent{
+		Kind:        WinnerPickedEvent,
+		Lottery:     common.HexToAddress("0x00000000000000000000000000000000000000a1"),
+		BlockNumber: 7,
+		TxHash:      common.HexToHash("0x01"),
+		LogIndex:    2,
+		Player:      &player,
+		Amount:      big.NewInt(1000),
+	}, nil)
+	if !ok {
+		t.Fatal("a winner was not worth a notification")
+	}
+	return notification
+}
+
+func readDeadLetters(t *testing.T, file string) []deadLetter {
+	t.Helper()
+	opened, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer opened.Close()
+	var letters []deadLetter
+	scanner := bufio.NewScanner(opened)
+	for scanner.Scan() {
+		var letter deadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			t.Fatal(err)
+		}
+		letters = append(letters, letter)
+	}
+	return letters
+}
+
+func TestNotifierSignsDeliveries(t *testing.T) {
+	receiver, webhook := newTestReceiver(t, "shared secret")
+	deadLetters := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	notification := testNotification(t)
+
+	notifier := NewNotifier([]string{webhook}, "shared secret", 3, time.Millisecond, deadLetters)
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+	_, accepted, rejected := receiver.seen()
+	if len(rejected) != 0 || len(accepted) != 1 {
+		t.Fatalf("receiver accepted %v and rejected %v", accepted, rejected)
+	}
+	if accepted := accepted[0]; accepted.Id != notification.Id || accepted.Kind != WinnerPickedNotification || accepted.Event.Amount.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("receiver got %+v", accepted)
+	}
+	if letters := readDeadLetters(t, deadLetters); len(letters) != 0 {
+		t.Fatalf("a delivered notification was dead-lettered: %v", letters)
+	}
+}
+
+func TestNotifierDoesNotRetryRejectedSignatures(t *testing.T) {
+	receiver, webhook := newTestReceiver(t, "shared secret")
+	deadLetters := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	notification := testNotification(t)
+
+	notifier := NewNotifier([]string{webhook}, "another secret", 3, time.Millisecond, deadLetters)
+	if err := notifier.Notify(context.Background(), notification); err == nil {
+		t.Fatal("a rejected delivery succeeded")
+	}
+	// a 401 is not worth retrying
+	if _, accepted, rejected := receiver.seen(); len(rejected) != 1 || len(accepted) != 0 {
+		t.Fatalf("receiver accepted %v and rejected %v", accepted, rejected)
+	}
+	letters := readDeadLetters(t, deadLetters)
+	if len(letters) != 1 || letters[0].Webhook != webhook || letters[0].Notification.Id != notification.Id {
+		t.Fatalf("dead letters are %+v", letters)
+	}
+}
+
+func TestNotifierRetriesWithTheSameId(t *testing.T) {
+	receiver, webhook := newTestReceiver(t, "shared secret", http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	notification := testNotification(t)
+
+	notifier := NewNotifier([]string{webhook}, "shared secret", 3, time.Millisecond, "")
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+	// every attempt was signed anew and checked
+	deliveries, accepted, rejected := receiver.seen()
+	if deliveries != 3 || len(rejected) != 0 {
+		t.Fatalf("receiver saw %d deliveries and rejected %v", deliveries, rejected)
+	}
+	if len(accepted) != 1 || accepted[0].Id != notification.Id {
+		t.Fatalf("receiver accepted %v", accepted)
+	}
+}
+
+func TestNotifierDeadLettersOnceTheAttemptsRunOut(t *testing.T) {
+	failing, failingWebhook := newTestReceiver(t, "shared secret", http.StatusBadGateway, http.StatusBadGateway)
+	working, workingWebhook := newTestReceiver(t, "shared secret")
+	deadLetters := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	notification := testNotification(t)
+
+	notifier := NewNotifier([]string{failingWebhook, workingWebhook}, "shared secret", 2, time.Millisecond, deadLetters)
+	if err := notifier.Notify(context.Background(), notification); err == nil {
+		t.Fatal("a failed webhook was not reported")
+	}
+	if deliveries, accepted, _ := failing.seen(); deliveries != 2 || len(accepted) != 0 {
+		t.Fatalf("failing webhook saw %d deliveries", deliveries)
+	}
+	if _, accepted, _ := working.seen(); len(accepted) != 1 {
+		t.Fatalf("working webhook accepted %v", accepted)
+	}
+	letters := readDeadLetters(t, deadLetters)
+	if len(letters) != 1 || letters[0].Webhook != failingWebhook || letters[0].Error == "" {
+		t.Fatalf("dead letters are %+v", letters)
+	}
+}
+
+func TestNewNotificationPicksTheEvents(t *testing.T) {
+	largeEntry := big.NewInt(100)
+	tests := []struct {
+		kind   LotteryEventKind
+		amount int64
+		want   NotificationKind
+	}{
+		{WinnerPickedEvent, 1, WinnerPickedNotification},
+		{RoundCancelledEvent, 1, RoundCancelledNotification},
+		{PlayerEnteredEvent, 100, LargeEntryNotification},
+		{PlayerEnteredEvent, 99, ""},
+		{NewRoundEvent, 0, ""},
+		{ReorgEvent, 0, ""},
+	}
+	for _, test := range tests {
+		notification, ok := NewNotification(LotteryEvent{Kind: test.kind, Amount: big.NewInt(test.amount)}, largeEntry)
+		if ok != (test.want != "") || notification.Kind != test.want {
+			t.Errorf("%s of %d notified %q, want %q", test.kind, test.amount, notification.Kind, test.want)
+		}
+	}
+
+	if _, ok := NewNotification(LotteryEvent{Kind: PlayerEnteredEvent, Amount: big.NewInt(1000)}, nil); ok {
+		t.Error("an entry was notified without a large entry threshold")
+	}
+}
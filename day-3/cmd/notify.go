@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func notifyCommand() *cobra.Command {
+	var webhooks []string
+	var secret string
+	var attempts int
+	var backoff time.Duration
+	var deadLetter string
+	var selection string
+
+	command := &cobra.Command{
+		Use:   "notify",
+		Short: "post lottery events to webhooks",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(webhooks) == 0 {
+				return fmt.Errorf("no webhooks configured, pass --webhook or set NOTIFY_WEBHOOKS")
+			}
+			if secret == "" {
+				log.Println("no webhook secret configured, notifications are signed with an empty key")
+			}
+			return selectLottery(selection)
+		},
+	}
+	command.PersistentFlags().StringSliceVar(&webhooks, "webhook", getNotifyWebhooks(), "webhook URL to post to, repeat for several (defaults to the comma separated NOTIFY_WEBHOOKS)")
+	command.PersistentFlags().StringVar(&secret, "secret", os.Getenv("NOTIFY_SECRET"), "key the "+SignatureHeader+" HMAC is made with (defaults to NOTIFY_SECRET)")
+	command.PersistentFlags().IntVar(&attempts, "attempts", 5, "deliveries per webhook before a notification is dead-lettered")
+	command.PersistentFlags().DurationVar(&backoff, "backoff", time.Second, "wait after the first failed delivery, doubled after each next one")
+	command.PersistentFlags().StringVar(&deadLetter, "dead-letter", getDeadLetterFile(), "file failed notifications are appended to (defaults to NOTIFY_DEAD_LETTER)")
+	command.PersistentFlags().StringVar(&selection, "lottery", "", "lottery address or factory id to watch (defaults to LOTTERY_CONTRACT_ADDRESS)")
+
+	notifier := func() *Notifier {
+		return NewNotifier(webhooks, secret, attempts, backoff, deadLetter)
+	}
+	command.AddCommand(notifyRunCommand(notifier))
+	command.AddCommand(notifyTestCommand(&webhooks, &secret))
+	return command
+}
+
+func notifyRunCommand(notifier func() *Notifier) *cobra.Command {
+	var largeEntry string
+	var fromBlock int64
+	var metrics *metricsOptions
+
+	command := &cobra.Command{
+		Use:   "run",
+		Short: "notify when a winner is picked, a round is cancelled, or a large entry is made",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := metrics.start(); err != nil {
+				log.Fatal(err)
+			}
+
+			var threshold *big.Int
+			if largeEntry != "" {
+				amount, err := ParseUnits(largeEntry, EtherDecimals)
+				if err != nil {
+					log.Fatal(err)
+				}
+				threshold = amount
+			}
+
+			var start *uint64
+			if fromBlock >= 0 {
+				block := uint64(fromBlock)
+				start = &block
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			notifier := notifier()
+			lottery := getLotteryAddress()
+			log.Println("notifying for lottery ", lottery)
+			err := WatchLotteryEvents(ctx, []common.Address{lottery}, start, func(event LotteryEvent) error {
+				if event.Kind == ReorgEvent {
+					log.Println("reorg back to block ", event.BlockNumber, ", notifications for orphaned blocks were already sent")
+					return nil
+				}
+				notification, ok := NewNotification(event, threshold)
+				if !ok {
+					return nil
+				}
+				log.Println("notifying ", notification.Kind, " in transaction hash: ", event.TxHash)
+				if err := notifier.Notify(ctx, notification); err != nil {
+					log.Println(err)
+				}
+				return nil
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	command.Flags().StringVar(&largeEntry, "large-entry", "", "also notify entries staking at least this much ether, or whole tokens")
+	command.Flags().Int64Var(&fromBlock, "from-block", -1, "replay events from this block before following new ones")
+	metrics = addMetricsFlags(command, true)
+	return command
+}
+
+func notifyTestCommand(webhooks *[]string, secret *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test",
+		Short: "post a test notification to every webhook once",
+		Run: func(cmd *cobra.Command, args []string) {
+			notification := Notification{
+				Id:        fmt.Sprintf("test-%d", time.Now().UnixNano()),
+				Kind:      TestNotification,
+				CreatedAt: time.Now().UTC(),
+			}
+
+			failed := false
+			for _, webhook := range *webhooks {
+				// a single attempt and no dead letter, to report the
+				// webhook's answer straight away
+				notifier := NewNotifier([]string{webhook}, *secret, 1, 0, "")
+				if err := notifier.Notify(context.Background(), notification); err != nil {
+					log.Println(err)
+					failed = true
+					continue
+				}
+				log.Println("notified ", webhook)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func getNotifyWebhooks() []string {
+	var webhooks []string
+	for _, webhook := range strings.Split(os.Getenv("NOTIFY_WEBHOOKS"), ",") {
+		if webhook = strings.TrimSpace(webhook); webhook != "" {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks
+}
+
+func getDeadLetterFile() string {
+	if file := os.Getenv("NOTIFY_DEAD_LETTER"); file != "" {
+		return file
+	}
+	return defaultDeadLetterFile
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func lotteryPauseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "pause",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("pausing the lottery...")
+			transaction, err := PauseLottery()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery paused in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func lotteryUnpauseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "unpause",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("unpausing the lottery...")
+			transaction, err := UnpauseLottery()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery unpaused in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func lotteryCancelRoundCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "cancel-round",
+		Run: func(cmd *cobra.Command, args []string) {
+			players, err := GetLotteryPlayers()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Println("cancelling the round and refunding ", len(players), " entries...")
+			transaction, err := CancelLotteryRound()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("round cancelled in transaction hash: ", transaction.Hash())
+			log.Println("players can now reclaim their tickets with lottery claim")
+		},
+	}
+}
+
+func GetLotteryPaused() (bool, error) {
+	client, err := GetClient()
+	if err != nil {
+		return false, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return false, err
+	}
+
+	paused, err := lotteryContract.Paused(nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch lottery pause state: %w", err)
+	}
+
+	return paused, nil
+}
+
+func PauseLottery() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.Pause(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pause lottery: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func UnpauseLottery() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.Unpause(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpause lottery: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func CancelLotteryRound() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.CancelRound(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel lottery round: %w", err)
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPauseMidRoundThenCancelRefundsEveryEntrant(t *testing.T) {
+	chain := newTestChain(t, 4)
+	lotteryAddress, lotteryContract := chain.deployLottery(0)
+
+	entrants := []int{1, 2, 3}
+	stakes := []string{"1", "0.25", "3"}
+	spent := map[int]*big.Int{}
+	starting := map[int]*big.Int{}
+	for i, account := range entrants {
+		starting[account] = chain.balance(chain.accounts[account])
+		receipt := chain.mine(lotteryContract.Enter(chain.paying(account, mustParseEther(t, stakes[i]))))
+		spent[account] = chain.gasCost(receipt)
+	}
+	// account 1 enters twice, to be refunded both stakes
+	receipt := chain.mine(lotteryContract.Enter(chain.paying(1, mustParseEther(t, "0.5"))))
+	spent[1].Add(spent[1], chain.gasCost(receipt))
+
+	chain.mine(lotteryContract.Pause(chain.transactor(0)))
+	if _, err := lotteryContract.Enter(chain.paying(2, mustParseEther(t, "1"))); err == nil {
+		t.Fatal("entering a paused lottery succeeded")
+	}
+	if _, err := lotteryContract.PickWinner(chain.transactor(0)); err == nil {
+		t.Fatal("picking a winner of a paused lottery succeeded")
+	}
+	if _, err := lotteryContract.CancelRound(chain.transactor(1)); err == nil {
+		t.Fatal("a player without the admin role cancelled the round")
+	}
+
+	chain.mine(lotteryContract.CancelRound(chain.transactor(0)))
+	players, err := lotteryContract.GetPlayers(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pot, err := lotteryContract.Pot(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 0 || pot.Sign() != 0 {
+		t.Fatalf("cancelled round left %d players and a pot of %v", len(players), pot)
+	}
+
+	// refunds are claimable while the lottery stays paused
+	for _, account := range entrants {
+		receipt := chain.mine(lotteryContract.Withdraw(chain.transactor(account)))
+		spent[account].Add(spent[account], chain.gasCost(receipt))
+
+		restored := new(big.Int).Add(chain.balance(chain.accounts[account]), spent[account])
+		if restored.Cmp(starting[account]) != 0 {
+			t.Fatalf("account %d ended with %v plus %v of gas, want its starting %v", account, chain.balance(chain.accounts[account]), spent[account], starting[account])
+		}
+	}
+	if left := chain.balance(lotteryAddress); left.Sign() != 0 {
+		t.Fatalf("lottery still holds %v after every refund", left)
+	}
+
+	chain.mine(lotteryContract.Unpause(chain.transactor(0)))
+	chain.enter(lotteryContract, 2, "1")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	permitTypeHash       = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+)
+
+// Permit is an EIP-2612 approval together with its signature.
+type Permit struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+
+	V uint8
+	R [32]byte
+	S [32]byte
+}
+
+// PermitDomainSeparator hashes the EIP-712 domain used by FredCoin.permit.
+func PermitDomainSeparator(name string, chainId *big.Int, token common.Address) common.Hash {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(name)),
+		crypto.Keccak256([]byte("1")),
+		math.U256Bytes(new(big.Int).Set(chainId)),
+		common.LeftPadBytes(token.Bytes(), 32),
+	)
+}
+
+// SignPermit signs the permit locally with the given key, so the approval
+// never needs a transaction of its own.
+func SignPermit(key *ecdsa.PrivateKey, domainSeparator common.Hash, permit *Permit) error {
+	structHash := crypto.Keccak256(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(permit.Owner.Bytes(), 32),
+		common.LeftPadBytes(permit.Spender.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(permit.Value)),
+		math.U256Bytes(new(big.Int).Set(permit.Nonce)),
+		math.U256Bytes(new(big.Int).Set(permit.Deadline)),
+	)
+	digest := crypto.Keccak256([]byte("\x19\x01"), domainSeparator.Bytes(), structHash)
+
+	signature, err := crypto.Sign(digest, key)
+	if err != nil {
+		return fmt.Errorf("failed to sign permit: %w", err)
+	}
+
+	copy(permit.R[:], signature[:32])
+	copy(permit.S[:], signature[32:64])
+	permit.V = signature[64] + 27
+	return nil
+}
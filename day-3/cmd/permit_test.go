@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEnterWithPermitSignedByTheEntrant(t *testing.T) {
+	chain := newTestChain(t, 3)
+	tokenAddress, tokenContract := chain.deployToken(0, "100")
+	lotteryAddress, lotteryContract := chain.deployLottery(0)
+	ticketPrice := mustParseEther(t, "2")
+	chain.mine(lotteryContract.SetPaymentToken(chain.transactor(0), tokenAddress, ticketPrice))
+	chain.mine(tokenContract.Mint(chain.transactor(0), chain.accounts[1], mustParseEther(t, "10")))
+
+	domainSeparator := PermitDomainSeparator("FredCoin", testChainId, tokenAddress)
+	onChainSeparator, err := tokenContract.DOMAINSEPARATOR(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domainSeparator != onChainSeparator {
+		t.Fatalf("domain separator %v, token uses %v", domainSeparator, onChainSeparator)
+	}
+
+	// a permit naming another owner than its signer is what the lottery
+	// rejects, since it always spends from the sender
+	mismatched := &Permit{
+		Owner:    chain.accounts[2],
+		Spender:  lotteryAddress,
+		Value:    ticketPrice,
+		Nonce:    new(big.Int),
+		Deadline: big.NewInt(1 << 40),
+	}
+	if err := SignPermit(chain.keys[1], domainSeparator, mismatched); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lotteryContract.EnterWithPermit(chain.transactor(1), mismatched.Deadline, mismatched.V, mismatched.R, mismatched.S); err == nil {
+		t.Fatal("entering with a permit for another owner succeeded")
+	}
+
+	permit := &Permit{
+		Owner:    chain.accounts[1],
+		Spender:  lotteryAddress,
+		Value:    ticketPrice,
+		Nonce:    new(big.Int),
+		Deadline: big.NewInt(1 << 40),
+	}
+	if err := SignPermit(chain.keys[1], domainSeparator, permit); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(lotteryContract.EnterWithPermit(chain.transactor(1), permit.Deadline, permit.V, permit.R, permit.S))
+
+	players, err := lotteryContract.GetPlayers(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 1 || players[0] != chain.accounts[1] {
+		t.Fatalf("players are %v, want the permit's signer", players)
+	}
+	if balance := tokenBalance(t, tokenContract, lotteryAddress); balance.Cmp(ticketPrice) != 0 {
+		t.Fatalf("lottery holds %v FRED, want the ticket price %v", balance, ticketPrice)
+	}
+
+	// the nonce is spent, so the same signature cannot enter twice
+	if _, err := lotteryContract.EnterWithPermit(chain.transactor(1), permit.Deadline, permit.V, permit.R, permit.S); err == nil {
+		t.Fatal("replaying a permit succeeded")
+	}
+}
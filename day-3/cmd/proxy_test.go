@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"day-3/proxy"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+func TestProxyKeepsStateAcrossUpgrades(t *testing.T) {
+	chain := newTestChain(t, 3)
+	firstImplementation, _ := chain.deployLottery(0)
+	secondImplementation, _ := chain.deployLottery(0)
+
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	initialize, err := parsed.Pack("initialize", chain.accounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyAddress, transaction, _, err := proxy.DeployLotteryProxy(chain.transactor(0), chain, firstImplementation, initialize)
+	chain.mine(transaction, err)
+
+	implementation := func() common.Address {
+		t.Helper()
+		value, err := chain.StorageAt(context.Background(), proxyAddress, implementationSlot, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return common.BytesToAddress(value)
+	}
+	if current := implementation(); current != firstImplementation {
+		t.Fatalf("proxy points at %v, want %v", current, firstImplementation)
+	}
+
+	proxied, err := lottery.NewLottery(proxyAddress, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.enter(proxied, 2, "1.5")
+
+	if _, err := proxied.UpgradeTo(chain.transactor(2), secondImplementation); err == nil {
+		t.Fatal("a player upgraded the lottery")
+	}
+	chain.mine(proxied.UpgradeTo(chain.transactor(1), secondImplementation))
+	if current := implementation(); current != secondImplementation {
+		t.Fatalf("proxy points at %v after upgrading, want %v", current, secondImplementation)
+	}
+
+	// the round started before the upgrade carries on
+	players, err := proxied.GetPlayers(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pot, err := proxied.Pot(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 1 || players[0] != chain.accounts[2] || pot.Cmp(mustParseEther(t, "1.5")) != 0 {
+		t.Fatalf("upgrade left players %v and a pot of %v", players, pot)
+	}
+	chain.mine(proxied.PickWinner(chain.transactor(1)))
+
+	// the implementations themselves cannot be upgraded
+	implementationContract, err := lottery.NewLottery(firstImplementation, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := implementationContract.UpgradeTo(chain.transactor(0), secondImplementation); err == nil {
+		t.Fatal("upgrading an implementation directly succeeded")
+	}
+}
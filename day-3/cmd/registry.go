@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const defaultRegistryFile = "./deployments.json"
+
+// Deployment is a contract deployed by this CLI on one network.
+type Deployment struct {
+	Address         common.Address `json:"address"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+
+	// only recorded for CREATE2 deployments, so the contract can be
+	// redeployed to the same address on another network
+	Salt         *common.Hash    `json:"salt,omitempty"`
+	InitCodeHash *common.Hash    `json:"initCodeHash,omitempty"`
+	Deployer     *common.Address `json:"deployer,omitempty"`
+
+	// only recorded for proxies, so the next upgrade can be checked against
+	// the storage layout of the implementation it replaces
+	Implementation *common.Address `json:"implementation,omitempty"`
+	StorageLayout  *StorageLayout  `json:"storageLayout,omitempty"`
+}
+
+// Registry holds the deployments of every network by chain id and then by
+// contract name.
+type Registry map[string]map[string]Deployment
+
+func getRegistryFile() string {
+	if file := os.Getenv("DEPLOYMENTS_FILE"); file != "" {
+		return file
+	}
+	return defaultRegistryFile
+}
+
+func LoadRegistry() (Registry, error) {
+	registry := Registry{}
+
+	content, err := os.ReadFile(getRegistryFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment registry: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment registry: %w", err)
+	}
+	return registry, nil
+}
+
+func (r Registry) Save() error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode deployment registry: %w", err)
+	}
+
+	if err := os.WriteFile(getRegistryFile(), append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write deployment registry: %w", err)
+	}
+	return nil
+}
+
+func (r Registry) Lookup(chainId *big.Int, contract string) (Deployment, bool) {
+	deployment, ok := r[chainId.String()][contract]
+	return deployment, ok
+}
+
+// Name finds the contract deployed at the address on the chain.
+func (r Registry) Name(chainId *big.Int, address common.Address) (string, bool) {
+	for name, deployment := range r[chainId.String()] {
+		if deployment.Address == address {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (r Registry) Record(chainId *big.Int, contract string, deployment Deployment) {
+	network := chainId.String()
+	if r[network] == nil {
+		r[network] = map[string]Deployment{}
+	}
+	r[network][contract] = deployment
+}
+
+// recordDeployment adds a deployment to the registry file for the chain
+// the client is connected to.
+func recordDeployment(client *ethclient.Client, contract string, deployment Deployment) error {
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return err
+	}
+
+	registry.Record(chainId, contract, deployment)
+	return registry.Save()
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LotteryRoles maps the role names used on the command line to the role
+// identifiers hashed in Lottery.sol.
+var LotteryRoles = map[string]common.Hash{
+	"operator": crypto.Keccak256Hash([]byte("OPERATOR_ROLE")),
+	"admin":    crypto.Keccak256Hash([]byte("ADMIN_ROLE")),
+}
+
+func lotteryRolesCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use: "roles",
+	}
+	command.AddCommand(lotteryRolesGrantCommand())
+	command.AddCommand(lotteryRolesRevokeCommand())
+	command.AddCommand(lotteryRolesListCommand())
+	return command
+}
+
+func lotteryRolesGrantCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "grant <operator|admin> <address>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			role, account := parseRoleArgs(args)
+
+			log.Println("granting ", args[0], " role to ", account, "...")
+			transaction, err := GrantLotteryRole(role, account)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("role granted in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func lotteryRolesRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "revoke <operator|admin> <address>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			role, account := parseRoleArgs(args)
+
+			log.Println("revoking ", args[0], " role from ", account, "...")
+			transaction, err := RevokeLotteryRole(role, account)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("role revoked in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func lotteryRolesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			manager, err := GetLotteryManager()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("manager (holds every role): ", manager)
+
+			// the treasury follows the manager through a transfer only while
+			// it was never set apart with 'fees set'
+			fees, err := GetLotteryFees()
+			if err != nil {
+				log.Fatal(err)
+			}
+			if fees.Treasury == manager {
+				log.Println("treasury: the manager, moves with it")
+			} else {
+				log.Println("treasury (stays on a manager transfer): ", fees.Treasury)
+			}
+
+			names := make([]string, 0, len(LotteryRoles))
+			for name := range LotteryRoles {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				members, err := GetLotteryRoleMembers(LotteryRoles[name])
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println(name, " role members: ", members)
+			}
+		},
+	}
+}
+
+func lotteryTransferManagerCommand() *cobra.Command {
+	var accept bool
+
+	command := &cobra.Command{
+		Use:  "transfer-manager [address]",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if accept {
+				log.Println("accepting lottery management...")
+				transaction, err := AcceptLotteryManager()
+				if err != nil {
+					log.Fatal(err)
+				}
+				log.Println("management accepted in transaction hash: ", transaction.Hash())
+				return
+			}
+
+			if len(args) != 1 || !common.IsHexAddress(args[0]) {
+				log.Fatal("a new manager address is required unless --accept is given")
+			}
+
+			pendingManager := common.HexToAddress(args[0])
+			log.Println("proposing ", pendingManager, " as the new manager...")
+			transaction, err := ProposeLotteryManager(pendingManager)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("transfer proposed in transaction hash: ", transaction.Hash())
+			log.Println("the new manager must now run transfer-manager --accept")
+		},
+	}
+	command.Flags().BoolVar(&accept, "accept", false, "accept a pending transfer as the proposed manager")
+	return command
+}
+
+func parseRoleArgs(args []string) (common.Hash, common.Address) {
+	role, ok := LotteryRoles[strings.ToLower(args[0])]
+	if !ok {
+		log.Fatalf("unknown role: %s", args[0])
+	}
+	if !common.IsHexAddress(args[1]) {
+		log.Fatalf("invalid address: %s", args[1])
+	}
+	return role, common.HexToAddress(args[1])
+}
+
+func GetLotteryRoleMembers(role common.Hash) ([]common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := lotteryContract.GetRoleMembers(nil, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role members: %w", err)
+	}
+
+	return members, nil
+}
+
+func GrantLotteryRole(role common.Hash, account common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.GrantRole(transactionOptions, role, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func RevokeLotteryRole(role common.Hash, account common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.RevokeRole(transactionOptions, role, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func ProposeLotteryManager(pendingManager common.Address) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.TransferManager(transactionOptions, pendingManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to propose new manager: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func AcceptLotteryManager() (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryContract, err := getLotteryContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := lotteryContract.AcceptManager(transactionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept manager role: %w", err)
+	}
+
+	return transaction, nil
+}
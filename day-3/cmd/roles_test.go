@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"day-3/lottery"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// rolesTestLottery is a served lottery managed by account 0, with account
+// 1 in an open round.
+func rolesTestLottery(t *testing.T) (*testChain, *lottery.Lottery) {
+	t.Helper()
+	chain := newTestChain(t, 4)
+	address, contract := chain.deployLottery(0)
+	chain.serve()
+	useLottery(t, address)
+	chain.enter(contract, 1, "1")
+	return chain, contract
+}
+
+func (c *testChain) signAs(account int) {
+	c.t.Helper()
+	useSigner(c.t, &keySigner{key: c.keys[account]})
+}
+
+func TestRolesGateOperatorAndAdminCalls(t *testing.T) {
+	chain, contract := rolesTestLottery(t)
+	operator, admin := chain.accounts[2], chain.accounts[3]
+
+	// only the manager grants roles
+	chain.signAs(2)
+	if _, err := GrantLotteryRole(LotteryRoles["operator"], operator); err == nil {
+		t.Fatal("a stranger granted a role")
+	}
+	chain.signAs(0)
+	if _, err := GrantLotteryRole(LotteryRoles["operator"], operator); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GrantLotteryRole(LotteryRoles["admin"], admin); err != nil {
+		t.Fatal(err)
+	}
+	if members, err := GetLotteryRoleMembers(LotteryRoles["operator"]); err != nil || len(members) != 1 || members[0] != operator {
+		t.Fatalf("operators are %v: %v", members, err)
+	}
+
+	// the admin pauses and cancels but does not draw, the operator the reverse
+	chain.signAs(3)
+	if _, err := PickLotteryWinner(); err == nil {
+		t.Fatal("an admin picked the winner")
+	}
+	if _, err := PauseLottery(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnpauseLottery(); err != nil {
+		t.Fatal(err)
+	}
+	chain.signAs(2)
+	if _, err := PauseLottery(); err == nil {
+		t.Fatal("an operator paused the lottery")
+	}
+	if _, err := CancelLotteryRound(); err == nil {
+		t.Fatal("an operator cancelled the round")
+	}
+	if _, err := PickLotteryWinner(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a revoked operator can no longer draw
+	chain.signAs(0)
+	if _, err := RevokeLotteryRole(LotteryRoles["operator"], operator); err != nil {
+		t.Fatal(err)
+	}
+	if members, err := GetLotteryRoleMembers(LotteryRoles["operator"]); err != nil || len(members) != 0 {
+		t.Fatalf("operators are %v: %v", members, err)
+	}
+	chain.enter(contract, 1, "1")
+	chain.signAs(2)
+	if _, err := PickLotteryWinner(); err == nil {
+		t.Fatal("a revoked operator picked the winner")
+	}
+	chain.signAs(0)
+	if _, err := RevokeLotteryRole(LotteryRoles["operator"], operator); err == nil {
+		t.Fatal("revoked a role twice")
+	}
+}
+
+func TestManagerTransferNeedsTheProposedAccount(t *testing.T) {
+	chain, contract := rolesTestLottery(t)
+	previous, next := chain.accounts[0], chain.accounts[1]
+
+	chain.signAs(1)
+	if _, err := ProposeLotteryManager(next); err == nil {
+		t.Fatal("a player proposed a manager")
+	}
+	chain.signAs(0)
+	if _, err := ProposeLotteryManager(next); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := contract.PendingManager(nil); err != nil || pending != next {
+		t.Fatalf("pending manager is %v: %v", pending, err)
+	}
+
+	// neither a stranger nor the manager may accept for the proposed account
+	chain.signAs(2)
+	if _, err := AcceptLotteryManager(); err == nil {
+		t.Fatal("a stranger accepted the transfer")
+	}
+	chain.signAs(0)
+	if _, err := AcceptLotteryManager(); err == nil {
+		t.Fatal("the manager accepted its own transfer")
+	}
+
+	chain.signAs(1)
+	if _, err := AcceptLotteryManager(); err != nil {
+		t.Fatal(err)
+	}
+	if manager, err := GetLotteryManager(); err != nil || manager != next {
+		t.Fatalf("manager is %v: %v", manager, err)
+	}
+	if pending, err := contract.PendingManager(nil); err != nil || pending != (common.Address{}) {
+		t.Fatalf("pending manager is still %v: %v", pending, err)
+	}
+	// the default treasury went along with the manager
+	if fees, err := GetLotteryFees(); err != nil || fees.Treasury != next {
+		t.Fatalf("treasury is %+v: %v", fees, err)
+	}
+
+	chain.signAs(0)
+	if _, err := GrantLotteryRole(LotteryRoles["operator"], previous); err == nil {
+		t.Fatal("the previous manager granted a role")
+	}
+	if _, err := PickLotteryWinner(); err == nil {
+		t.Fatal("the previous manager picked the winner")
+	}
+	chain.signAs(1)
+	if _, err := PickLotteryWinner(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagerTransferKeepsATreasurySetApart(t *testing.T) {
+	chain, _ := rolesTestLottery(t)
+	treasury := chain.accounts[3]
+
+	chain.signAs(0)
+	if _, err := SetLotteryFee(big.NewInt(100), treasury); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ProposeLotteryManager(chain.accounts[1]); err != nil {
+		t.Fatal(err)
+	}
+	chain.signAs(1)
+	if _, err := AcceptLotteryManager(); err != nil {
+		t.Fatal(err)
+	}
+	if fees, err := GetLotteryFees(); err != nil || fees.Treasury != treasury {
+		t.Fatalf("treasury is %+v: %v", fees, err)
+	}
+}
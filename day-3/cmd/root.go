@@ -15,8 +15,23 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&signerSelection, "signer", "", "signing backend: key, keystore, mnemonic, or an external signer's http(s)/ws URL or IPC path (defaults to SIGNER)")
 	rootCmd.AddCommand(buildAndBindContractCommand())
 	rootCmd.AddCommand(deployAndTestLotteryContract())
+	rootCmd.AddCommand(deployCreate2DeployerCommand())
+	rootCmd.AddCommand(deployMulticallCommand())
+	rootCmd.AddCommand(deployProxyCommand())
+	rootCmd.AddCommand(upgradeCommand())
+	rootCmd.AddCommand(lotteryCommand())
+	rootCmd.AddCommand(tokenCommand())
+	rootCmd.AddCommand(callCommand())
+	rootCmd.AddCommand(sendCommand())
+	rootCmd.AddCommand(consoleCommand())
+	rootCmd.AddCommand(txCommand())
+	rootCmd.AddCommand(indexerCommand())
+	rootCmd.AddCommand(serveCommand())
+	rootCmd.AddCommand(notifyCommand())
+	rootCmd.AddCommand(keeperCommand())
 }
 
 func Execute() {
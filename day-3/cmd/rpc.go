@@ -0,0 +1,631 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultRpcTimeout  = 30 * time.Second
+	defaultRpcAttempts = 3
+	// defaultRpcDeadline bounds a request with all its attempts, for the
+	// callers that pass a context without a deadline of their own
+	defaultRpcDeadline = 60 * time.Second
+
+	// rpcRetryBase is the longest first wait between attempts. Each next
+	// wait doubles it, and the actual wait is a random part of it.
+	rpcRetryBase = 250 * time.Millisecond
+
+	// an endpoint failing breakerThreshold times in a row is skipped for
+	// breakerCooldown, then tried by a single request
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+
+	healthCheckInterval = 15 * time.Second
+	// maxHeadLag is how many blocks an endpoint may trail the others
+	// before it is only used when the others fail
+	maxHeadLag = 5
+)
+
+// idempotentMethods are the reads that are safe to repeat on another
+// endpoint after a failure or timeout.
+var idempotentMethods = map[string]bool{
+	"eth_blockNumber":           true,
+	"eth_call":                  true,
+	"eth_chainId":               true,
+	"eth_feeHistory":            true,
+	"eth_gasPrice":              true,
+	"eth_getBalance":            true,
+	"eth_getBlockByHash":        true,
+	"eth_getBlockByNumber":      true,
+	"eth_getCode":               true,
+	"eth_getLogs":               true,
+	"eth_getStorageAt":          true,
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionReceipt": true,
+	"eth_maxPriorityFeePerGas":  true,
+	"eth_syncing":               true,
+	"debug_traceCall":           true,
+	"net_version":               true,
+	"web3_clientVersion":        true,
+}
+
+// pendingStateMethods are the reads a transaction is built from, which
+// depend on the transactions the node has pending. Another endpoint may
+// not have seen those yet and answer a nonce already taken, so they are
+// retried on the endpoint that failed instead of moving on.
+var pendingStateMethods = map[string]bool{
+	"eth_estimateGas":         true,
+	"eth_getTransactionCount": true,
+}
+
+// rpcRequestKind is how a request may be retried after a failure.
+type rpcRequestKind int
+
+const (
+	// writes are only moved on when they never reached the node
+	rpcWrite rpcRequestKind = iota
+	// reads are retried on the next endpoint
+	rpcRead
+	// pending state reads are retried on the same endpoint
+	rpcPendingRead
+	// a signed transaction is the same wherever it is sent, so it is
+	// retried on the next endpoint, and a node that already knows it
+	// counts as having accepted it
+	rpcRawTransaction
+)
+
+var (
+	sharedRpcPool     *RpcPool
+	sharedRpcPoolErr  error
+	sharedRpcPoolOnce sync.Once
+
+	// sharedRpcClients holds the client of each websocket or IPC endpoint
+	sharedRpcClients     = map[string]*rpc.Client{}
+	sharedRpcClientsLock sync.Mutex
+)
+
+// getNodeEndpoints reads NODE_ENDPOINTS, a comma separated list in order
+// of preference, and falls back to NODE_ENDPOINT.
+func getNodeEndpoints() []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(os.Getenv("NODE_ENDPOINTS"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		endpoints = []string{os.Getenv("NODE_ENDPOINT")}
+	}
+	return endpoints
+}
+
+func isHttpEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://")
+}
+
+// getRpcDuration reads a duration setting such as RPC_TIMEOUT.
+func getRpcDuration(name string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(name); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+		log.Println("ignoring invalid ", name, ": ", value)
+	}
+	return fallback
+}
+
+func getRpcAttempts() int {
+	if value := os.Getenv("RPC_ATTEMPTS"); value != "" {
+		if attempts, err := strconv.Atoi(value); err == nil && attempts > 0 {
+			return attempts
+		}
+		log.Println("ignoring invalid RPC_ATTEMPTS: ", value)
+	}
+	return defaultRpcAttempts
+}
+
+// GetClient returns a client of the shared RPC pool. Clients share their
+// connections, so they are not closed after use.
+func GetClient() (*ethclient.Client, error) {
+	client, err := GetRpcClient()
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
+
+// GetRpcClient is GetClient for raw JSON-RPC calls. A single websocket or
+// IPC endpoint is not pooled: it is dialed once, and its client shared by
+// every caller, subscriptions included.
+func GetRpcClient() (*rpc.Client, error) {
+	endpoints := getNodeEndpoints()
+	if len(endpoints) == 1 && !isHttpEndpoint(endpoints[0]) {
+		return getSharedRpcClient(endpoints[0])
+	}
+
+	sharedRpcPoolOnce.Do(func() {
+		sharedRpcPool, sharedRpcPoolErr = NewRpcPool(endpoints, getRpcDuration("RPC_TIMEOUT", defaultRpcTimeout), getRpcDuration("RPC_DEADLINE", defaultRpcDeadline), getRpcAttempts())
+		if sharedRpcPoolErr == nil && len(endpoints) > 1 {
+			go sharedRpcPool.RunHealthChecks(context.Background(), healthCheckInterval)
+		}
+	})
+	if sharedRpcPoolErr != nil {
+		return nil, sharedRpcPoolErr
+	}
+	return sharedRpcPool.Dial()
+}
+
+// getSharedRpcClient dials the endpoint on first use. The client
+// reconnects by itself when the connection drops.
+func getSharedRpcClient(endpoint string) (*rpc.Client, error) {
+	sharedRpcClientsLock.Lock()
+	defer sharedRpcClientsLock.Unlock()
+	if client, ok := sharedRpcClients[endpoint]; ok {
+		return client, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getRpcDuration("RPC_TIMEOUT", defaultRpcTimeout))
+	defer cancel()
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create eth client: %w", err)
+	}
+	sharedRpcClients[endpoint] = client
+	return client, nil
+}
+
+// RpcPool spreads JSON-RPC requests over HTTP endpoints, sharing their
+// connections between clients. Every attempt has a deadline, and so has
+// the request with all its attempts. Failed reads and signed transactions
+// are retried with jitter, moving to the next endpoint, and reads of the
+// pending state are retried on the same endpoint, while other writes are
+// only moved when the request never reached the node. Endpoints that keep
+// failing are skipped by a circuit breaker until they recover.
+type RpcPool struct {
+	endpoints []*rpcEndpoint
+	timeout   time.Duration
+	deadline  time.Duration
+	attempts  int
+	transport http.RoundTripper
+	client    *http.Client
+}
+
+func NewRpcPool(endpoints []string, timeout time.Duration, deadline time.Duration, attempts int) (*RpcPool, error) {
+	return newRpcPool(endpoints, timeout, deadline, attempts, &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	})
+}
+
+func newRpcPool(endpoints []string, timeout time.Duration, deadline time.Duration, attempts int, transport http.RoundTripper) (*RpcPool, error) {
+	if len(endpoints) == 0 || endpoints[0] == "" {
+		return nil, fmt.Errorf("no node endpoint configured, set NODE_ENDPOINT or NODE_ENDPOINTS")
+	}
+
+	pool := &RpcPool{timeout: timeout, deadline: deadline, attempts: attempts, transport: transport}
+	for _, endpoint := range endpoints {
+		if !isHttpEndpoint(endpoint) {
+			return nil, fmt.Errorf("only http(s) endpoints can be pooled, not %q", endpoint)
+		}
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node endpoint: %w", err)
+		}
+		pool.endpoints = append(pool.endpoints, &rpcEndpoint{url: parsed, label: parsed.Host})
+	}
+	pool.client = &http.Client{Transport: &metricsTransport{next: pool}}
+	return pool, nil
+}
+
+func (p *RpcPool) Dial() (*rpc.Client, error) {
+	client, err := rpc.DialHTTPWithClient(p.endpoints[0].url.String(), p.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create eth client: %w", err)
+	}
+	return client, nil
+}
+
+// RoundTrip sends a JSON-RPC request to the pool's endpoints, until the
+// pool's deadline at the latest.
+func (p *RpcPool) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), p.deadline)
+	response, err := p.roundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+func (p *RpcPool) roundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	kind, rawTransaction := classifyRequest(body)
+	retried := kind != rpcWrite
+
+	candidates := p.candidates()
+	var lastErr error
+	for attempt := 0; attempt < p.attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepWithJitter(request.Context(), attempt); err != nil {
+				return nil, lastErr
+			}
+		}
+		endpoint := candidates[attempt%len(candidates)]
+		if kind == rpcPendingRead {
+			endpoint = candidates[0]
+		}
+
+		response, err := p.send(request, endpoint, body)
+		if err != nil {
+			endpoint.failed()
+			if request.Context().Err() != nil {
+				return nil, err
+			}
+			lastErr = fmt.Errorf("%s: %w", endpoint.label, err)
+			if !retried && !isDialError(err) {
+				return nil, lastErr
+			}
+			continue
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+			endpoint.failed()
+			if retried && attempt+1 < p.attempts {
+				lastErr = fmt.Errorf("%s answered %s", endpoint.label, response.Status)
+				io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+				response.Body.Close()
+				continue
+			}
+			return response, nil
+		}
+
+		endpoint.succeeded()
+		if kind == rpcRawTransaction && response.StatusCode == http.StatusOK {
+			return acceptKnownTransaction(response, rawTransaction)
+		}
+		return response, nil
+	}
+	return nil, lastErr
+}
+
+// send makes one attempt under the pool's deadline, which ends when the
+// response body is closed.
+func (p *RpcPool) send(request *http.Request, endpoint *rpcEndpoint, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), p.timeout)
+	attempt := request.Clone(ctx)
+	target := *endpoint.url
+	attempt.URL = &target
+	attempt.Host = target.Host
+	attempt.Body = io.NopCloser(bytes.NewReader(body))
+	attempt.ContentLength = int64(len(body))
+	attempt.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	response, err := p.transport.RoundTrip(attempt)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// candidates orders the endpoints to try: the healthy ones by preference,
+// then the lagging ones, then, should every circuit be open, the ones
+// closest to trying again.
+func (p *RpcPool) candidates() []*rpcEndpoint {
+	now := time.Now()
+	var healthy, lagging, open []*rpcEndpoint
+	for _, endpoint := range p.endpoints {
+		available, behind := endpoint.state(now)
+		switch {
+		case available && !behind:
+			healthy = append(healthy, endpoint)
+		case available:
+			lagging = append(lagging, endpoint)
+		default:
+			open = append(open, endpoint)
+		}
+	}
+
+	candidates := append(healthy, lagging...)
+	if len(candidates) == 0 {
+		sort.SliceStable(open, func(i, j int) bool {
+			return open[i].reopensAt().Before(open[j].reopensAt())
+		})
+		candidates = open
+	}
+	return candidates
+}
+
+// RunHealthChecks asks every endpoint for its head each interval, feeding
+// the circuit breakers and flagging endpoints that trail the others.
+func (p *RpcPool) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.CheckHealth(ctx)
+		}
+	}
+}
+
+func (p *RpcPool) CheckHealth(ctx context.Context) {
+	heads := make([]uint64, len(p.endpoints))
+	var wait sync.WaitGroup
+	for i, endpoint := range p.endpoints {
+		wait.Add(1)
+		go func(i int, endpoint *rpcEndpoint) {
+			defer wait.Done()
+			head, err := p.blockNumber(ctx, endpoint)
+			if err != nil {
+				endpoint.failed()
+				return
+			}
+			endpoint.succeeded()
+			heads[i] = head
+		}(i, endpoint)
+	}
+	wait.Wait()
+
+	best := uint64(0)
+	for _, head := range heads {
+		if head > best {
+			best = head
+		}
+	}
+	for i, endpoint := range p.endpoints {
+		if heads[i] > 0 {
+			endpoint.setLagging(best-heads[i] > maxHeadLag)
+		}
+	}
+}
+
+func (p *RpcPool) blockNumber(ctx context.Context, endpoint *rpcEndpoint) (uint64, error) {
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.url.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := p.send(request, endpoint, body)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("answered %s", response.Status)
+	}
+
+	var message struct {
+		Result hexutil.Uint64   `json:"result"`
+		Error  *json.RawMessage `json:"error"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&message); err != nil {
+		return 0, err
+	}
+	if message.Error != nil {
+		return 0, fmt.Errorf("eth_blockNumber failed: %s", *message.Error)
+	}
+	return uint64(message.Result), nil
+}
+
+// rpcEndpoint is an endpoint with its circuit breaker. The breaker opens
+// after breakerThreshold failures in a row, and after the cooldown lets a
+// single request through to decide whether to close again.
+type rpcEndpoint struct {
+	url   *url.URL
+	label string
+
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+	lagging   bool
+}
+
+// state reports whether requests may be sent, and whether the endpoint
+// trails the others. A cooled down breaker lets one caller probe it and
+// stays open for the others, for another cooldown should the probe not
+// be sent.
+func (e *rpcEndpoint) state(now time.Time) (bool, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.failures < breakerThreshold {
+		return true, e.lagging
+	}
+	if now.Before(e.openUntil) {
+		return false, e.lagging
+	}
+	e.openUntil = now.Add(breakerCooldown)
+	return true, e.lagging
+}
+
+func (e *rpcEndpoint) reopensAt() time.Time {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.openUntil
+}
+
+func (e *rpcEndpoint) succeeded() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.failures >= breakerThreshold {
+		log.Println("node endpoint ", e.label, " recovered")
+	}
+	e.failures = 0
+	rpcEndpointUp.WithLabelValues(e.label).Set(1)
+}
+
+func (e *rpcEndpoint) failed() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.failures++
+	if e.failures >= breakerThreshold {
+		if e.failures == breakerThreshold {
+			log.Println("node endpoint ", e.label, " failed ", breakerThreshold, " times in a row, skipping it for ", breakerCooldown)
+		}
+		e.openUntil = time.Now().Add(breakerCooldown)
+		rpcEndpointUp.WithLabelValues(e.label).Set(0)
+	}
+}
+
+func (e *rpcEndpoint) setLagging(lagging bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if lagging && !e.lagging {
+		log.Println("node endpoint ", e.label, " is more than ", maxHeadLag, " blocks behind, preferring the others")
+	}
+	e.lagging = lagging
+}
+
+// classifyRequest tells how a request or batch may be retried: as the
+// most careful of its calls. A batch sending a transaction along with
+// other calls is a write. For a single signed transaction, it also
+// returns the transaction.
+func classifyRequest(body []byte) (rpcRequestKind, hexutil.Bytes) {
+	type call struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	var calls []call
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &calls); err != nil {
+			return rpcWrite, nil
+		}
+	} else {
+		calls = make([]call, 1)
+		if err := json.Unmarshal(trimmed, &calls[0]); err != nil {
+			return rpcWrite, nil
+		}
+	}
+
+	if len(calls) == 1 && calls[0].Method == "eth_sendRawTransaction" && len(calls[0].Params) == 1 {
+		var rawTransaction hexutil.Bytes
+		if err := json.Unmarshal(calls[0].Params[0], &rawTransaction); err != nil {
+			return rpcWrite, nil
+		}
+		return rpcRawTransaction, rawTransaction
+	}
+
+	kind := rpcRead
+	for _, call := range calls {
+		switch {
+		case pendingStateMethods[call.Method]:
+			kind = rpcPendingRead
+		case !idempotentMethods[call.Method]:
+			return rpcWrite, nil
+		}
+	}
+	if len(calls) == 0 {
+		return rpcWrite, nil
+	}
+	return kind, nil
+}
+
+// acceptKnownTransaction answers with the transaction's hash when the node
+// refused it as already known, as happens when an earlier attempt reached
+// a node after all and it was passed on.
+func acceptKnownTransaction(response *http.Response, rawTransaction hexutil.Bytes) (*http.Response, error) {
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var answer struct {
+		Id    json.RawMessage `json:"id"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &answer) == nil && answer.Error != nil && isKnownTransactionError(answer.Error.Message) {
+		body, err = json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      answer.Id,
+			"result":  crypto.Keccak256Hash(rawTransaction),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+	response.Header.Del("Content-Length")
+	return response, nil
+}
+
+func isKnownTransactionError(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "already known") || strings.HasPrefix(message, "known transaction")
+}
+
+// isDialError reports failures to connect, after which the request was
+// certainly not received.
+func isDialError(err error) bool {
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// sleepWithJitter waits a random part of the backoff of the attempt, or
+// until the context ends.
+func sleepWithJitter(ctx context.Context, attempt int) error {
+	backoff := rpcRetryBase << (attempt - 1)
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff)) + 1))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeNode is a JSON-RPC endpoint knowing eth_blockNumber,
+// eth_getTransactionCount and the sending of transactions, answering after
+// the injected latency and failures.
+type fakeNode struct {
+	url string
+
+	lock    sync.Mutex
+	head    uint64
+	latency time.Duration
+	// answers are the HTTP statuses given to the next requests, and down
+	// fails every request after them with a 503
+	answers  []int
+	down     bool
+	rpcError string
+	requests map[string]int
+}
+
+func newFakeNode(t *testing.T, head uint64) *fakeNode {
+	t.Helper()
+	node := &fakeNode{head: head, requests: map[string]int{}}
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		node.serve(writer, request, released)
+	}))
+	t.Cleanup(func() {
+		close(released)
+		server.Close()
+	})
+	node.url = server.URL
+	return node
+}
+
+func (n *fakeNode) serve(writer http.ResponseWriter, request *http.Request, released <-chan struct{}) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var call struct {
+		Id     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(body, &call); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.lock.Lock()
+	n.requests[call.Method]++
+	latency, status := n.latency, 0
+	if len(n.answers) > 0 {
+		status, n.answers = n.answers[0], n.answers[1:]
+	} else if n.down {
+		status = http.StatusServiceUnavailable
+	}
+	head, rpcError := n.head, n.rpcError
+	n.lock.Unlock()
+
+	select {
+	case <-time.After(latency):
+	case <-request.Context().Done():
+		return
+	case <-released:
+		return
+	}
+	if status != 0 {
+		writer.WriteHeader(status)
+		return
+	}
+
+	answer := map[string]interface{}{"jsonrpc": "2.0", "id": call.Id}
+	switch {
+	case rpcError != "":
+		answer["error"] = map[string]interface{}{"code": -32000, "message": rpcError}
+	case call.Method == "eth_blockNumber", call.Method == "eth_getTransactionCount":
+		answer["result"] = hexutil.Uint64(head)
+	case call.Method == "eth_sendRawTransaction", call.Method == "eth_sendTransaction":
+		answer["result"] = common.HexToHash("0xfeed")
+	default:
+		answer["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(answer)
+}
+
+func (n *fakeNode) update(change func(node *fakeNode)) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	change(n)
+}
+
+func (n *fakeNode) count(method string) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.requests[method]
+}
+
+// newTestPool pools the nodes, in order of preference.
+func newTestPool(t *testing.T, timeout time.Duration, deadline time.Duration, attempts int, nodes ...*fakeNode) (*RpcPool, *rpc.Client) {
+	t.Helper()
+	var endpoints []string
+	for _, node := range nodes {
+		endpoints = append(endpoints, node.url)
+	}
+	pool, err := newRpcPool(endpoints, timeout, deadline, attempts, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := pool.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool, client
+}
+
+func blockNumber(t *testing.T, client *rpc.Client) uint64 {
+	t.Helper()
+	head, err := ethclient.NewClient(client).BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return head
+}
+
+// sendTransaction sends a transaction for the node to sign, which is not
+// safe to send twice.
+func sendTransaction(client *rpc.Client) error {
+	var hash common.Hash
+	return client.CallContext(context.Background(), &hash, "eth_sendTransaction", map[string]interface{}{})
+}
+
+func sendRawTransaction(client *rpc.Client) (common.Hash, error) {
+	var hash common.Hash
+	err := client.CallContext(context.Background(), &hash, "eth_sendRawTransaction", "0x01")
+	return hash, err
+}
+
+func TestRpcPoolRetriesReadsOnTheNextEndpoint(t *testing.T) {
+	first, second := newFakeNode(t, 10), newFakeNode(t, 20)
+	first.update(func(node *fakeNode) { node.answers = []int{http.StatusServiceUnavailable} })
+	_, client := newTestPool(t, time.Second, 10*time.Second, 3, first, second)
+
+	if head := blockNumber(t, client); head != 20 {
+		t.Fatalf("read head %d, want the second endpoint's", head)
+	}
+	if first.count("eth_blockNumber") != 1 || second.count("eth_blockNumber") != 1 {
+		t.Fatalf("endpoints saw %d and %d reads", first.count("eth_blockNumber"), second.count("eth_blockNumber"))
+	}
+
+	// the failure alone does not open the breaker
+	if head := blockNumber(t, client); head != 10 {
+		t.Fatalf("read head %d, want the preferred endpoint's", head)
+	}
+}
+
+func TestRpcPoolMovesSlowReadsOn(t *testing.T) {
+	slow, fast := newFakeNode(t, 10), newFakeNode(t, 20)
+	slow.update(func(node *fakeNode) { node.latency = 5 * time.Second })
+	_, client := newTestPool(t, 200*time.Millisecond, 10*time.Second, 3, slow, fast)
+
+	start := time.Now()
+	if head := blockNumber(t, client); head != 20 {
+		t.Fatalf("read head %d, want the fast endpoint's", head)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("read took %v, past the attempt timeout", elapsed)
+	}
+}
+
+func TestRpcPoolDeadlineBoundsEveryAttempt(t *testing.T) {
+	first, second := newFakeNode(t, 10), newFakeNode(t, 20)
+	for _, node := range []*fakeNode{first, second} {
+		node.update(func(node *fakeNode) { node.latency = 5 * time.Second })
+	}
+	// each attempt alone may take longer than the whole request
+	_, client := newTestPool(t, 2*time.Second, 300*time.Millisecond, 5, first, second)
+
+	start := time.Now()
+	if _, err := ethclient.NewClient(client).BlockNumber(context.Background()); err == nil {
+		t.Fatal("read from slow endpoints succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("read gave up after %v, past the deadline", elapsed)
+	}
+	if requests := first.count("eth_blockNumber") + second.count("eth_blockNumber"); requests != 1 {
+		t.Fatalf("endpoints saw %d reads within the deadline, want 1", requests)
+	}
+}
+
+func TestRpcPoolDoesNotRepeatWrites(t *testing.T) {
+	first, second := newFakeNode(t, 10), newFakeNode(t, 20)
+	first.update(func(node *fakeNode) { node.answers = []int{http.StatusBadGateway} })
+	_, client := newTestPool(t, time.Second, 10*time.Second, 3, first, second)
+
+	// the node may have received it, so it is not sent again
+	if err := sendTransaction(client); err == nil {
+		t.Fatal("a failed write succeeded")
+	}
+	if first.count("eth_sendTransaction") != 1 || second.count("eth_sendTransaction") != 0 {
+		t.Fatalf("endpoints saw %d and %d writes", first.count("eth_sendTransaction"), second.count("eth_sendTransaction"))
+	}
+}
+
+func TestRpcPoolMovesWritesThatNeverConnected(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	reachable := newFakeNode(t, 20)
+	pool, err := newRpcPool([]string{unreachable.URL, reachable.url}, time.Second, 10*time.Second, 3, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := pool.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sendTransaction(client); err != nil {
+		t.Fatal(err)
+	}
+	if reachable.count("eth_sendTransaction") != 1 {
+		t.Fatalf("reachable endpoint saw %d writes", reachable.count("eth_sendTransaction"))
+	}
+}
+
+func TestRpcPoolKeepsPendingStateReadsOnOneEndpoint(t *testing.T) {
+	first, second := newFakeNode(t, 7), newFakeNode(t, 5)
+	first.update(func(node *fakeNode) { node.answers = []int{http.StatusServiceUnavailable} })
+	_, client := newTestPool(t, time.Second, 10*time.Second, 3, first, second)
+
+	// the second endpoint has not seen the pending transactions, and would
+	// answer a nonce already taken
+	nonce, err := ethclient.NewClient(client).PendingNonceAt(context.Background(), common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 7 || first.count("eth_getTransactionCount") != 2 || second.count("eth_getTransactionCount") != 0 {
+		t.Fatalf("read nonce %d, endpoints saw %d and %d reads", nonce, first.count("eth_getTransactionCount"), second.count("eth_getTransactionCount"))
+	}
+}
+
+func TestRpcPoolMovesSignedTransactionsOn(t *testing.T) {
+	first, second := newFakeNode(t, 10), newFakeNode(t, 20)
+	first.update(func(node *fakeNode) { node.answers = []int{http.StatusBadGateway} })
+	// the first endpoint passed the transaction on before failing
+	second.update(func(node *fakeNode) { node.rpcError = "already known" })
+	_, client := newTestPool(t, time.Second, 10*time.Second, 3, first, second)
+
+	hash, err := sendRawTransaction(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != crypto.Keccak256Hash([]byte{0x01}) {
+		t.Fatalf("sent transaction %v, want the hash of the signed transaction", hash)
+	}
+	if first.count("eth_sendRawTransaction") != 1 || second.count("eth_sendRawTransaction") != 1 {
+		t.Fatalf("endpoints saw %d and %d writes", first.count("eth_sendRawTransaction"), second.count("eth_sendRawTransaction"))
+	}
+
+	// other refusals are still errors
+	first.update(func(node *fakeNode) { node.answers = []int{http.StatusBadGateway} })
+	second.update(func(node *fakeNode) { node.rpcError = "nonce too low" })
+	if _, err := sendRawTransaction(client); err == nil || !strings.Contains(err.Error(), "nonce too low") {
+		t.Fatalf("send failed with %v, want the node's error", err)
+	}
+}
+
+func TestRpcPoolReturnsNodeErrorsAsAnswered(t *testing.T) {
+	first, second := newFakeNode(t, 10), newFakeNode(t, 20)
+	first.update(func(node *fakeNode) { node.rpcError = "header not found" })
+	_, client := newTestPool(t, time.Second, 10*time.Second, 3, first, second)
+
+	_, err := ethclient.NewClient(client).BlockNumber(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "header not found") {
+		t.Fatalf("read failed with %v, want the node's error", err)
+	}
+	if first.count("eth_blockNumber") != 1 || second.count("eth_blockNumber") != 0 {
+		t.Fatal("an answered request was retried")
+	}
+}
+
+func TestRpcPoolBreakerSkipsFailingEndpoints(t *testing.T) {
+	failing, healthy := newFakeNode(t, 10), newFakeNode(t, 20)
+	failing.update(func(node *fakeNode) { node.down = true })
+	pool, client := newTestPool(t, time.Second, 10*time.Second, 2, failing, healthy)
+
+	for i := 0; i < breakerThreshold; i++ {
+		blockNumber(t, client)
+	}
+	if failing.count("eth_blockNumber") != breakerThreshold {
+		t.Fatalf("failing endpoint saw %d reads", failing.count("eth_blockNumber"))
+	}
+	// the breaker is open, so reads go straight to the healthy endpoint
+	blockNumber(t, client)
+	if failing.count("eth_blockNumber") != breakerThreshold || healthy.count("eth_blockNumber") != breakerThreshold+1 {
+		t.Fatalf("endpoints saw %d and %d reads", failing.count("eth_blockNumber"), healthy.count("eth_blockNumber"))
+	}
+
+	// after the cooldown a recovered endpoint is probed and used again
+	failing.update(func(node *fakeNode) { node.down = false })
+	pool.endpoints[0].lock.Lock()
+	pool.endpoints[0].openUntil = time.Now()
+	pool.endpoints[0].lock.Unlock()
+	if head := blockNumber(t, client); head != 10 {
+		t.Fatalf("read head %d after the cooldown, want the recovered endpoint's", head)
+	}
+	if available, _ := pool.endpoints[0].state(time.Now()); !available {
+		t.Fatal("the recovered endpoint's breaker stayed open")
+	}
+}
+
+func TestRpcPoolHealthChecksPreferLeadingEndpoints(t *testing.T) {
+	lagging, leading := newFakeNode(t, 100), newFakeNode(t, 100+maxHeadLag+1)
+	pool, client := newTestPool(t, time.Second, 10*time.Second, 3, lagging, leading)
+
+	pool.CheckHealth(context.Background())
+	if head := blockNumber(t, client); head != 100+maxHeadLag+1 {
+		t.Fatalf("read head %d, want the leading endpoint's", head)
+	}
+
+	lagging.update(func(node *fakeNode) { node.head = 100 + maxHeadLag + 1 })
+	pool.CheckHealth(context.Background())
+	checks := lagging.count("eth_blockNumber")
+	blockNumber(t, client)
+	if lagging.count("eth_blockNumber") != checks+1 {
+		t.Fatal("the endpoint that caught up is not preferred again")
+	}
+}
+
+// websocketEth is the eth namespace of a websocket test node.
+type websocketEth struct{}
+
+func (websocketEth) BlockNumber() hexutil.Uint64 {
+	return 42
+}
+
+func TestWebsocketEndpointsShareOneClient(t *testing.T) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", websocketEth{}); err != nil {
+		t.Fatal(err)
+	}
+	listener := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(func() {
+		listener.Close()
+		server.Stop()
+	})
+	useNodeEndpoint(t, "ws://"+strings.TrimPrefix(listener.URL, "http://"))
+
+	first, err := GetRpcClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GetRpcClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("the websocket endpoint was dialed twice")
+	}
+	client, err := GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := client.BlockNumber(context.Background())
+	if err != nil || head != 42 {
+		t.Fatalf("read head %d: %v", head, err)
+	}
+}
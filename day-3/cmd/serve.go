@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/grpc"
+)
+
+//go:embed openapi.yaml
+var openApiSpec []byte
+
+func serveCommand() *cobra.Command {
+	var listen string
+	var grpcListen string
+	var apiKey string
+	var database string
+	var allowOrigin string
+	var selection string
+	var metrics *metricsOptions
+
+	command := &cobra.Command{
+		Use:   "serve",
+		Short: "serve lottery state and transactions over HTTP, and optionally gRPC",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := selectLottery(selection); err != nil {
+				log.Fatal(err)
+			}
+			if apiKey == "" {
+				log.Println("no API key configured, manager endpoints are disabled")
+			}
+			if err := metrics.start(); err != nil {
+				log.Fatal(err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			api := NewServer(apiKey, database, allowOrigin)
+			server := &http.Server{
+				Addr:              listen,
+				Handler:           api.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+				// shutting down does not close websockets, cancelling
+				// their context does
+				BaseContext: func(net.Listener) context.Context { return ctx },
+			}
+
+			var grpcServer *grpc.Server
+			if grpcListen != "" {
+				listener, err := net.Listen("tcp", grpcListen)
+				if err != nil {
+					log.Fatal(fmt.Errorf("failed to listen for gRPC on %s: %w", grpcListen, err))
+				}
+				grpcServer = api.GrpcServer()
+				go func() {
+					log.Println("serving gRPC on ", grpcListen)
+					if err := grpcServer.Serve(listener); err != nil {
+						log.Fatal(err)
+					}
+				}()
+			}
+
+			go func() {
+				<-ctx.Done()
+				if grpcServer != nil {
+					grpcServer.GracefulStop()
+				}
+				shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				server.Shutdown(shutdown)
+			}()
+
+			log.Println("serving lottery ", getLotteryAddress(), " on ", listen)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		},
+	}
+	command.Flags().StringVar(&listen, "listen", ":8080", "address to serve HTTP on")
+	command.Flags().StringVar(&grpcListen, "grpc", "", "also serve gRPC, on "+defaultGrpcAddress+" unless an address is given")
+	command.Flags().Lookup("grpc").NoOptDefVal = defaultGrpcAddress
+	command.Flags().StringVar(&apiKey, "api-key", os.Getenv("SERVER_API_KEY"), "key required by the manager endpoints (defaults to SERVER_API_KEY)")
+	command.Flags().StringVar(&database, "db", getIndexerDatabase(), "indexer database for the round history")
+	command.Flags().StringVar(&allowOrigin, "allow-origin", "", "origin allowed to call the API from a browser, for CORS")
+	command.Flags().StringVar(&selection, "lottery", "", "lottery address or factory id to serve (defaults to LOTTERY_CONTRACT_ADDRESS)")
+	metrics = addMetricsFlags(command, false)
+	return command
+}
+
+// Server exposes the lottery to the web frontend. Reads and unsigned
+// entries are public. The manager endpoints sign with the configured
+// signer and need the API key.
+type Server struct {
+	apiKey      string
+	database    string
+	allowOrigin string
+
+	// sends are serialised so concurrent requests do not pick the same
+	// pending nonce
+	sendLock sync.Mutex
+}
+
+func NewServer(apiKey string, database string, allowOrigin string) *Server {
+	return &Server{apiKey: apiKey, database: database, allowOrigin: allowOrigin}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.yaml", s.get(s.handleOpenApi))
+	mux.Handle("/metrics", metricsHandler())
+	mux.HandleFunc("/api/lottery", s.get(s.handleLottery))
+	mux.HandleFunc("/api/lottery/players", s.get(s.handlePlayers))
+	mux.HandleFunc("/api/lottery/rounds", s.get(s.handleRounds))
+	mux.HandleFunc("/api/lottery/events", s.get(s.handleEvents))
+	mux.HandleFunc("/api/accounts/", s.get(s.handleAccount))
+	mux.HandleFunc("/api/lottery/enter", s.post(s.handleEnter))
+	mux.HandleFunc("/api/lottery/pick-winner", s.post(s.manager(PickLotteryWinner)))
+	mux.HandleFunc("/api/lottery/pause", s.post(s.manager(PauseLottery)))
+	mux.HandleFunc("/api/lottery/unpause", s.post(s.manager(UnpauseLottery)))
+	mux.HandleFunc("/api/lottery/cancel-round", s.post(s.manager(CancelLotteryRound)))
+	return s.cors(mux)
+}
+
+// apiError carries the status code to answer a failed request with.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+type apiHandler func(w http.ResponseWriter, r *http.Request) (interface{}, error)
+
+func (s *Server) get(handler apiHandler) http.HandlerFunc {
+	return s.method(http.MethodGet, handler)
+}
+
+func (s *Server) post(handler apiHandler) http.HandlerFunc {
+	return s.method(http.MethodPost, handler)
+}
+
+// method answers with the handler's result as JSON, or with an error
+// object. Errors other than an apiError are node failures.
+func (s *Server) method(method string, handler apiHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeJson(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		result, err := handler(w, r)
+		if err != nil {
+			var requestError *apiError
+			if errors.As(err, &requestError) {
+				writeJson(w, requestError.status, map[string]string{"error": requestError.message})
+				return
+			}
+			log.Println(r.Method, " ", r.URL.Path, " failed: ", err)
+			writeJson(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+			return
+		}
+		if result != nil {
+			writeJson(w, http.StatusOK, result)
+		}
+	}
+}
+
+func writeJson(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func (s *Server) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.allowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", s.allowOrigin)
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleOpenApi(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(openApiSpec)
+	return nil, nil
+}
+
+func (s *Server) handleLottery(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	return GetLotteryState()
+}
+
+func (s *Server) handlePlayers(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	players, err := GetLotteryPlayers()
+	if err != nil {
+		return nil, err
+	}
+	if players == nil {
+		players = []common.Address{}
+	}
+	return players, nil
+}
+
+func (s *Server) handleRounds(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if _, err := os.Stat(s.database); err != nil {
+		return nil, &apiError{http.StatusServiceUnavailable, "round history needs the indexer, run 'indexer run' first"}
+	}
+
+	store, err := OpenIndexerStore(s.database)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	lottery := getLotteryAddress()
+	rounds, err := store.RoundHistory(&lottery)
+	if err != nil {
+		return nil, err
+	}
+
+	if rounds == nil {
+		rounds = []LotteryRound{}
+	}
+	return rounds, nil
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	address := strings.TrimPrefix(r.URL.Path, "/api/accounts/")
+	if !common.IsHexAddress(address) {
+		return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid address %q", address)}
+	}
+	return GetAccountBalances(common.HexToAddress(address))
+}
+
+// enterRequest is the body of POST /api/lottery/enter. Value is in ether
+// and ignored when tickets are priced in a token.
+type enterRequest struct {
+	From  string `json:"from"`
+	Value string `json:"value"`
+}
+
+func (s *Server) handleEnter(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	var request enterRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&request); err != nil {
+		return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err)}
+	}
+	if !common.IsHexAddress(request.From) {
+		return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("invalid from address %q", request.From)}
+	}
+	if request.Value == "" {
+		request.Value = "0"
+	}
+	value, err := ParseUnits(request.Value, EtherDecimals)
+	if err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+
+	return BuildLotteryEntry(common.HexToAddress(request.From), value)
+}
+
+// manager wraps a send in the API key check, answering with the hash of
+// the sent transaction.
+func (s *Server) manager(send func() (*types.Transaction, error)) apiHandler {
+	return func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		if s.apiKey == "" {
+			return nil, &apiError{http.StatusForbidden, "manager endpoints are disabled, no API key is configured"}
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-API-Key")), []byte(s.apiKey)) != 1 {
+			return nil, &apiError{http.StatusUnauthorized, "missing or invalid X-API-Key"}
+		}
+
+		s.sendLock.Lock()
+		defer s.sendLock.Unlock()
+
+		transaction, err := send()
+		if err != nil {
+			return nil, err
+		}
+		log.Println(r.URL.Path, " sent in transaction hash: ", transaction.Hash())
+		return map[string]common.Hash{"transactionHash": transaction.Hash()}, nil
+	}
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const testApiKey = "test-api-key"
+
+// servedLottery is a lottery managed by account 0 of the chain, served by
+// the API with account 0 as its signer.
+type servedLottery struct {
+	*testChain
+	address  common.Address
+	contract *lottery.Lottery
+	server   *Server
+}
+
+func newServedLottery(t *testing.T, apiKey string) *servedLottery {
+	t.Helper()
+	chain := newTestChain(t, 3)
+	address, contract := chain.deployLottery(0)
+	chain.serve()
+	t.Setenv("LOTTERY_CONTRACT_ADDRESS", address.Hex())
+	t.Setenv("TOKEN_CONTRACT_ADDRESS", "")
+	useSigner(t, &keySigner{key: chain.keys[0]})
+
+	server := NewServer(apiKey, filepath.Join(t.TempDir(), "indexer.db"), "")
+	return &servedLottery{testChain: chain, address: address, contract: contract, server: server}
+}
+
+// inModuleRoot runs the rest of the test from the module root, where the
+// commands find the build directory.
+func inModuleRoot(t *testing.T) {
+	t.Helper()
+	directory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(".."); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(directory) })
+}
+
+// request answers the request and decodes the JSON response into result,
+// unless result is nil.
+func (l *servedLottery) request(method string, path string, apiKey string, body string, result interface{}) int {
+	l.t.Helper()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if apiKey != "" {
+		request.Header.Set("X-API-Key", apiKey)
+	}
+	recorder := httptest.NewRecorder()
+	l.server.Handler().ServeHTTP(recorder, request)
+
+	if result != nil && recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+			l.t.Fatalf("%s %s answered %s: %v", method, path, recorder.Body, err)
+		}
+	}
+	return recorder.Code
+}
+
+func (l *servedLottery) state() *LotteryState {
+	l.t.Helper()
+	state := &LotteryState{}
+	if status := l.request(http.MethodGet, "/api/lottery", "", "", state); status != http.StatusOK {
+		l.t.Fatalf("GET /api/lottery answered %d", status)
+	}
+	return state
+}
+
+func TestServerReadsTheLottery(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+
+	state := served.state()
+	if state.Address != served.address || state.Manager != served.accounts[0] {
+		t.Fatalf("state is of lottery %v managed by %v", state.Address, state.Manager)
+	}
+	if state.RoundOpen || state.Pot.Sign() != 0 || len(state.Players) != 0 || state.Deadline != nil {
+		t.Fatalf("fresh lottery has state %+v", state)
+	}
+
+	served.enter(served.contract, 1, "1")
+	served.enter(served.contract, 2, "2")
+
+	state = served.state()
+	if !state.RoundOpen || state.Pot.Cmp(mustParseEther(t, "3")) != 0 || state.Deadline == nil {
+		t.Fatalf("running round has state %+v", state)
+	}
+	var players []common.Address
+	if status := served.request(http.MethodGet, "/api/lottery/players", "", "", &players); status != http.StatusOK {
+		t.Fatalf("GET /api/lottery/players answered %d", status)
+	}
+	if len(players) != 2 || players[0] != served.accounts[1] || players[1] != served.accounts[2] {
+		t.Fatalf("players are %v", players)
+	}
+
+	var balances AccountBalances
+	if status := served.request(http.MethodGet, "/api/accounts/"+served.accounts[1].Hex(), "", "", &balances); status != http.StatusOK {
+		t.Fatalf("GET /api/accounts answered %d", status)
+	}
+	if balances.Ether.Cmp(served.balance(served.accounts[1])) != 0 || balances.UnclaimedWinnings.Sign() != 0 || balances.Token != nil {
+		t.Fatalf("balances are %+v", balances)
+	}
+	if status := served.request(http.MethodGet, "/api/accounts/not-an-address", "", "", nil); status != http.StatusBadRequest {
+		t.Fatalf("GET with an invalid address answered %d", status)
+	}
+
+	// without the indexer there is no round history to serve
+	if status := served.request(http.MethodGet, "/api/lottery/rounds", "", "", nil); status != http.StatusServiceUnavailable {
+		t.Fatalf("GET /api/lottery/rounds without an indexer answered %d", status)
+	}
+	if status := served.request(http.MethodPost, "/api/lottery", "", "", nil); status != http.StatusMethodNotAllowed {
+		t.Fatalf("POST /api/lottery answered %d", status)
+	}
+}
+
+func TestServerBuildsEntriesForWalletsToSign(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+	inModuleRoot(t)
+	player := served.accounts[2]
+
+	var entry LotteryEntry
+	body := `{"from": "` + player.Hex() + `", "value": "0.25"}`
+	if status := served.request(http.MethodPost, "/api/lottery/enter", "", body, &entry); status != http.StatusOK {
+		t.Fatalf("POST /api/lottery/enter answered %d", status)
+	}
+	unsigned := entry.Transaction
+	if entry.ApprovalRequired || unsigned.From != player || unsigned.To != served.address || unsigned.ChainId.Cmp(testChainId) != 0 {
+		t.Fatalf("entry is %+v", unsigned)
+	}
+	if unsigned.Value.Cmp(mustParseEther(t, "0.25")) != 0 || unsigned.Gas == 0 {
+		t.Fatalf("entry pays %v with %d gas", unsigned.Value, unsigned.Gas)
+	}
+
+	// the wallet signs and sends it as built
+	signed, err := types.SignTx(unsigned.Transaction(), types.LatestSignerForChainID(testChainId), served.keys[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := served.SendTransaction(context.Background(), signed); err != nil {
+		t.Fatal(err)
+	}
+	served.mine(signed, nil)
+	if players := served.state().Players; len(players) != 1 || players[0] != player {
+		t.Fatalf("players are %v after the signed entry", players)
+	}
+
+	for _, body := range []string{`{`, `{"from": "0x1234"}`, `{"from": "` + player.Hex() + `", "value": "lots"}`} {
+		if status := served.request(http.MethodPost, "/api/lottery/enter", "", body, nil); status != http.StatusBadRequest {
+			t.Fatalf("entry %s answered %d", body, status)
+		}
+	}
+}
+
+func TestServerManagerEndpoints(t *testing.T) {
+	served := newServedLottery(t, testApiKey)
+	served.enter(served.contract, 1, "1")
+
+	if status := served.request(http.MethodPost, "/api/lottery/pick-winner", "", "", nil); status != http.StatusUnauthorized {
+		t.Fatalf("picking without the key answered %d", status)
+	}
+	if status := served.request(http.MethodPost, "/api/lottery/pick-winner", "wrong", "", nil); status != http.StatusUnauthorized {
+		t.Fatalf("picking with the wrong key answered %d", status)
+	}
+
+	var sent map[string]common.Hash
+	if status := served.request(http.MethodPost, "/api/lottery/pause", testApiKey, "", &sent); status != http.StatusOK {
+		t.Fatalf("pausing answered %d", status)
+	}
+	if !served.state().Paused {
+		t.Fatalf("lottery is not paused after transaction %v", sent["transactionHash"])
+	}
+	// a paused draw reverts in estimation and is never sent
+	if status := served.request(http.MethodPost, "/api/lottery/pick-winner", testApiKey, "", nil); status != http.StatusBadGateway {
+		t.Fatalf("picking while paused answered %d", status)
+	}
+	if status := served.request(http.MethodPost, "/api/lottery/unpause", testApiKey, "", nil); status != http.StatusOK {
+		t.Fatalf("unpausing answered %d", status)
+	}
+
+	if status := served.request(http.MethodPost, "/api/lottery/pick-winner", testApiKey, "", &sent); status != http.StatusOK {
+		t.Fatalf("picking answered %d", status)
+	}
+	receipt, err := served.TransactionReceipt(context.Background(), sent["transactionHash"])
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("draw %v was not mined: %v", sent["transactionHash"], err)
+	}
+	var balances AccountBalances
+	served.request(http.MethodGet, "/api/accounts/"+served.accounts[1].Hex(), "", "", &balances)
+	if balances.UnclaimedWinnings.Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("winner has %v unclaimed, want the pot", balances.UnclaimedWinnings)
+	}
+
+	served.enter(served.contract, 2, "0.5")
+	if status := served.request(http.MethodPost, "/api/lottery/cancel-round", testApiKey, "", nil); status != http.StatusOK {
+		t.Fatalf("cancelling answered %d", status)
+	}
+	if state := served.state(); len(state.Players) != 0 || state.Pot.Sign() != 0 {
+		t.Fatalf("cancelled round left %+v", state)
+	}
+}
+
+func TestServerWithoutApiKeyDisablesManagerEndpoints(t *testing.T) {
+	served := newServedLottery(t, "")
+	for _, path := range []string{"/api/lottery/pick-winner", "/api/lottery/pause", "/api/lottery/unpause", "/api/lottery/cancel-round"} {
+		if status := served.request(http.MethodPost, path, "", "", nil); status != http.StatusForbidden {
+			t.Fatalf("POST %s answered %d", path, status)
+		}
+	}
+	if served.state().Paused {
+		t.Fatal("a disabled endpoint paused the lottery")
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// signerSelection is set from the --signer flag, see GetSigner.
+var signerSelection string
+
+// currentSigner is resolved once per run, since unlocking a keystore is
+// slow and an external signer may prompt for its account list.
+var currentSigner Signer
+
+// Signer signs transactions for the account the CLI acts as.
+type Signer interface {
+	Address() common.Address
+	SignTx(transaction *types.Transaction, chainId *big.Int) (*types.Transaction, error)
+}
+
+// keySigner holds the private key in process. The raw key, keystore and
+// mnemonic backends all end up here.
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *keySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *keySigner) SignTx(transaction *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	return types.SignTx(transaction, types.LatestSignerForChainID(chainId), s.key)
+}
+
+// externalSigner delegates to a Clef compatible signer through its
+// account_signTransaction API, so the key never enters this process.
+type externalSigner struct {
+	signer  *external.ExternalSigner
+	account accounts.Account
+}
+
+func (s *externalSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *externalSigner) SignTx(transaction *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	signed, err := s.signer.SignTx(s.account, transaction, chainId)
+	if err != nil {
+		return nil, fmt.Errorf("external signer refused the transaction: %w", err)
+	}
+	return signed, nil
+}
+
+// GetSigner resolves the signing backend from --signer or SIGNER:
+//
+//	key       ACCOUNT_PRIVATE_KEY
+//	keystore  KEYSTORE_FILE unlocked with KEYSTORE_PASSWORD
+//	mnemonic  ACCOUNT_MNEMONIC at ACCOUNT_DERIVATION_PATH (m/44'/60'/0'/0/0)
+//	http(s)://..., ws(s)://... or a .ipc path for an external signer such as Clef
+//
+// Without a selection the mnemonic or keystore is used when configured, and
+// the raw key otherwise. Anything else is rejected by name rather than
+// dialled.
+func GetSigner() (Signer, error) {
+	if currentSigner != nil {
+		return currentSigner, nil
+	}
+
+	selection := signerSelection
+	if selection == "" {
+		selection = os.Getenv("SIGNER")
+	}
+	if selection == "" {
+		switch {
+		case os.Getenv("ACCOUNT_MNEMONIC") != "":
+			selection = "mnemonic"
+		case os.Getenv("KEYSTORE_FILE") != "":
+			selection = "keystore"
+		default:
+			selection = "key"
+		}
+	}
+
+	var signer Signer
+	var err error
+	switch selection {
+	case "key":
+		signer, err = newRawKeySigner(os.Getenv("ACCOUNT_PRIVATE_KEY"))
+	case "keystore":
+		signer, err = newKeystoreSigner(os.Getenv("KEYSTORE_FILE"), os.Getenv("KEYSTORE_PASSWORD"))
+	case "mnemonic":
+		signer, err = newMnemonicSigner(os.Getenv("ACCOUNT_MNEMONIC"), os.Getenv("ACCOUNT_DERIVATION_PATH"))
+	default:
+		if !isSignerEndpoint(selection) {
+			return nil, fmt.Errorf("unknown signer %q, choose key, keystore, mnemonic or an http(s)://, ws(s):// or .ipc endpoint", selection)
+		}
+		signer, err = newExternalSigner(selection, os.Getenv("ACCOUNT_ADDRESS"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	currentSigner = signer
+	return signer, nil
+}
+
+func newRawKeySigner(hexKey string) (Signer, error) {
+	if hexKey == "" {
+		return nil, errors.New("no signer configured, set ACCOUNT_PRIVATE_KEY or choose one with --signer")
+	}
+
+	key, err := crypto.ToECDSA(common.FromHex(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_PRIVATE_KEY: %w", err)
+	}
+	return &keySigner{key: key}, nil
+}
+
+func newKeystoreSigner(file string, password string) (Signer, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(content, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock keystore %s: %w", file, err)
+	}
+	return &keySigner{key: key.PrivateKey}, nil
+}
+
+func newMnemonicSigner(mnemonic string, path string) (Signer, error) {
+	derivationPath := accounts.DefaultBaseDerivationPath
+	if path != "" {
+		parsed, err := accounts.ParseDerivationPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %s: %w", path, err)
+		}
+		derivationPath = parsed
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(strings.TrimSpace(mnemonic), os.Getenv("ACCOUNT_MNEMONIC_PASSPHRASE"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_MNEMONIC: %w", err)
+	}
+
+	key, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+	return &keySigner{key: key}, nil
+}
+
+// deriveKey follows BIP-32 private key derivation from the seed.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, common.LeftPadBytes(key.Bytes(), 32)...)
+		} else {
+			parent, err := crypto.ToECDSA(common.LeftPadBytes(key.Bytes(), 32))
+			if err != nil {
+				return nil, fmt.Errorf("failed to derive key: %w", err)
+			}
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("derivation path %v hits an invalid key", path)
+		}
+		key.Add(key, tweak).Mod(key, curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("derivation path %v hits an invalid key", path)
+		}
+		chainCode = sum[32:]
+	}
+
+	return crypto.ToECDSA(common.LeftPadBytes(key.Bytes(), 32))
+}
+
+// isSignerEndpoint tells an external signer's endpoint from a mistyped
+// backend name.
+func isSignerEndpoint(selection string) bool {
+	for _, scheme := range []string{"http://", "https://", "ws://", "wss://"} {
+		if strings.HasPrefix(selection, scheme) {
+			return true
+		}
+	}
+	if strings.HasSuffix(selection, ".ipc") {
+		return true
+	}
+	info, err := os.Stat(selection)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
+
+// newExternalSigner connects to the signer and picks ACCOUNT_ADDRESS, or
+// the first account it manages when none is configured.
+func newExternalSigner(endpoint string, address string) (Signer, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to external signer %s: %w", endpoint, err)
+	}
+
+	managed := signer.Accounts()
+	if len(managed) == 0 {
+		return nil, fmt.Errorf("external signer %s manages no accounts", endpoint)
+	}
+	if address == "" {
+		return &externalSigner{signer: signer, account: managed[0]}, nil
+	}
+
+	for _, account := range managed {
+		if account.Address == common.HexToAddress(address) {
+			return &externalSigner{signer: signer, account: account}, nil
+		}
+	}
+	return nil, fmt.Errorf("external signer %s does not manage %s", endpoint, address)
+}
+
+// getAccountKey returns the private key for the few places that sign
+// messages rather than transactions, such as permits. External signers
+// keep their key to themselves.
+func getAccountKey() (*ecdsa.PrivateKey, error) {
+	signer, err := GetSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	local, ok := signer.(*keySigner)
+	if !ok {
+		return nil, errors.New("this needs a key, keystore or mnemonic signer, an external signer cannot sign it")
+	}
+	return local.key, nil
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// standInSigner is a Clef compatible JSON-RPC signer holding keys the rest
+// of the CLI cannot reach. It records what it signed.
+type standInSigner struct {
+	keys map[common.Address]*ecdsa.PrivateKey
+
+	lock   sync.Mutex
+	signed []*types.Transaction
+}
+
+// signedTransaction is what Clef answers to account_signTransaction.
+type signedTransaction struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func (s *standInSigner) Version() string {
+	return "6.0.0"
+}
+
+func (s *standInSigner) List() []common.Address {
+	var addresses []common.Address
+	for address := range s.keys {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+func (s *standInSigner) SignTransaction(args apitypes.SendTxArgs, methodSelector *string) (*signedTransaction, error) {
+	key, ok := s.keys[args.From.Address()]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %v", args.From.Address())
+	}
+	signed, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID((*big.Int)(args.ChainID)), key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.signed = append(s.signed, signed)
+	return &signedTransaction{Raw: raw, Tx: signed}, nil
+}
+
+func (s *standInSigner) transactions() []*types.Transaction {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.signed
+}
+
+// serveSigner serves a stand-in signer for the keys and selects it with
+// --signer for the rest of the test.
+func serveSigner(t *testing.T, keys ...*ecdsa.PrivateKey) *standInSigner {
+	t.Helper()
+	signer := &standInSigner{keys: map[common.Address]*ecdsa.PrivateKey{}}
+	for _, key := range keys {
+		signer.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", signer); err != nil {
+		t.Fatal(err)
+	}
+	listener := httptest.NewServer(server)
+	t.Cleanup(func() {
+		listener.Close()
+		server.Stop()
+	})
+
+	signerSelection = listener.URL
+	currentSigner = nil
+	t.Cleanup(func() {
+		signerSelection = ""
+		currentSigner = nil
+	})
+	return signer
+}
+
+// useSigner makes GetSigner return the signer for the rest of the test.
+func useSigner(t *testing.T, signer Signer) {
+	t.Helper()
+	currentSigner = signer
+	t.Cleanup(func() { currentSigner = nil })
+}
+
+func TestNewTransactionOptionsUsesTheSignersNonce(t *testing.T) {
+	chain := newTestChain(t, 3)
+	lotteryAddress, _ := chain.deployLottery(0)
+	chain.serve()
+	t.Setenv("LOTTERY_CONTRACT_ADDRESS", lotteryAddress.Hex())
+
+	// the signer has sent transactions before, while ACCOUNT_ADDRESS names
+	// an account that never has
+	_, tokenContract := chain.deployToken(1, "1")
+	chain.mine(tokenContract.Mint(chain.transactor(1), chain.accounts[1], big.NewInt(1)))
+	t.Setenv("ACCOUNT_ADDRESS", chain.accounts[2].Hex())
+	signer := serveSigner(t, chain.keys[1])
+	if _, err := GetSigner(); err == nil {
+		t.Fatal("the external signer picked an account it does not manage")
+	}
+	t.Setenv("ACCOUNT_ADDRESS", "")
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transactionOptions.From != chain.accounts[1] {
+		t.Fatalf("options send from %v, want the signer %v", transactionOptions.From, chain.accounts[1])
+	}
+	if transactionOptions.Nonce.Uint64() != 2 {
+		t.Fatalf("options use nonce %v, want the signer's 2", transactionOptions.Nonce)
+	}
+	if transactionOptions.GasLimit != 0 {
+		t.Fatalf("options fix the gas limit at %d instead of estimating it", transactionOptions.GasLimit)
+	}
+
+	transaction, err := EnterLotteryWithEther(mustParseEther(t, "0.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed := signer.transactions(); len(signed) != 1 || signed[0].Hash() != transaction.Hash() {
+		t.Fatalf("entry %v was not signed by the configured signer", transaction.Hash())
+	}
+	if transaction.Nonce() != 2 {
+		t.Fatalf("entry was sent with nonce %d, want 2", transaction.Nonce())
+	}
+	if transaction.Gas() == 300000 {
+		t.Fatal("entry used the old fixed gas limit")
+	}
+
+	players, err := GetLotteryPlayers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 1 || players[0] != chain.accounts[1] {
+		t.Fatalf("players are %v, want the signer", players)
+	}
+}
+
+func TestPermitNeedsALocalKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveSigner(t, key)
+	if _, err := getAccountKey(); err == nil {
+		t.Fatal("an external signer handed out its key")
+	}
+	if external, err := GetSigner(); err != nil || external.Address() != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("external signer acts as %v: %v", external, err)
+	}
+
+	useSigner(t, &keySigner{key: key})
+	local, err := getAccountKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local != key {
+		t.Fatal("the key signer handed out another key")
+	}
+}
+
+// TestDeriveKeyBip32Vectors checks the hand rolled derivation against the
+// private keys of test vectors 1, 2 and 3 in BIP-32.
+func TestDeriveKeyBip32Vectors(t *testing.T) {
+	vector1 := "000102030405060708090a0b0c0d0e0f"
+	vector2 := "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542"
+	// vector 3 derives a key with a leading zero byte
+	vector3 := "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be"
+
+	tests := []struct {
+		seed string
+		path string
+		key  string
+	}{
+		{vector1, "m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{vector1, "m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{vector1, "m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{vector1, "m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{vector1, "m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{vector1, "m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		{vector2, "m", "4b03d6fc340455b363f51020ad3ecca4f0850280cf436c70c727923f6db46c3e"},
+		{vector2, "m/0", "abe74a98f6c7eabee0428f53798f0ab8aa1bd37873999041703c742f15ac7e1e"},
+		{vector2, "m/0/2147483647'", "877c779ad9687164e9c2f4f0f4ff0340814392330693ce95a58fe18fd52e6e93"},
+		{vector2, "m/0/2147483647'/1", "704addf544a06e5ee4bea37098463c23613da32020d604506da8c0518e1da4b7"},
+		{vector2, "m/0/2147483647'/1/2147483646'", "f1c7c871a54a804afe328b4c83a1c33b8e5ff48f5087273f04efa83b247d6a2d"},
+		{vector2, "m/0/2147483647'/1/2147483646'/2", "bb7d39bdb83ecf58f2fd82b6d918341cbef428661ef01ab97c28a4842125ac23"},
+		{vector3, "m", "00ddb80b067e0d4993197fe10f2657a844a384589847602d56f0c629c81aae32"},
+		{vector3, "m/0'", "491f7a2eebc7b57028e0d3faa0acda02e75c33b03c48fb288c41e2ea44e1daef"},
+	}
+	for _, test := range tests {
+		t.Run(test.seed[:8]+" "+test.path, func(t *testing.T) {
+			seed, err := hex.DecodeString(test.seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var path accounts.DerivationPath
+			if test.path != "m" {
+				path, err = accounts.ParseDerivationPath(test.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			key, err := deriveKey(seed, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(crypto.FromECDSA(key)); got != test.key {
+				t.Fatalf("derived %s, want %s", got, test.key)
+			}
+		})
+	}
+}
+
+func TestGetSignerRejectsUnknownBackends(t *testing.T) {
+	currentSigner = nil
+	t.Cleanup(func() { signerSelection = "" })
+	for _, selection := range []string{"ledgr", "Key", "localhost:8550"} {
+		signerSelection = selection
+		_, err := GetSigner()
+		if err == nil || !strings.Contains(err.Error(), "unknown signer") {
+			t.Errorf("--signer %s failed with %v", selection, err)
+		}
+	}
+	for _, selection := range []string{"http://localhost:8550", "wss://signer", "/tmp/clef.ipc"} {
+		if !isSignerEndpoint(selection) {
+			t.Errorf("%s is not taken for an endpoint", selection)
+		}
+	}
+}
+
+func TestMnemonicSignerUsesTheEthereumPath(t *testing.T) {
+	t.Setenv("ACCOUNT_MNEMONIC_PASSPHRASE", "")
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+
+	signer, err := newMnemonicSigner(mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"); signer.Address() != want {
+		t.Fatalf("m/44'/60'/0'/0/0 is %v, want %v", signer.Address(), want)
+	}
+
+	if _, err := newMnemonicSigner(strings.Repeat("abandon ", 12), ""); err == nil {
+		t.Fatal("a mnemonic with a bad checksum was accepted")
+	}
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Simulation is the outcome of running a transaction as an eth_call against
+// the pending block instead of broadcasting it.
+type Simulation struct {
+	From  common.Address
+	To    *common.Address
+	Value *big.Int
+
+	Reverted     bool
+	RevertReason string
+	ReturnData   []byte
+
+	Gas      uint64
+	GasPrice *big.Int
+
+	// BalanceChanges holds the ether balance difference of every account
+	// the transaction touches. They come from a prestate trace when the node
+	// supports debug_traceCall, and are otherwise estimated from the value
+	// and the gas cost alone.
+	BalanceChanges map[common.Address]*big.Int
+	Traced         bool
+	Logs           []*types.Log
+}
+
+// Cost is the estimated gas fee of the transaction.
+func (s *Simulation) Cost() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(s.Gas), s.GasPrice)
+}
+
+// SimulateTransaction runs a transaction from the signer's account without
+// signing or sending it, so that it previews the sender, nonce and balance
+// the real transaction would have even when ACCOUNT_ADDRESS names another
+// account. A nil to simulates a contract creation.
+func SimulateTransaction(client *ethclient.Client, to *common.Address, value *big.Int, data []byte) (*Simulation, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	signer, err := GetSigner()
+	if err != nil {
+		return nil, err
+	}
+	simulation := &Simulation{From: signer.Address(), To: to, Value: value}
+	message := ethereum.CallMsg{From: simulation.From, To: to, Value: value, Data: data}
+
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gas price: %w", err)
+	}
+	simulation.GasPrice = gasPrice
+
+	returnData, err := client.PendingCallContract(context.Background(), message)
+	if err != nil {
+		reason, reverted := revertReason(err)
+		if !reverted {
+			return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+		}
+		simulation.Reverted = true
+		simulation.RevertReason = reason
+		return simulation, nil
+	}
+	simulation.ReturnData = returnData
+
+	gas, err := client.EstimateGas(context.Background(), message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	simulation.Gas = gas
+
+	message.Gas = gas
+	message.GasPrice = gasPrice
+	if err := traceSimulation(simulation, message); err != nil {
+		log.Println("node cannot trace the simulation, balance changes are estimated: ", err)
+		simulation.BalanceChanges = map[common.Address]*big.Int{
+			simulation.From: new(big.Int).Neg(new(big.Int).Add(value, simulation.Cost())),
+		}
+		if to != nil && value.Sign() > 0 {
+			simulation.BalanceChanges[*to] = new(big.Int).Set(value)
+		}
+	}
+
+	return simulation, nil
+}
+
+// revertReason reports whether the error is an EVM revert and decodes the
+// Error(string) reason when the node returns the revert data.
+func revertReason(err error) (string, bool) {
+	var dataError rpc.DataError
+	if errors.As(err, &dataError) {
+		if data, ok := dataError.ErrorData().(string); ok {
+			reason, unpackErr := abi.UnpackRevert(common.FromHex(data))
+			if unpackErr == nil {
+				return reason, true
+			}
+		}
+	}
+
+	message := err.Error()
+	if !strings.Contains(message, "revert") {
+		return "", false
+	}
+	if _, reason, ok := strings.Cut(message, "execution reverted: "); ok {
+		return reason, true
+	}
+	return "", true
+}
+
+type prestateAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+type prestateDiff struct {
+	Pre  map[common.Address]prestateAccount `json:"pre"`
+	Post map[common.Address]prestateAccount `json:"post"`
+}
+
+type callFrame struct {
+	Logs []struct {
+		Address common.Address `json:"address"`
+		Topics  []common.Hash  `json:"topics"`
+		Data    hexutil.Bytes  `json:"data"`
+	} `json:"logs"`
+	Calls []callFrame `json:"calls"`
+}
+
+// traceSimulation replays the simulated transaction with debug_traceCall to
+// read the balance differences and the emitted events.
+func traceSimulation(simulation *Simulation, message ethereum.CallMsg) error {
+	client, err := GetRpcClient()
+	if err != nil {
+		return err
+	}
+
+	arguments := map[string]interface{}{
+		"from":     message.From,
+		"value":    (*hexutil.Big)(message.Value),
+		"data":     hexutil.Bytes(message.Data),
+		"gas":      hexutil.Uint64(message.Gas),
+		"gasPrice": (*hexutil.Big)(message.GasPrice),
+	}
+	if message.To != nil {
+		arguments["to"] = message.To
+	}
+
+	var diff prestateDiff
+	prestateTracer := map[string]interface{}{"tracer": "prestateTracer", "tracerConfig": map[string]interface{}{"diffMode": true}}
+	if err := client.CallContext(context.Background(), &diff, "debug_traceCall", arguments, "pending", prestateTracer); err != nil {
+		return err
+	}
+
+	// in diff mode post only lists the fields that changed
+	simulation.BalanceChanges = map[common.Address]*big.Int{}
+	for address, after := range diff.Post {
+		if after.Balance == nil {
+			continue
+		}
+		change := new(big.Int).Set(after.Balance.ToInt())
+		if before, ok := diff.Pre[address]; ok && before.Balance != nil {
+			change.Sub(change, before.Balance.ToInt())
+		}
+		simulation.BalanceChanges[address] = change
+	}
+	simulation.Traced = true
+
+	var frame callFrame
+	callTracer := map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]interface{}{"withLog": true}}
+	if err := client.CallContext(context.Background(), &frame, "debug_traceCall", arguments, "pending", callTracer); err != nil {
+		log.Println("node cannot trace the events of the simulation: ", err)
+		return nil
+	}
+	simulation.Logs = collectTracedLogs(frame)
+
+	return nil
+}
+
+func collectTracedLogs(frame callFrame) []*types.Log {
+	var logs []*types.Log
+	for _, traced := range frame.Logs {
+		logs = append(logs, &types.Log{Address: traced.Address, Topics: traced.Topics, Data: traced.Data})
+	}
+	for _, call := range frame.Calls {
+		logs = append(logs, collectTracedLogs(call)...)
+	}
+	return logs
+}
+
+// logSimulation prints the outcome of a dry run. Labels name the accounts
+// whose balance changes are listed, e.g. sender or lottery.
+func logSimulation(simulation *Simulation, contractAbi *abi.ABI, labels map[common.Address]string) {
+	log.Println("dry run, nothing was sent")
+	if simulation.Reverted {
+		if simulation.RevertReason == "" {
+			log.Println("transaction would revert without a reason")
+		} else {
+			log.Println("transaction would revert: ", simulation.RevertReason)
+		}
+		return
+	}
+
+	log.Println("transaction would succeed")
+	log.Println("estimated gas: ", simulation.Gas)
+	log.Println("estimated cost: ", FormatUnits(simulation.Cost(), EtherDecimals), " ether at ", FormatUnits(simulation.GasPrice, 9), " gwei")
+
+	if simulation.Traced {
+		log.Println("balance changes:")
+	} else {
+		log.Println("balance changes, estimated from the value and gas cost:")
+	}
+	addresses := make([]common.Address, 0, len(simulation.BalanceChanges))
+	for address := range simulation.BalanceChanges {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Hex() < addresses[j].Hex() })
+	for _, address := range addresses {
+		label := labels[address]
+		if label == "" {
+			label = address.Hex()
+		} else {
+			label = fmt.Sprintf("%s (%s)", label, address.Hex())
+		}
+		change := FormatUnits(simulation.BalanceChanges[address], EtherDecimals)
+		if simulation.BalanceChanges[address].Sign() > 0 {
+			change = "+" + change
+		}
+		log.Println("  ", label, ": ", change, " ether")
+	}
+
+	logEvents(simulation.Logs, contractAbi)
+}
+
+// SimulateLotteryCall dry runs a lottery method from the signer's account.
+func SimulateLotteryCall(value *big.Int, method string, args ...interface{}) (*Simulation, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lottery abi: %w", err)
+	}
+
+	calldata, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+
+	address := getLotteryAddress()
+	return SimulateTransaction(client, &address, value, calldata)
+}
+
+// logLotterySimulation prints a dry run of a lottery transaction, including
+// the winnings a simulated draw would credit.
+func logLotterySimulation(simulation *Simulation) {
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		log.Fatal(fmt.Errorf("failed to parse lottery abi: %w", err))
+	}
+
+	logSimulation(simulation, &parsed, map[common.Address]string{
+		simulation.From:     "sender",
+		getLotteryAddress(): "lottery",
+	})
+
+	winnerPicked := parsed.Events["WinnerPicked"]
+	for _, simulatedLog := range simulation.Logs {
+		if len(simulatedLog.Topics) != 2 || simulatedLog.Topics[0] != winnerPicked.ID {
+			continue
+		}
+		values, err := winnerPicked.Inputs.NonIndexed().Unpack(simulatedLog.Data)
+		if err != nil || len(values) != 1 {
+			continue
+		}
+		log.Println("winner ", common.BytesToAddress(simulatedLog.Topics[1].Bytes()), " would be credited ", FormatUnits(values[0].(*big.Int), EtherDecimals), " to claim")
+	}
+}
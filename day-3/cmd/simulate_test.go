@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDryRunPreviewsTheSignersTransaction(t *testing.T) {
+	chain := newTestChain(t, 3)
+	lotteryAddress, _ := chain.deployLottery(0)
+	chain.serve()
+	useLottery(t, lotteryAddress)
+	// ACCOUNT_ADDRESS names an account that is neither signer, and that
+	// could not afford the entry
+	t.Setenv("ACCOUNT_ADDRESS", "0x00000000000000000000000000000000000000a1")
+	ctx := context.Background()
+
+	useSigner(t, &keySigner{key: chain.keys[1]})
+	stake := mustParseEther(t, "0.5")
+	entry, err := SimulateLotteryCall(stake, "enter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Reverted || entry.From != chain.accounts[1] {
+		t.Fatalf("entry dry run from %v reverted: %v", entry.From, entry.Reverted)
+	}
+	transaction, err := EnterLotteryWithEther(stake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := chain.TransactionReceipt(ctx, transaction.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("entry was not mined: %v", err)
+	}
+	if transaction.Gas() != entry.Gas {
+		t.Fatalf("entry was sent with %d gas, the dry run estimated %d", transaction.Gas(), entry.Gas)
+	}
+
+	// a player may not draw, and the dry run says so as the send does
+	draw, err := SimulateLotteryCall(nil, "pickWinner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !draw.Reverted {
+		t.Fatal("a player's draw was predicted to succeed")
+	}
+	if _, err := PickLotteryWinner(); err == nil {
+		t.Fatal("a player's draw was sent")
+	}
+
+	useSigner(t, &keySigner{key: chain.keys[0]})
+	draw, err = SimulateLotteryCall(nil, "pickWinner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draw.Reverted || draw.From != chain.accounts[0] {
+		t.Fatalf("manager's draw dry run from %v reverted: %v", draw.From, draw.Reverted)
+	}
+	transaction, err = PickLotteryWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err = chain.TransactionReceipt(ctx, transaction.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("draw was not mined: %v", err)
+	}
+	if transaction.Gas() != draw.Gas {
+		t.Fatalf("draw was sent with %d gas, the dry run estimated %d", transaction.Gas(), draw.Gas)
+	}
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"day-3/multicall"
+	"day-3/token"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// LotterySnapshot is the state of a lottery as of a single block. It is
+// read in a single call whatever the number of players: one Multicall3
+// aggregate3, or one JSON-RPC batch. Lotteries priced in a token take a
+// second call at the same block, for the token's decimals and the token
+// winnings, since those are kept per token.
+type LotterySnapshot struct {
+	Address     common.Address
+	BlockNumber uint64
+	BlockTime   time.Time
+
+	Manager      common.Address
+	Paused       bool
+	Pot          *big.Int
+	Balance      *big.Int
+	Players      []common.Address
+	Deadline     *RoundDeadline
+	PaymentToken common.Address
+	TicketPrice  *big.Int
+	// TokenDecimals are the payment token's, which the ticket price, the
+	// pot and the token winnings are counted in
+	TokenDecimals int
+
+	// winnings still to claim of the requested accounts, only holding the
+	// ones that have some
+	Unclaimed       map[common.Address]*big.Int
+	UnclaimedTokens map[common.Address]*big.Int
+
+	// Multicall is the Multicall3 the reads were aggregated with, or nil
+	// when they were sent as JSON-RPC batches
+	Multicall *common.Address
+}
+
+// maxSnapshotReads bounds how often the state is read again because the
+// head moved while the node answered a batch.
+const maxSnapshotReads = 3
+
+// snapshotCall is one read of a snapshot. A balance read asks for the
+// ether balance of the address instead of calling a contract. Through a
+// Multicall3, a call allowed to fail is marked failed instead of failing
+// the others.
+type snapshotCall struct {
+	to           common.Address
+	data         []byte
+	balance      *common.Address
+	allowFailure bool
+	failed       bool
+	result       []byte
+}
+
+// GetLotterySnapshot reads the selected lottery as of the latest block,
+// including the winnings of the given accounts. The reads go through the
+// Multicall3 of MULTICALL_ADDRESS, or else in a JSON-RPC batch. A batch the
+// head moved during is read again, through the chain's Multicall3 when it
+// has one.
+func GetLotterySnapshot(accounts []common.Address) (*LotterySnapshot, error) {
+	client, err := GetRpcClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	snapshot := &LotterySnapshot{
+		Address:         getLotteryAddress(),
+		Unclaimed:       map[common.Address]*big.Int{},
+		UnclaimedTokens: map[common.Address]*big.Int{},
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lottery abi: %w", err)
+	}
+	call := func(method string, arguments ...interface{}) *snapshotCall {
+		data, err := parsed.Pack(method, arguments...)
+		if err != nil {
+			// the methods are fixed, so this is a programming error
+			panic(err)
+		}
+		return &snapshotCall{to: snapshot.Address, data: data}
+	}
+
+	manager := call("manager")
+	paused := call("paused")
+	pot := call("pot")
+	players := call("getPlayers")
+	deadline := call("roundDeadline")
+	paymentToken := call("paymentToken")
+	ticketPrice := call("ticketPrice")
+	balance := &snapshotCall{balance: &snapshot.Address}
+	state := []*snapshotCall{manager, paused, pot, players, deadline, paymentToken, ticketPrice, balance}
+
+	// accounts may be given more than once
+	var holders []common.Address
+	seen := map[common.Address]bool{}
+	for _, address := range accounts {
+		if !seen[address] {
+			seen[address] = true
+			holders = append(holders, address)
+		}
+	}
+	var winnings []*snapshotCall
+	for _, address := range holders {
+		winnings = append(winnings, call("pendingWithdrawals", address))
+	}
+
+	if err := snapshot.readLatest(ctx, client, append(state, winnings...)); err != nil {
+		return nil, fmt.Errorf("failed to fetch lottery state: %w", err)
+	}
+
+	var roundDeadline *big.Int
+	for _, result := range []struct {
+		call  *snapshotCall
+		value interface{}
+		name  string
+	}{
+		{manager, &snapshot.Manager, "manager"},
+		{paused, &snapshot.Paused, "paused"},
+		{pot, &snapshot.Pot, "pot"},
+		{players, &snapshot.Players, "getPlayers"},
+		{deadline, &roundDeadline, "roundDeadline"},
+		{paymentToken, &snapshot.PaymentToken, "paymentToken"},
+		{ticketPrice, &snapshot.TicketPrice, "ticketPrice"},
+	} {
+		if err := parsed.UnpackIntoInterface(result.value, result.name, result.call.result); err != nil {
+			return nil, fmt.Errorf("failed to decode lottery %s: %w", result.name, err)
+		}
+	}
+	snapshot.Balance = new(big.Int).SetBytes(balance.result)
+	snapshot.Deadline = &RoundDeadline{ChainTime: snapshot.BlockTime}
+	if roundDeadline.Sign() > 0 {
+		snapshot.Deadline.Deadline = time.Unix(roundDeadline.Int64(), 0)
+	}
+	for i, address := range holders {
+		if amount := new(big.Int).SetBytes(winnings[i].result); amount.Sign() > 0 {
+			snapshot.Unclaimed[address] = amount
+		}
+	}
+
+	if snapshot.PaymentToken == (common.Address{}) {
+		return snapshot, nil
+	}
+	tokenAbi, err := abi.JSON(strings.NewReader(token.FredCoinABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token abi: %w", err)
+	}
+	decimalsData, err := tokenAbi.Pack("decimals")
+	if err != nil {
+		panic(err)
+	}
+	decimals := &snapshotCall{to: snapshot.PaymentToken, data: decimalsData}
+	var tokenWinnings []*snapshotCall
+	for _, address := range holders {
+		tokenWinnings = append(tokenWinnings, call("pendingTokenWithdrawals", snapshot.PaymentToken, address))
+	}
+	if err := snapshot.read(ctx, client, append([]*snapshotCall{decimals}, tokenWinnings...)); err != nil {
+		return nil, fmt.Errorf("failed to fetch payment token state: %w", err)
+	}
+	snapshot.TokenDecimals = int(new(big.Int).SetBytes(decimals.result).Int64())
+	for i, address := range holders {
+		if amount := new(big.Int).SetBytes(tokenWinnings[i].result); amount.Sign() > 0 {
+			snapshot.UnclaimedTokens[address] = amount
+		}
+	}
+
+	return snapshot, nil
+}
+
+// readLatest makes the calls at the latest block in a single call, and
+// sets the snapshot's block to the one they were made at.
+func (s *LotterySnapshot) readLatest(ctx context.Context, client *rpc.Client, calls []*snapshotCall) error {
+	multicallAddress, err := configuredMulticall()
+	if err != nil {
+		return err
+	}
+
+	// a Multicall3 without the block reads is not asked again
+	var blockless *common.Address
+	for attempt := 0; attempt < maxSnapshotReads; attempt++ {
+		if multicallAddress != nil && (blockless == nil || *blockless != *multicallAddress) {
+			read, err := s.readLatestMulticall(ctx, client, *multicallAddress, calls)
+			if err != nil || read {
+				return err
+			}
+			blockless = multicallAddress
+		}
+
+		chain, err := s.readLatestBatch(ctx, client, calls)
+		if err != nil || chain.consistent {
+			return err
+		}
+		multicallAddress, err = resolveMulticall(chain.id, chain.canonicalCode)
+		if err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("new blocks kept arriving during %d reads", maxSnapshotReads)
+}
+
+// readLatestMulticall makes the calls in one aggregate3 along with the
+// Multicall3's getBlockNumber and getCurrentBlockTimestamp. It reports
+// false when the Multicall3 lacks those, as then the block is unknown.
+func (s *LotterySnapshot) readLatestMulticall(ctx context.Context, client *rpc.Client, address common.Address, calls []*snapshotCall) (bool, error) {
+	parsed, err := abi.JSON(strings.NewReader(multicall.Multicall3ABI))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse multicall abi: %w", err)
+	}
+	blockCall := func(method string) *snapshotCall {
+		data, err := parsed.Pack(method)
+		if err != nil {
+			panic(err)
+		}
+		return &snapshotCall{to: address, data: data, allowFailure: true}
+	}
+	blockNumber := blockCall("getBlockNumber")
+	blockTime := blockCall("getCurrentBlockTimestamp")
+
+	if err := readMulticall(ctx, client, address, "latest", append([]*snapshotCall{blockNumber, blockTime}, calls...)); err != nil {
+		return false, err
+	}
+	if blockNumber.failed || blockTime.failed {
+		return false, nil
+	}
+	s.BlockNumber = new(big.Int).SetBytes(blockNumber.result).Uint64()
+	s.BlockTime = time.Unix(new(big.Int).SetBytes(blockTime.result).Int64(), 0)
+	s.Multicall = &address
+	return true, nil
+}
+
+// latestChain is what a batch learnt of the chain besides the calls.
+type latestChain struct {
+	id            *big.Int
+	canonicalCode []byte
+	// consistent is whether the head stayed the same during the batch
+	consistent bool
+}
+
+// readLatestBatch makes the calls in one JSON-RPC batch, between reads of
+// the latest block number, so that a block landing while the node answers
+// is noticed. The batch also asks for what resolving the chain's
+// Multicall3 takes.
+func (s *LotterySnapshot) readLatestBatch(ctx context.Context, client *rpc.Client, calls []*snapshotCall) (*latestChain, error) {
+	var header struct {
+		Number hexutil.Uint64 `json:"number"`
+		Time   hexutil.Uint64 `json:"timestamp"`
+	}
+	var chainId hexutil.Big
+	var canonicalCode hexutil.Bytes
+	var head hexutil.Uint64
+	elements := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber", Args: []interface{}{"latest", false}, Result: &header},
+		{Method: "eth_chainId", Result: &chainId},
+		{Method: "eth_getCode", Args: []interface{}{CanonicalMulticall3, "latest"}, Result: &canonicalCode},
+	}
+	reads, collect := batchElements(calls, "latest")
+	elements = append(elements, reads...)
+	elements = append(elements, rpc.BatchElem{Method: "eth_blockNumber", Result: &head})
+
+	if err := batchCall(ctx, client, elements); err != nil {
+		return nil, err
+	}
+	collect()
+	s.BlockNumber = uint64(header.Number)
+	s.BlockTime = time.Unix(int64(header.Time), 0)
+	return &latestChain{
+		id:            (*big.Int)(&chainId),
+		canonicalCode: canonicalCode,
+		consistent:    head == header.Number,
+	}, nil
+}
+
+// read makes the calls at the snapshot's block, as a single Multicall3
+// call when there is one, or as a single JSON-RPC batch.
+func (s *LotterySnapshot) read(ctx context.Context, client *rpc.Client, calls []*snapshotCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	block := hexutil.EncodeUint64(s.BlockNumber)
+	if s.Multicall != nil {
+		return readMulticall(ctx, client, *s.Multicall, block, calls)
+	}
+	return readBatch(ctx, client, block, calls)
+}
+
+func readBatch(ctx context.Context, client *rpc.Client, block string, calls []*snapshotCall) error {
+	elements, collect := batchElements(calls, block)
+	if err := batchCall(ctx, client, elements); err != nil {
+		return err
+	}
+	collect()
+	return nil
+}
+
+// batchElements are the JSON-RPC calls making the calls at the block, and
+// collect stores their results in the calls once the batch was answered.
+func batchElements(calls []*snapshotCall, block string) ([]rpc.BatchElem, func()) {
+	elements := make([]rpc.BatchElem, len(calls))
+	results := make([]hexutil.Bytes, len(calls))
+	balances := make([]hexutil.Big, len(calls))
+	for i, call := range calls {
+		if call.balance != nil {
+			elements[i] = rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{*call.balance, block}, Result: &balances[i]}
+			continue
+		}
+		message := map[string]interface{}{"to": call.to, "data": hexutil.Bytes(call.data)}
+		elements[i] = rpc.BatchElem{Method: "eth_call", Args: []interface{}{message, block}, Result: &results[i]}
+	}
+
+	collect := func() {
+		for i, call := range calls {
+			if call.balance != nil {
+				call.result = (*big.Int)(&balances[i]).Bytes()
+			} else {
+				call.result = results[i]
+			}
+		}
+	}
+	return elements, collect
+}
+
+// readMulticall reads balances through the Multicall3's getEthBalance, so
+// every read lands in the one call.
+func readMulticall(ctx context.Context, client *rpc.Client, address common.Address, block string, calls []*snapshotCall) error {
+	parsed, err := abi.JSON(strings.NewReader(multicall.Multicall3ABI))
+	if err != nil {
+		return fmt.Errorf("failed to parse multicall abi: %w", err)
+	}
+
+	aggregated := make([]multicall.Multicall3Call3, len(calls))
+	for i, call := range calls {
+		aggregated[i] = multicall.Multicall3Call3{Target: call.to, AllowFailure: call.allowFailure, CallData: call.data}
+		if call.balance != nil {
+			data, err := parsed.Pack("getEthBalance", *call.balance)
+			if err != nil {
+				return fmt.Errorf("failed to pack balance read: %w", err)
+			}
+			aggregated[i] = multicall.Multicall3Call3{Target: address, AllowFailure: call.allowFailure, CallData: data}
+		}
+	}
+	data, err := parsed.Pack("aggregate3", aggregated)
+	if err != nil {
+		return fmt.Errorf("failed to pack multicall: %w", err)
+	}
+
+	var output hexutil.Bytes
+	message := map[string]interface{}{"to": address, "data": hexutil.Bytes(data)}
+	if err := client.CallContext(ctx, &output, "eth_call", message, block); err != nil {
+		return fmt.Errorf("multicall %v failed: %w", address, err)
+	}
+
+	unpacked, err := parsed.Unpack("aggregate3", output)
+	if err != nil {
+		return fmt.Errorf("failed to decode multicall %v results: %w", address, err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]multicall.Multicall3Result)).(*[]multicall.Multicall3Result)
+	if len(results) != len(calls) {
+		return fmt.Errorf("multicall %v returned %d results for %d calls", address, len(results), len(calls))
+	}
+	for i, call := range calls {
+		call.result = results[i].ReturnData
+		call.failed = !results[i].Success
+	}
+	return nil
+}
+
+// batchCall sends a JSON-RPC batch and fails with the first call that
+// failed.
+func batchCall(ctx context.Context, client *rpc.Client, elements []rpc.BatchElem) error {
+	if err := client.BatchCallContext(ctx, elements); err != nil {
+		return err
+	}
+	for _, element := range elements {
+		if element.Error != nil {
+			return fmt.Errorf("%s failed: %w", element.Method, element.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"day-3/multicall"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// snapshotTestLottery is a lottery managed by account 0, with a refunded
+// round for account 1 and an open one with account 2 in it.
+func snapshotTestLottery(t *testing.T) (*testChain, common.Address, *lottery.Lottery) {
+	t.Helper()
+	chain := newTestChain(t, 3)
+	address, contract := chain.deployLottery(0)
+	chain.serve()
+	useLottery(t, address)
+
+	chain.enter(contract, 1, "1")
+	chain.mine(contract.CancelRound(chain.transactor(0)))
+	chain.enter(contract, 2, "0.5")
+	return chain, address, contract
+}
+
+// expectSnapshot reads the snapshot of the lottery for accounts 1 and 2,
+// and checks it took the given number of requests to the node.
+func expectSnapshot(t *testing.T, chain *testChain, requests int64) *LotterySnapshot {
+	t.Helper()
+	before := chain.requests.Load()
+	snapshot, err := GetLotterySnapshot([]common.Address{chain.accounts[1], chain.accounts[2], chain.accounts[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if made := chain.requests.Load() - before; made != requests {
+		t.Fatalf("the snapshot took %d requests, want %d", made, requests)
+	}
+
+	head, err := chain.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.BlockNumber != head.Number.Uint64() || snapshot.BlockTime.Unix() != int64(head.Time) {
+		t.Fatalf("snapshot is of block %d at %v, want the head %d", snapshot.BlockNumber, snapshot.BlockTime, head.Number)
+	}
+	return snapshot
+}
+
+func TestLotterySnapshotIsOneBatch(t *testing.T) {
+	chain, address, _ := snapshotTestLottery(t)
+	t.Setenv("MULTICALL_ADDRESS", "none")
+
+	snapshot := expectSnapshot(t, chain, 1)
+	if snapshot.Multicall != nil {
+		t.Fatalf("reads went through the multicall %v", snapshot.Multicall)
+	}
+	if snapshot.Address != address || snapshot.Manager != chain.accounts[0] || snapshot.Paused {
+		t.Fatalf("snapshot is %+v", snapshot)
+	}
+	stake := mustParseEther(t, "0.5")
+	if snapshot.Pot.Cmp(stake) != 0 || len(snapshot.Players) != 1 || snapshot.Players[0] != chain.accounts[2] {
+		t.Fatalf("round is %v with players %v", snapshot.Pot, snapshot.Players)
+	}
+	// the balance holds the refund still to claim besides the pot
+	if want := mustParseEther(t, "1.5"); snapshot.Balance.Cmp(want) != 0 {
+		t.Fatalf("balance is %v, want %v", snapshot.Balance, want)
+	}
+	if snapshot.Deadline == nil || snapshot.Deadline.Deadline.IsZero() {
+		t.Fatalf("open round has no deadline: %+v", snapshot.Deadline)
+	}
+	if len(snapshot.Unclaimed) != 1 || snapshot.Unclaimed[chain.accounts[1]].Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("unclaimed winnings are %v", snapshot.Unclaimed)
+	}
+}
+
+func TestLotterySnapshotIsOneMulticall(t *testing.T) {
+	chain, _, _ := snapshotTestLottery(t)
+	multicallAddress, transaction, _, err := multicall.DeployMulticall3(chain.transactor(0), chain)
+	chain.mine(transaction, err)
+	t.Setenv("MULTICALL_ADDRESS", multicallAddress.Hex())
+
+	snapshot := expectSnapshot(t, chain, 1)
+	if snapshot.Multicall == nil || *snapshot.Multicall != multicallAddress {
+		t.Fatalf("reads went through the multicall %v", snapshot.Multicall)
+	}
+	if snapshot.Pot.Cmp(mustParseEther(t, "0.5")) != 0 || len(snapshot.Players) != 1 {
+		t.Fatalf("round is %v with players %v", snapshot.Pot, snapshot.Players)
+	}
+	if snapshot.Balance.Cmp(mustParseEther(t, "1.5")) != 0 {
+		t.Fatalf("balance is %v", snapshot.Balance)
+	}
+	if len(snapshot.Unclaimed) != 1 || snapshot.Unclaimed[chain.accounts[1]].Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("unclaimed winnings are %v", snapshot.Unclaimed)
+	}
+}
+
+func TestLotterySnapshotReadsTokenWinningsAtTheSameBlock(t *testing.T) {
+	chain, address, contract := snapshotTestLottery(t)
+	t.Setenv("MULTICALL_ADDRESS", "none")
+	chain.mine(contract.CancelRound(chain.transactor(0)))
+
+	// account 1 is refunded a round priced in a token with 6 decimals
+	tokenAddress, tokenContract := chain.deployTestContract("SixDecimalToken", 1, big.NewInt(1_000_000_000))
+	chain.mine(contract.SetPaymentToken(chain.transactor(0), tokenAddress, big.NewInt(2_500_000)))
+	chain.mine(tokenContract.Transact(chain.transactor(1), "approve", address, big.NewInt(2_500_000)))
+	chain.mine(contract.EnterWithToken(chain.transactor(1)))
+	chain.mine(contract.CancelRound(chain.transactor(0)))
+
+	snapshot := expectSnapshot(t, chain, 2)
+	if snapshot.PaymentToken != tokenAddress || snapshot.TicketPrice.Cmp(big.NewInt(2_500_000)) != 0 || snapshot.TokenDecimals != 6 {
+		t.Fatalf("snapshot is priced %v in %v with %d decimals", snapshot.TicketPrice, snapshot.PaymentToken, snapshot.TokenDecimals)
+	}
+	if len(snapshot.UnclaimedTokens) != 1 || snapshot.UnclaimedTokens[chain.accounts[1]].Cmp(big.NewInt(2_500_000)) != 0 {
+		t.Fatalf("unclaimed token winnings are %v", snapshot.UnclaimedTokens)
+	}
+	if len(snapshot.Unclaimed) != 2 {
+		t.Fatalf("unclaimed winnings are %v", snapshot.Unclaimed)
+	}
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.9;
+
+interface ILottery {
+    function enter() external payable;
+    function withdraw() external;
+}
+
+// A contract wallet that plays the lottery but refuses ether, like a
+// wallet whose receive hook runs out of gas or reverts.
+contract RevertingWallet {
+    function enter(address lottery) public payable {
+        ILottery(lottery).enter{value: msg.value}();
+    }
+
+    function withdraw(address lottery) public {
+        ILottery(lottery).withdraw();
+    }
+
+    receive() external payable {
+        revert("RevertingWallet: ether refused");
+    }
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"context"
+	"day-3/token"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func tokenCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use: "token",
+	}
+	command.AddCommand(tokenDeployCommand())
+	command.AddCommand(tokenBalanceCommand())
+	command.AddCommand(tokenTransferCommand())
+	command.AddCommand(tokenApproveCommand())
+	command.AddCommand(tokenAllowanceCommand())
+	command.AddCommand(tokenMintCommand())
+	command.AddCommand(tokenBurnCommand())
+	return command
+}
+
+func tokenDeployCommand() *cobra.Command {
+	var supplyCap string
+
+	command := &cobra.Command{
+		Use: "deploy",
+		Run: func(cmd *cobra.Command, args []string) {
+			maxSupply, err := ParseUnits(supplyCap, EtherDecimals)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Println("deploying FredCoin with a supply cap of ", supplyCap, "...")
+			_, address, err := DeployToken(maxSupply)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("token deployed to address: ", address)
+			log.Println("set TOKEN_CONTRACT_ADDRESS to use it with the other token commands")
+		},
+	}
+	command.Flags().StringVar(&supplyCap, "cap", "21000000", "maximum token supply in whole FRED")
+	return command
+}
+
+func tokenBalanceCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "balance [address]",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			holder := getAccountAddress()
+			if len(args) == 1 {
+				holder = parseAddressArg(args[0])
+			}
+
+			balance, err := GetTokenBalance(holder)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("FRED balance of ", holder, " is: ", FormatUnits(balance, EtherDecimals))
+		},
+	}
+}
+
+func tokenTransferCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "transfer <to> <amount>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			to, amount := parseAddressArg(args[0]), parseTokenAmountArg(args[1])
+
+			log.Println("transferring ", args[1], " FRED to ", to, "...")
+			transaction, err := TransferToken(to, amount)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("tokens transferred in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func tokenApproveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "approve <spender> <amount>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			spender, amount := parseAddressArg(args[0]), parseTokenAmountArg(args[1])
+
+			log.Println("approving ", spender, " to spend ", args[1], " FRED...")
+			transaction, err := ApproveToken(spender, amount)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("allowance set in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func tokenAllowanceCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "allowance <owner> <spender>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			owner, spender := parseAddressArg(args[0]), parseAddressArg(args[1])
+
+			allowance, err := GetTokenAllowance(owner, spender)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println(spender, " may spend ", FormatUnits(allowance, EtherDecimals), " FRED of ", owner)
+		},
+	}
+}
+
+func tokenMintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "mint <to> <amount>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			to, amount := parseAddressArg(args[0]), parseTokenAmountArg(args[1])
+
+			log.Println("minting ", args[1], " FRED to ", to, "...")
+			transaction, err := MintToken(to, amount)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("tokens minted in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func tokenBurnCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:  "burn <amount>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			amount := parseTokenAmountArg(args[0])
+
+			log.Println("burning ", args[0], " FRED...")
+			transaction, err := BurnToken(amount)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("tokens burned in transaction hash: ", transaction.Hash())
+		},
+	}
+}
+
+func parseAddressArg(arg string) common.Address {
+	if !common.IsHexAddress(arg) {
+		log.Fatalf("invalid address: %s", arg)
+	}
+	return common.HexToAddress(arg)
+}
+
+func parseTokenAmountArg(arg string) *big.Int {
+	amount, err := ParseUnits(arg, EtherDecimals)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return amount
+}
+
+// DeployToken deploys FredCoin with the given cap and records it in the
+// registry.
+func DeployToken(maxSupply *big.Int) (*token.FredCoin, common.Address, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	_, transaction, _, err := token.DeployFredCoin(transactionOptions, client, maxSupply)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to deploy token: %w", err)
+	}
+
+	address, err := bind.WaitDeployed(context.Background(), client, transaction)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("error occured while waiting for token to deploy: %w", err)
+	}
+
+	err = recordDeployment(client, "FredCoin", Deployment{Address: address, TransactionHash: transaction.Hash()})
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	contract, err := token.NewFredCoin(address, client)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to bind token contract: %w", err)
+	}
+
+	return contract, address, nil
+}
+
+func GetTokenBalance(holder common.Address) (*big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := tokenContract.BalanceOf(nil, holder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+func GetTokenAllowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := tokenContract.Allowance(nil, owner, spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token allowance: %w", err)
+	}
+
+	return allowance, nil
+}
+
+// GetTokenDecimals returns the decimals amounts of the given token are
+// counted in, which need not be FredCoin's.
+func GetTokenDecimals(tokenAddress common.Address) (int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return 0, err
+	}
+	return readTokenDecimals(client, tokenAddress, nil)
+}
+
+// ParseTokenAmount parses an amount of whole tokens of the given token
+// into its base units.
+func ParseTokenAmount(tokenAddress common.Address, text string) (*big.Int, error) {
+	decimals, err := GetTokenDecimals(tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnits(text, decimals)
+}
+
+func TransferToken(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := tokenContract.Transfer(transactionOptions, to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer tokens: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func ApproveToken(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := tokenContract.Approve(transactionOptions, spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve tokens: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func MintToken(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := tokenContract.Mint(transactionOptions, to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint tokens: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func BurnToken(amount *big.Int) (*types.Transaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenContract, err := getTokenContract(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := tokenContract.Burn(transactionOptions, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to burn tokens: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func getTokenContract(client *ethclient.Client) (*token.FredCoin, error) {
+	tokenContract, err := token.NewFredCoin(getTokenAddress(), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token contract: %w", err)
+	}
+	return tokenContract, nil
+}
+
+// readTokenDecimals returns the decimals amounts of the token are counted
+// in.
+func readTokenDecimals(client bind.ContractCaller, tokenAddress common.Address, options *bind.CallOpts) (int, error) {
+	tokenContract, err := token.NewFredCoinCaller(tokenAddress, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bind token %v: %w", tokenAddress, err)
+	}
+	decimals, err := tokenContract.Decimals(options)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch token %v decimals: %w", tokenAddress, err)
+	}
+	return int(decimals), nil
+}
+
+func getTokenAddress() common.Address {
+	return common.HexToAddress(os.Getenv("TOKEN_CONTRACT_ADDRESS"))
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"day-3/token"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func tokenBalance(t *testing.T, tokenContract *token.FredCoin, holder common.Address) *big.Int {
+	t.Helper()
+	balance, err := tokenContract.BalanceOf(nil, holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return balance
+}
+
+func tokenAllowance(t *testing.T, tokenContract *token.FredCoin, owner common.Address, spender common.Address) *big.Int {
+	t.Helper()
+	allowance, err := tokenContract.Allowance(nil, owner, spender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return allowance
+}
+
+// onlyTransfer returns the single Transfer event the receipt logged.
+func onlyTransfer(t *testing.T, tokenContract *token.FredCoin, receipt *types.Receipt) *token.FredCoinTransfer {
+	t.Helper()
+	if len(receipt.Logs) != 1 {
+		t.Fatalf("transaction logged %d events, want 1", len(receipt.Logs))
+	}
+	event, err := tokenContract.ParseTransfer(*receipt.Logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
+
+// findApproval returns the Approval event among the receipt's logs.
+func findApproval(t *testing.T, tokenContract *token.FredCoin, receipt *types.Receipt) *token.FredCoinApproval {
+	t.Helper()
+	for _, log := range receipt.Logs {
+		if event, err := tokenContract.ParseApproval(*log); err == nil {
+			return event
+		}
+	}
+	t.Fatal("transaction did not log an Approval")
+	return nil
+}
+
+func TestTokenMintAndTransfer(t *testing.T) {
+	chain := newTestChain(t, 3)
+	_, tokenContract := chain.deployToken(0, "100")
+	owner, alice, bob := chain.accounts[0], chain.accounts[1], chain.accounts[2]
+
+	receipt := chain.mine(tokenContract.Mint(chain.transactor(0), alice, mustParseEther(t, "60")))
+	event := onlyTransfer(t, tokenContract, receipt)
+	if event.From != (common.Address{}) || event.To != alice || event.Value.Cmp(mustParseEther(t, "60")) != 0 {
+		t.Fatalf("mint logged a transfer of %v from %v to %v", event.Value, event.From, event.To)
+	}
+
+	if _, err := tokenContract.Mint(chain.transactor(1), alice, mustParseEther(t, "1")); err == nil {
+		t.Fatal("a holder who does not own the token minted")
+	}
+	if _, err := tokenContract.Mint(chain.transactor(0), owner, mustParseEther(t, "40.000000000000000001")); err == nil {
+		t.Fatal("minting past the cap succeeded")
+	}
+	chain.mine(tokenContract.Mint(chain.transactor(0), owner, mustParseEther(t, "40")))
+
+	receipt = chain.mine(tokenContract.Transfer(chain.transactor(1), bob, mustParseEther(t, "25")))
+	event = onlyTransfer(t, tokenContract, receipt)
+	if event.From != alice || event.To != bob || event.Value.Cmp(mustParseEther(t, "25")) != 0 {
+		t.Fatalf("transfer logged %v from %v to %v", event.Value, event.From, event.To)
+	}
+	if balance := tokenBalance(t, tokenContract, alice); balance.Cmp(mustParseEther(t, "35")) != 0 {
+		t.Fatalf("sender holds %v after the transfer, want 35 FRED", balance)
+	}
+	if balance := tokenBalance(t, tokenContract, bob); balance.Cmp(mustParseEther(t, "25")) != 0 {
+		t.Fatalf("recipient holds %v after the transfer, want 25 FRED", balance)
+	}
+
+	if _, err := tokenContract.Transfer(chain.transactor(2), alice, mustParseEther(t, "25.000000000000000001")); err == nil {
+		t.Fatal("transferring more than the balance succeeded")
+	}
+	if _, err := tokenContract.Transfer(chain.transactor(2), common.Address{}, mustParseEther(t, "1")); err == nil {
+		t.Fatal("transferring to the zero address succeeded")
+	}
+
+	receipt = chain.mine(tokenContract.Burn(chain.transactor(2), mustParseEther(t, "5")))
+	event = onlyTransfer(t, tokenContract, receipt)
+	if event.From != bob || event.To != (common.Address{}) {
+		t.Fatalf("burn logged a transfer from %v to %v", event.From, event.To)
+	}
+	supply, err := tokenContract.TotalSupply(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supply.Cmp(mustParseEther(t, "95")) != 0 {
+		t.Fatalf("total supply is %v after burning, want 95 FRED", supply)
+	}
+}
+
+func TestTokenApproveAndTransferFrom(t *testing.T) {
+	chain := newTestChain(t, 3)
+	_, tokenContract := chain.deployToken(0, "100")
+	holder, spender, recipient := chain.accounts[0], chain.accounts[1], chain.accounts[2]
+	chain.mine(tokenContract.Mint(chain.transactor(0), holder, mustParseEther(t, "100")))
+
+	if _, err := tokenContract.TransferFrom(chain.transactor(1), holder, recipient, mustParseEther(t, "1")); err == nil {
+		t.Fatal("spending without an allowance succeeded")
+	}
+
+	receipt := chain.mine(tokenContract.Approve(chain.transactor(0), spender, mustParseEther(t, "10")))
+	approval := findApproval(t, tokenContract, receipt)
+	if approval.Owner != holder || approval.Spender != spender || approval.Value.Cmp(mustParseEther(t, "10")) != 0 {
+		t.Fatalf("approve logged %v from %v to %v", approval.Value, approval.Owner, approval.Spender)
+	}
+	if allowance := tokenAllowance(t, tokenContract, holder, spender); allowance.Cmp(mustParseEther(t, "10")) != 0 {
+		t.Fatalf("allowance is %v, want 10 FRED", allowance)
+	}
+
+	// spending logs the transfer and the reduced allowance
+	receipt = chain.mine(tokenContract.TransferFrom(chain.transactor(1), holder, recipient, mustParseEther(t, "4")))
+	approval = findApproval(t, tokenContract, receipt)
+	if approval.Value.Cmp(mustParseEther(t, "6")) != 0 {
+		t.Fatalf("spending logged an allowance of %v, want 6 FRED", approval.Value)
+	}
+	if allowance := tokenAllowance(t, tokenContract, holder, spender); allowance.Cmp(mustParseEther(t, "6")) != 0 {
+		t.Fatalf("allowance is %v after spending, want 6 FRED", allowance)
+	}
+	if balance := tokenBalance(t, tokenContract, recipient); balance.Cmp(mustParseEther(t, "4")) != 0 {
+		t.Fatalf("recipient holds %v, want 4 FRED", balance)
+	}
+	if _, err := tokenContract.TransferFrom(chain.transactor(1), holder, recipient, mustParseEther(t, "6.000000000000000001")); err == nil {
+		t.Fatal("spending more than the allowance succeeded")
+	}
+
+	// approving replaces the allowance instead of adding to it
+	chain.mine(tokenContract.Approve(chain.transactor(0), spender, mustParseEther(t, "1")))
+	if allowance := tokenAllowance(t, tokenContract, holder, spender); allowance.Cmp(mustParseEther(t, "1")) != 0 {
+		t.Fatalf("allowance is %v after approving again, want 1 FRED", allowance)
+	}
+
+	// an unlimited allowance is never spent down
+	chain.mine(tokenContract.Approve(chain.transactor(0), spender, math.MaxBig256))
+	receipt = chain.mine(tokenContract.TransferFrom(chain.transactor(1), holder, recipient, mustParseEther(t, "50")))
+	onlyTransfer(t, tokenContract, receipt)
+	if allowance := tokenAllowance(t, tokenContract, holder, spender); allowance.Cmp(math.MaxBig256) != 0 {
+		t.Fatalf("unlimited allowance dropped to %v", allowance)
+	}
+}
+
+func TestParseTokenAmountInTheTokenDecimals(t *testing.T) {
+	chain := newTestChain(t, 2)
+	fredCoin, _ := chain.deployToken(0, "100")
+	sixDecimals, _ := chain.deployTestContract("SixDecimalToken", 1, big.NewInt(1_000_000_000))
+	chain.serve()
+
+	tests := []struct {
+		token  common.Address
+		amount string
+		want   string
+	}{
+		{fredCoin, "2.5", "2500000000000000000"},
+		{sixDecimals, "2.5", "2500000"},
+		{sixDecimals, "0.000001", "1"},
+		{sixDecimals, "0.0000001", ""},
+		{chain.accounts[1], "1", ""},
+	}
+	for _, test := range tests {
+		amount, err := ParseTokenAmount(test.token, test.amount)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("parsed %s of %v as %v", test.amount, test.token, amount)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s of %v: %v", test.amount, test.token, err)
+			continue
+		}
+		if amount.String() != test.want {
+			t.Errorf("%s of %v parsed as %v, want %s", test.amount, test.token, amount, test.want)
+		}
+	}
+}
+
+func TestDeployTokenRecordsItInTheRegistry(t *testing.T) {
+	chain := newTestChain(t, 1)
+	chain.serve()
+	chain.signAs(0)
+	t.Setenv("DEPLOYMENTS_FILE", filepath.Join(t.TempDir(), "deployments.json"))
+
+	tokenContract, address, err := DeployToken(mustParseEther(t, "1000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supplyCap, err := tokenContract.Cap(nil); err != nil || supplyCap.Cmp(mustParseEther(t, "1000")) != 0 {
+		t.Fatalf("token cap is %v: %v", supplyCap, err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployment, ok := registry.Lookup(testChainId, "FredCoin"); !ok || deployment.Address != address {
+		t.Fatalf("registry records %+v for the token deployed at %v", deployment, address)
+	}
+}
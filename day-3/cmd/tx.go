@@ -0,0 +1,499 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func txCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "tx",
+		Short: "build, sign and broadcast a transaction in separate steps",
+	}
+	command.AddCommand(txBuildCommand())
+	command.AddCommand(txSignCommand())
+	command.AddCommand(txBroadcastCommand())
+	return command
+}
+
+func txBuildCommand() *cobra.Command {
+	var abiSource string
+	var value string
+	var from string
+	var nonce int64
+	var out string
+
+	command := &cobra.Command{
+		Use:   "build <address|contract> <method> [args...]",
+		Short: "write an unsigned transaction, without needing the private key",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			amount, err := ParseUnits(value, EtherDecimals)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var fixedNonce *uint64
+			if nonce >= 0 {
+				n := uint64(nonce)
+				fixedNonce = &n
+			}
+
+			unsigned, err := BuildUnsignedTransaction(parseAddressArg(from), args[0], abiSource, args[1], args[2:], amount, fixedNonce)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			content, err := json.MarshalIndent(unsigned, "", "  ")
+			if err != nil {
+				log.Fatal(fmt.Errorf("failed to encode unsigned transaction: %w", err))
+			}
+			if err := writeOutput(out, string(content)); err != nil {
+				log.Fatal(err)
+			}
+			method, values, err := decodeCalldata(unsigned.Abi, unsigned.Data)
+			logUnsignedTransaction(unsigned, method, values, err)
+		},
+	}
+	command.Flags().StringVar(&abiSource, "abi", "", "abi file, or the name of a contract in ./build")
+	command.Flags().StringVar(&value, "value", "0", "ether to send with the transaction")
+	command.Flags().StringVar(&from, "from", os.Getenv("ACCOUNT_ADDRESS"), "account that will sign the transaction")
+	command.Flags().Int64Var(&nonce, "nonce", -1, "nonce to use instead of the account's pending nonce")
+	command.Flags().StringVar(&out, "out", "", "file to write the unsigned transaction to (defaults to stdout)")
+	return command
+}
+
+func txSignCommand() *cobra.Command {
+	var chainId int64
+	var abiSource string
+	var out string
+	var yes bool
+
+	command := &cobra.Command{
+		Use:   "sign <unsigned.json>",
+		Short: "sign an unsigned transaction offline with the selected signer",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				log.Fatal(fmt.Errorf("failed to read unsigned transaction: %w", err))
+			}
+
+			unsigned := &UnsignedTransaction{}
+			if err := json.Unmarshal(content, unsigned); err != nil {
+				log.Fatal(fmt.Errorf("failed to parse unsigned transaction: %w", err))
+			}
+
+			// the calldata is shown as the local abi reads it, never as the
+			// abi carried by the file says
+			method, values, err := CheckUnsignedCalldata(unsigned, abiSource)
+			if err != nil {
+				log.Fatal(err)
+			}
+			logUnsignedTransaction(unsigned, method, values, nil)
+			if !yes && !confirm("sign this transaction?") {
+				log.Fatal("signing cancelled")
+			}
+
+			signer, err := GetSigner()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			signed, err := SignUnsignedTransaction(unsigned, big.NewInt(chainId), signer, abiSource)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			raw, err := signed.MarshalBinary()
+			if err != nil {
+				log.Fatal(fmt.Errorf("failed to encode signed transaction: %w", err))
+			}
+			if err := writeOutput(out, hexutil.Encode(raw)); err != nil {
+				log.Fatal(err)
+			}
+			log.Println("signed transaction hash: ", signed.Hash())
+		},
+	}
+	command.Flags().Int64Var(&chainId, "chain-id", 0, "chain id the transaction must be for")
+	command.Flags().StringVar(&abiSource, "abi", "", "abi file, or the name of a contract in ./build, for targets not in the registry")
+	command.Flags().StringVar(&out, "out", "", "file to write the signed transaction to (defaults to stdout)")
+	command.Flags().BoolVar(&yes, "yes", false, "sign without asking for confirmation")
+	_ = command.MarkFlagRequired("chain-id")
+	return command
+}
+
+func txBroadcastCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "broadcast <signed file|0x...>",
+		Short: "submit a signed transaction and wait for its receipt",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			raw := args[0]
+			if !strings.HasPrefix(raw, "0x") {
+				content, err := os.ReadFile(raw)
+				if err != nil {
+					log.Fatal(fmt.Errorf("failed to read signed transaction: %w", err))
+				}
+				raw = strings.TrimSpace(string(content))
+			}
+
+			receipt, contractAbi, err := BroadcastSignedTransaction(raw)
+			if err != nil {
+				log.Fatal(err)
+			}
+			logReceipt(receipt, contractAbi)
+		},
+	}
+}
+
+// UnsignedTransaction is everything needed to sign a transaction on a
+// machine without network access. It carries the abi of the called method
+// so the signer can check the calldata before signing.
+type UnsignedTransaction struct {
+	ChainId   *big.Int        `json:"chainId"`
+	From      common.Address  `json:"from"`
+	To        common.Address  `json:"to"`
+	Nonce     uint64          `json:"nonce"`
+	Gas       uint64          `json:"gas"`
+	GasPrice  *big.Int        `json:"gasPrice,omitempty"`
+	GasTipCap *big.Int        `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap *big.Int        `json:"maxFeePerGas,omitempty"`
+	Value     *big.Int        `json:"value"`
+	Data      hexutil.Bytes   `json:"data"`
+	Abi       json.RawMessage `json:"abi,omitempty"`
+}
+
+// Transaction is a dynamic fee transaction, or a legacy one on networks
+// without a base fee.
+func (u *UnsignedTransaction) Transaction() *types.Transaction {
+	if u.GasFeeCap != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   u.ChainId,
+			Nonce:     u.Nonce,
+			GasTipCap: u.GasTipCap,
+			GasFeeCap: u.GasFeeCap,
+			Gas:       u.Gas,
+			To:        &u.To,
+			Value:     u.Value,
+			Data:      u.Data,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    u.Nonce,
+		GasPrice: u.GasPrice,
+		Gas:      u.Gas,
+		To:       &u.To,
+		Value:    u.Value,
+		Data:     u.Data,
+	})
+}
+
+// BuildUnsignedTransaction resolves the method like send does and fills in
+// the nonce, gas and fees from the node.
+func BuildUnsignedTransaction(from common.Address, target string, abiSource string, methodName string, args []string, value *big.Int, nonce *uint64) (*UnsignedTransaction, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	bound, err := bindMethod(client, target, abiSource, methodName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := bound.Calldata()
+	if err != nil {
+		return nil, err
+	}
+
+	fragment, err := abiFragment(bound.AbiSource, &bound.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	unsigned := &UnsignedTransaction{
+		ChainId: chainId,
+		From:    from,
+		To:      bound.Address,
+		Value:   value,
+		Data:    calldata,
+		Abi:     fragment,
+	}
+
+	if nonce != nil {
+		unsigned.Nonce = *nonce
+	} else {
+		unsigned.Nonce, err = client.PendingNonceAt(context.Background(), from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pending nonce: %w", err)
+		}
+	}
+
+	unsigned.Gas, err = client.EstimateGas(context.Background(), ethereum.CallMsg{From: from, To: &bound.Address, Value: value, Data: calldata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		unsigned.GasPrice, err = client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch gas price: %w", err)
+		}
+		return unsigned, nil
+	}
+
+	// the same fee caps bind uses, leaving room for the base fee to double
+	unsigned.GasTipCap, err = client.SuggestGasTipCap(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gas tip: %w", err)
+	}
+	unsigned.GasFeeCap = new(big.Int).Add(unsigned.GasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+
+	return unsigned, nil
+}
+
+// SignUnsignedTransaction signs without touching the network, refusing
+// transactions for another chain or another account, and calldata the local
+// abi of the target does not read as the transaction's abi does.
+func SignUnsignedTransaction(unsigned *UnsignedTransaction, chainId *big.Int, signer Signer, abiSource string) (*types.Transaction, error) {
+	if chainId.Sign() <= 0 {
+		return nil, errors.New("a chain id is required to sign")
+	}
+	if unsigned.ChainId == nil || unsigned.ChainId.Cmp(chainId) != 0 {
+		return nil, fmt.Errorf("transaction is for chain %v, not chain %v", unsigned.ChainId, chainId)
+	}
+	if signer.Address() != unsigned.From {
+		return nil, fmt.Errorf("transaction is from %v but the signer is %v", unsigned.From, signer.Address())
+	}
+	if _, _, err := CheckUnsignedCalldata(unsigned, abiSource); err != nil {
+		return nil, err
+	}
+
+	signed, err := signer.SignTx(unsigned.Transaction(), chainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signed, nil
+}
+
+// CheckUnsignedCalldata decodes the calldata with the local abi of the
+// target, found in the registry by address unless abiSource is given. The
+// abi carried by the transaction is only compared with it, since whoever
+// built the transaction chose it.
+func CheckUnsignedCalldata(unsigned *UnsignedTransaction, abiSource string) (*abi.Method, []interface{}, error) {
+	if len(unsigned.Data) == 0 {
+		return nil, nil, nil
+	}
+
+	if abiSource == "" {
+		registry, err := LoadRegistry()
+		if err != nil {
+			return nil, nil, err
+		}
+		name, ok := registry.Name(unsigned.ChainId, unsigned.To)
+		if !ok {
+			return nil, nil, fmt.Errorf("%v is not in the registry for chain %v, pass its abi with --abi", unsigned.To, unsigned.ChainId)
+		}
+		abiSource = name
+	}
+
+	localAbi, err := loadAbi(abiSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	method, values, err := decodeMethodCall(localAbi, unsigned.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("calldata does not match the %s abi: %w", abiSource, err)
+	}
+
+	if len(unsigned.Abi) > 0 {
+		carried, _, err := decodeCalldata(unsigned.Abi, unsigned.Data)
+		if err != nil || carried.String() != method.String() {
+			return nil, nil, fmt.Errorf("the abi in the transaction does not match the %s abi, which reads the calldata as %s", abiSource, method.Sig)
+		}
+	}
+	return method, values, nil
+}
+
+// BroadcastSignedTransaction submits a signed transaction after checking
+// it is for the connected chain, and waits for it to be mined.
+func BroadcastSignedTransaction(raw string) (*types.Receipt, *abi.ABI, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoded, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signed transaction: %w", err)
+	}
+
+	transaction := &types.Transaction{}
+	if err := transaction.UnmarshalBinary(encoded); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+	if transaction.ChainId().Cmp(chainId) != 0 {
+		return nil, nil, fmt.Errorf("transaction is signed for chain %v but the node is on chain %v", transaction.ChainId(), chainId)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainId), transaction)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+	log.Println("broadcasting transaction ", transaction.Hash(), " from ", sender, "...")
+
+	if err := client.SendTransaction(context.Background(), transaction); err != nil {
+		return nil, nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	receipt, err := bind.WaitMined(context.Background(), client, transaction)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error occured while waiting for transaction: %w", err)
+	}
+
+	// decode the events when the target is a contract we deployed
+	var contractAbi *abi.ABI
+	if transaction.To() != nil {
+		if _, name, err := resolveContract(client, transaction.To().Hex()); err == nil && name != "" {
+			contractAbi, _ = loadAbi(name)
+		}
+	}
+
+	return receipt, contractAbi, nil
+}
+
+// abiFragment extracts the abi entry of a single method, so an unsigned
+// transaction can be decoded where no build artifacts are available.
+func abiFragment(source string, method *abi.Method) (json.RawMessage, error) {
+	content, err := readAbi(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse abi %s: %w", source, err)
+	}
+
+	for _, entry := range entries {
+		fragment := json.RawMessage("[" + string(entry) + "]")
+		parsed, err := abi.JSON(strings.NewReader(string(fragment)))
+		if err != nil {
+			continue
+		}
+		for _, candidate := range parsed.Methods {
+			if candidate.Sig == method.Sig {
+				return fragment, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no entry for %s in abi %s", method.Sig, source)
+}
+
+// logUnsignedTransaction prints what is about to be signed, with the method
+// the calldata was decoded as.
+func logUnsignedTransaction(unsigned *UnsignedTransaction, method *abi.Method, values []interface{}, decodeErr error) {
+	transaction := unsigned.Transaction()
+
+	log.Println("chain id: ", unsigned.ChainId)
+	log.Println("from: ", unsigned.From)
+	log.Println("to: ", unsigned.To)
+	log.Println("nonce: ", unsigned.Nonce)
+	log.Println("value: ", FormatUnits(transaction.Value(), EtherDecimals), " ether")
+	log.Println("gas limit: ", unsigned.Gas)
+	if unsigned.GasFeeCap != nil {
+		log.Println("max fee: ", FormatUnits(unsigned.GasFeeCap, 9), " gwei, priority fee: ", FormatUnits(unsigned.GasTipCap, 9), " gwei")
+	} else {
+		log.Println("gas price: ", FormatUnits(unsigned.GasPrice, 9), " gwei")
+	}
+	log.Println("max cost: ", FormatUnits(transaction.Cost(), EtherDecimals), " ether")
+
+	if method == nil {
+		if decodeErr != nil {
+			log.Println("calldata could not be decoded (", decodeErr, "): ", hexutil.Encode(unsigned.Data))
+		}
+		return
+	}
+	log.Println("method: ", method.Sig)
+	logAbiValues(method.Inputs, values)
+}
+
+func decodeCalldata(fragment json.RawMessage, data []byte) (*abi.Method, []interface{}, error) {
+	if len(fragment) == 0 {
+		return nil, nil, errors.New("no abi included")
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(fragment)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid abi: %w", err)
+	}
+	return decodeMethodCall(&parsed, data)
+}
+
+func decodeMethodCall(contractAbi *abi.ABI, data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("no method selector")
+	}
+
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return method, values, nil
+}
+
+// writeOutput writes to the file, or to stdout when none is given so the
+// output can be piped.
+func writeOutput(file string, content string) error {
+	if file == "" {
+		fmt.Println(content)
+		return nil
+	}
+	if err := os.WriteFile(file, []byte(content+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	log.Println("written to ", file)
+	return nil
+}
+
+func confirm(question string) bool {
+	fmt.Fprint(os.Stderr, question, " [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
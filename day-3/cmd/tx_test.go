@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// txTestLottery is a lottery managed by account 0 and recorded in the
+// registry, so transactions to it can be built by name.
+func txTestLottery(t *testing.T) (*testChain, common.Address) {
+	t.Helper()
+	chain := newTestChain(t, 2)
+	address, _ := chain.deployLottery(0)
+	chain.serve()
+	inModuleRoot(t)
+	t.Setenv("DEPLOYMENTS_FILE", filepath.Join(t.TempDir(), "deployments.json"))
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.Record(testChainId, "Lottery", Deployment{Address: address})
+	if err := registry.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return chain, address
+}
+
+func TestTxBuildSignBroadcastRoundTrip(t *testing.T) {
+	chain, address := txTestLottery(t)
+	stake := mustParseEther(t, "0.5")
+
+	unsigned, err := BuildUnsignedTransaction(chain.accounts[1], "Lottery", "", "enter", nil, stake, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsigned.To != address || unsigned.From != chain.accounts[1] || unsigned.ChainId.Cmp(testChainId) != 0 || unsigned.Gas == 0 {
+		t.Fatalf("unsigned transaction is %+v", unsigned)
+	}
+
+	method, _, err := CheckUnsignedCalldata(unsigned, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method.Name != "enter" {
+		t.Fatalf("calldata reads as %s", method.Sig)
+	}
+
+	// only the account the transaction is from may sign it, for its chain
+	if _, err := SignUnsignedTransaction(unsigned, testChainId, &keySigner{key: chain.keys[0]}, ""); err == nil {
+		t.Fatal("signed as another account")
+	}
+	if _, err := SignUnsignedTransaction(unsigned, common.Big1, &keySigner{key: chain.keys[1]}, ""); err == nil {
+		t.Fatal("signed for another chain")
+	}
+	signed, err := SignUnsignedTransaction(unsigned, testChainId, &keySigner{key: chain.keys[1]}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, contractAbi, err := BroadcastSignedTransaction(hexutil.Encode(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.TxHash != signed.Hash() {
+		t.Fatalf("receipt is %+v", receipt)
+	}
+	if contractAbi == nil || len(receipt.Logs) != 1 {
+		t.Fatalf("entry logged %d events, decoded with %v", len(receipt.Logs), contractAbi)
+	}
+	if event, err := contractAbi.EventByID(receipt.Logs[0].Topics[0]); err != nil || event.Name != "PlayerEntered" {
+		t.Fatalf("entry logged %v: %v", event, err)
+	}
+
+	useLottery(t, address)
+	players, err := GetLotteryPlayers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 1 || players[0] != chain.accounts[1] {
+		t.Fatalf("players are %v", players)
+	}
+
+	// broadcasting the same transaction again is refused by the node
+	if _, _, err := BroadcastSignedTransaction(hexutil.Encode(raw)); err == nil {
+		t.Fatal("a mined transaction was broadcast again")
+	}
+}
+
+func TestTxSignRefusesATamperedAbi(t *testing.T) {
+	chain, address := txTestLottery(t)
+	signer := &keySigner{key: chain.keys[0]}
+
+	build := func() *UnsignedTransaction {
+		t.Helper()
+		unsigned, err := BuildUnsignedTransaction(chain.accounts[0], address.Hex(), "", "transferManager", []string{chain.accounts[1].Hex()}, common.Big0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return unsigned
+	}
+
+	// the abi names the argument as something else than the local abi does,
+	// with the same selector
+	renamed := build()
+	renamed.Abi = []byte(strings.Replace(string(renamed.Abi), "_pendingManager", "_refundAddress", 1))
+	if _, err := SignUnsignedTransaction(renamed, testChainId, signer, ""); err == nil {
+		t.Fatal("signed with a renamed argument in the abi")
+	}
+
+	// the calldata calls another method than the abi describes
+	swapped := build()
+	swapped.Data = crypto.Keccak256([]byte("pickWinner()"))[:4]
+	if _, err := SignUnsignedTransaction(swapped, testChainId, signer, ""); err == nil {
+		t.Fatal("signed calldata the abi does not describe")
+	}
+
+	// a target missing from the registry needs its abi given
+	unknown := build()
+	unknown.To = chain.accounts[1]
+	if _, err := SignUnsignedTransaction(unknown, testChainId, signer, ""); err == nil {
+		t.Fatal("signed a call to an unknown target")
+	}
+	if _, err := SignUnsignedTransaction(unknown, testChainId, signer, "Lottery"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SignUnsignedTransaction(build(), testChainId, signer, ""); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EtherDecimals is the number of decimals of ether and of FredCoin.
+const EtherDecimals = 18
+
+// ParseUnits converts a decimal amount such as "1.5" into its integer value
+// in the smallest unit, e.g. wei for 18 decimals.
+func ParseUnits(amount string, decimals int) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %s has more than %d decimals", amount, decimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	value, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	return value, nil
+}
+
+// FormatUnits is the inverse of ParseUnits, trimming trailing zeros.
+func FormatUnits(value *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(value).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	formatted := whole
+	if fraction != "" {
+		formatted += "." + fraction
+	}
+	if value.Sign() < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
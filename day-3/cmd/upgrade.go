@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"day-3/lottery"
+	"day-3/proxy"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func deployProxyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use: "deploy-proxy",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("deploying upgradeable lottery...")
+			deployment, err := DeployLotteryProxy()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery implementation deployed to address: ", deployment.Implementation)
+			log.Println("lottery proxy deployed to address: ", deployment.Address)
+		},
+	}
+}
+
+func upgradeCommand() *cobra.Command {
+	var check bool
+
+	command := &cobra.Command{
+		Use: "upgrade",
+		Run: func(cmd *cobra.Command, args []string) {
+			previous, err := getProxyDeployment()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			next, err := getStorageLayout("Lottery")
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if problems := CheckStorageLayoutUpgrade(previous.StorageLayout, next); len(problems) > 0 {
+				for _, problem := range problems {
+					log.Println("incompatible storage layout: ", problem)
+				}
+				log.Fatal("upgrade blocked, the new Lottery would corrupt the proxy's storage")
+			}
+			log.Println("storage layout is compatible with implementation ", previous.Implementation)
+			if check {
+				return
+			}
+
+			log.Println("upgrading lottery proxy ", previous.Address, "...")
+			deployment, err := UpgradeLottery(previous, next)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Println("lottery proxy now points at implementation: ", deployment.Implementation)
+		},
+	}
+	command.Flags().BoolVar(&check, "check", false, "only check the storage layout, without upgrading")
+	return command
+}
+
+// DeployLotteryProxy deploys the built Lottery as an implementation and an
+// ERC-1967 proxy in front of it, initialised with the account as manager.
+func DeployLotteryProxy() (*Deployment, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := getStorageLayout("Lottery")
+	if err != nil {
+		return nil, err
+	}
+
+	implementation, err := deployLotteryImplementation(client)
+	if err != nil {
+		return nil, err
+	}
+
+	lotteryAbi, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lottery abi: %w", err)
+	}
+
+	initialize, err := lotteryAbi.Pack("initialize", getAccountAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack initialize call: %w", err)
+	}
+
+	bytecode, err := getContractBinary("LotteryProxy")
+	if err != nil {
+		return nil, err
+	}
+
+	proxyAbi, err := abi.JSON(strings.NewReader(proxy.LotteryProxyABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy abi: %w", err)
+	}
+
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	_, transaction, _, err := bind.DeployContract(transactionOptions, proxyAbi, bytecode, client, implementation, initialize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy proxy: %w", err)
+	}
+
+	address, err := bind.WaitDeployed(context.Background(), client, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error occured while waiting for proxy to deploy: %w", err)
+	}
+
+	deployment := &Deployment{
+		Address:         address,
+		TransactionHash: transaction.Hash(),
+		Implementation:  &implementation,
+		StorageLayout:   layout,
+	}
+	if err := recordDeployment(client, "Lottery", *deployment); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// UpgradeLottery deploys the built Lottery and points the proxy at it. The
+// caller is expected to have checked the storage layouts first.
+func UpgradeLottery(previous *Deployment, layout *StorageLayout) (*Deployment, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	implementation, err := deployLotteryImplementation(client)
+	if err != nil {
+		return nil, err
+	}
+
+	proxied, err := lottery.NewLottery(previous.Address, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind lottery proxy: %w", err)
+	}
+
+	transactionOptions, err := newTransactionOptions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := proxied.UpgradeTo(transactionOptions, implementation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade lottery: %w", err)
+	}
+
+	receipt, err := bind.WaitMined(context.Background(), client, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error occured while waiting for upgrade: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("upgrade %v reverted", transaction.Hash())
+	}
+
+	deployment := *previous
+	deployment.Implementation = &implementation
+	deployment.StorageLayout = layout
+	if err := recordDeployment(client, "Lottery", deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+func deployLotteryImplementation(client *ethclient.Client) (common.Address, error) {
+	bytecode, err := getContractBinary("Lottery")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(lottery.LotteryABI))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to parse lottery abi: %w", err)
+	}
+
+	transactionOptions, err := GetTransactionOptions(client)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	_, transaction, _, err := bind.DeployContract(transactionOptions, parsed, bytecode, client, getAccountAddress())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy lottery implementation: %w", err)
+	}
+
+	address, err := bind.WaitDeployed(context.Background(), client, transaction)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error occured while waiting for lottery implementation to deploy: %w", err)
+	}
+
+	return address, nil
+}
+
+// getProxyDeployment returns the registry entry of the lottery proxy on the
+// connected network.
+func getProxyDeployment() (*Deployment, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	registry, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, ok := registry.Lookup(chainId, "Lottery")
+	if !ok || deployment.Implementation == nil || deployment.StorageLayout == nil {
+		return nil, fmt.Errorf("no upgradeable lottery recorded on chain %v, run deploy-proxy first", chainId)
+	}
+	return &deployment, nil
+}
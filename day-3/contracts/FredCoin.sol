@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.9;
+
+contract FredCoin {
+    string public constant name = "FredCoin";
+    string public constant symbol = "FRED";
+    uint8 public constant decimals = 18;
+
+    address public owner;
+    uint public immutable cap;
+    uint public totalSupply;
+    mapping(address => uint) public balanceOf;
+    mapping(address => mapping(address => uint)) public allowance;
+
+    bytes32 public constant PERMIT_TYPEHASH =
+        keccak256("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)");
+    mapping(address => uint) public nonces;
+
+    event Transfer(address indexed from, address indexed to, uint value);
+    event Approval(address indexed owner, address indexed spender, uint value);
+
+    constructor(uint _cap) {
+        require(_cap > 0);
+        owner = msg.sender;
+        cap = _cap;
+    }
+
+    function transfer(address to, uint value) public returns (bool) {
+        _transfer(msg.sender, to, value);
+        return true;
+    }
+
+    function approve(address spender, uint value) public returns (bool) {
+        _approve(msg.sender, spender, value);
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint value) public returns (bool) {
+        uint allowed = allowance[from][msg.sender];
+        if (allowed != type(uint).max) {
+            require(allowed >= value, "FredCoin: insufficient allowance");
+            _approve(from, msg.sender, allowed - value);
+        }
+        _transfer(from, to, value);
+        return true;
+    }
+
+    // EIP-2612: approve with an off-chain signature so that spending and
+    // approving can happen in one transaction
+    function permit(address holder, address spender, uint value, uint deadline, uint8 v, bytes32 r, bytes32 s) public {
+        require(block.timestamp <= deadline, "FredCoin: permit expired");
+        bytes32 structHash = keccak256(abi.encode(PERMIT_TYPEHASH, holder, spender, value, nonces[holder]++, deadline));
+        bytes32 digest = keccak256(abi.encodePacked("\x19\x01", DOMAIN_SEPARATOR(), structHash));
+        address signer = ecrecover(digest, v, r, s);
+        require(signer != address(0) && signer == holder, "FredCoin: invalid permit signature");
+        _approve(holder, spender, value);
+    }
+
+    // computed on every call so that signatures cannot be replayed on a fork
+    function DOMAIN_SEPARATOR() public view returns (bytes32) {
+        return keccak256(abi.encode(
+            keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"),
+            keccak256(bytes(name)),
+            keccak256(bytes("1")),
+            block.chainid,
+            address(this)
+        ));
+    }
+
+    function mint(address to, uint value) public restricted {
+        require(to != address(0), "FredCoin: mint to the zero address");
+        require(totalSupply + value <= cap, "FredCoin: cap exceeded");
+        totalSupply += value;
+        balanceOf[to] += value;
+        emit Transfer(address(0), to, value);
+    }
+
+    function burn(uint value) public {
+        require(balanceOf[msg.sender] >= value, "FredCoin: burn amount exceeds balance");
+        balanceOf[msg.sender] -= value;
+        totalSupply -= value;
+        emit Transfer(msg.sender, address(0), value);
+    }
+
+    function _transfer(address from, address to, uint value) private {
+        require(to != address(0), "FredCoin: transfer to the zero address");
+        require(balanceOf[from] >= value, "FredCoin: transfer amount exceeds balance");
+        balanceOf[from] -= value;
+        balanceOf[to] += value;
+        emit Transfer(from, to, value);
+    }
+
+    function _approve(address holder, address spender, uint value) private {
+        require(spender != address(0), "FredCoin: approve to the zero address");
+        allowance[holder][spender] = value;
+        emit Approval(holder, spender, value);
+    }
+
+    modifier restricted() {
+        require(msg.sender == owner);
+        _;
+    }
+}
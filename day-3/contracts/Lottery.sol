@@ -2,27 +2,309 @@
 
 pragma solidity ^0.8.9;
 
+interface IERC20Permit {
+    function transfer(address to, uint value) external returns (bool);
+    function transferFrom(address from, address to, uint value) external returns (bool);
+    function permit(address owner, address spender, uint value, uint deadline, uint8 v, bytes32 r, bytes32 s) external;
+}
+
 contract Lottery {
     address public manager;
+    address public pendingManager;
     address payable[] public players;
+    uint[] private stakes;
+    uint public pot;
+    bool public paused;
+    uint public roundDuration;
+    uint public roundStartedAt;
+    mapping(address => uint) public pendingWithdrawals;
+
+    // when set, tickets cost exactly ticketPrice of this token instead of ether
+    IERC20Permit public paymentToken;
+    uint public ticketPrice;
+    mapping(address => mapping(address => uint)) public pendingTokenWithdrawals;
+    mapping(address => uint) public accruedTokenFees;
+
+    uint public constant MAX_FEE_BASIS_POINTS = 10000;
+    uint public feeBasisPoints;
+    address payable public treasury;
+    uint public accruedFees;
+
+    bytes32 public constant OPERATOR_ROLE = keccak256("OPERATOR_ROLE");
+    bytes32 public constant ADMIN_ROLE = keccak256("ADMIN_ROLE");
+    mapping(bytes32 => mapping(address => bool)) public hasRole;
+    mapping(bytes32 => address[]) private roleMembers;
+
+    // ERC-1967 implementation slot, bytes32(uint(keccak256("eip1967.proxy.implementation")) - 1)
+    bytes32 private constant IMPLEMENTATION_SLOT = 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc;
+    address private immutable self = address(this);
+
+    event PlayerEntered(address indexed player, uint stake);
+    event WinnerPicked(address indexed winner, uint amount);
+    event Withdrawal(address indexed payee, uint amount);
+    event FeeChanged(uint feeBasisPoints, address treasury);
+    event FeesWithdrawn(address indexed treasury, uint amount);
+    event ManagerTransferProposed(address indexed manager, address indexed pendingManager);
+    event ManagerTransferred(address indexed previousManager, address indexed manager);
+    event RoleGranted(bytes32 indexed role, address indexed account);
+    event RoleRevoked(bytes32 indexed role, address indexed account);
+    event Paused(address account);
+    event Unpaused(address account);
+    event RoundCancelled(uint refunded);
+    event RoundDurationChanged(uint roundDuration);
+    event PaymentTokenChanged(address indexed token, uint ticketPrice);
+    event TokenWithdrawal(address indexed token, address indexed payee, uint amount);
+    event Upgraded(address indexed implementation);
+
+    // the manager is passed in rather than taken from msg.sender so that a
+    // CREATE2 deployer contract does not end up managing the lottery
+    constructor(address _manager) {
+        setup(_manager);
+    }
 
-    constructor() {
-        manager = msg.sender;
+    // clones created by LotteryFactory share this contract's code but not
+    // its storage, so they are set up here instead of by the constructor
+    function initialize(address _manager) public {
+        require(manager == address(0));
+        setup(_manager);
     }
 
-    function enter() public payable {
+    function setup(address _manager) private {
+        require(_manager != address(0));
+        manager = _manager;
+        treasury = payable(_manager);
+        roundDuration = 7 days;
+    }
+
+    function enter() public payable whenNotPaused {
+        require(address(paymentToken) == address(0));
         require(msg.value > .01 ether);
+        addPlayer(msg.value);
+    }
+
+    function enterWithToken() public whenNotPaused {
+        require(address(paymentToken) != address(0));
+        require(paymentToken.transferFrom(msg.sender, address(this), ticketPrice));
+        addPlayer(ticketPrice);
+    }
+
+    // approves and enters in a single transaction using an EIP-2612 permit
+    function enterWithPermit(uint deadline, uint8 v, bytes32 r, bytes32 s) public {
+        require(address(paymentToken) != address(0));
+        paymentToken.permit(msg.sender, address(this), ticketPrice, deadline, v, r, s);
+        enterWithToken();
+    }
+
+    function addPlayer(uint stake) private {
+        if (players.length == 0) {
+            roundStartedAt = block.timestamp;
+        }
         players.push(payable(msg.sender));
+        stakes.push(stake);
+        pot += stake;
+        emit PlayerEntered(msg.sender, stake);
     }
 
     function random() private view returns (uint) {
         return uint(keccak256(abi.encodePacked(block.difficulty, block.timestamp, players)));
     }
 
-    function pickWinner() public restricted {
+    // winnings are credited rather than sent so that a winner which cannot
+    // receive ether (e.g. a contract wallet) does not brick the round
+    function pickWinner() public onlyRole(OPERATOR_ROLE) whenNotPaused {
+        require(players.length > 0);
         uint index = random() % players.length;
-        players[index].transfer(address(this).balance);
+        address winner = players[index];
+        uint fee = pot * feeBasisPoints / MAX_FEE_BASIS_POINTS;
+        uint amount = pot - fee;
+        if (address(paymentToken) == address(0)) {
+            accruedFees += fee;
+        } else {
+            accruedTokenFees[address(paymentToken)] += fee;
+        }
+        credit(winner, amount);
+        resetRound();
+        emit WinnerPicked(winner, amount);
+    }
+
+    function pause() public onlyRole(ADMIN_ROLE) {
+        paused = true;
+        emit Paused(msg.sender);
+    }
+
+    function unpause() public onlyRole(ADMIN_ROLE) {
+        paused = false;
+        emit Unpaused(msg.sender);
+    }
+
+    function cancelRound() public onlyRole(ADMIN_ROLE) {
+        refundRound();
+    }
+
+    // once the deadline has passed anyone may refund the round, so the
+    // manager cannot hold player funds by never picking a winner
+    function refundExpiredRound() public {
+        require(players.length > 0 && block.timestamp >= roundDeadline());
+        refundRound();
+    }
+
+    function roundDeadline() public view returns (uint) {
+        if (players.length == 0) {
+            return 0;
+        }
+        return roundStartedAt + roundDuration;
+    }
+
+    function setRoundDuration(uint _roundDuration) public onlyRole(ADMIN_ROLE) {
+        require(_roundDuration > 0);
+        roundDuration = _roundDuration;
+        emit RoundDurationChanged(_roundDuration);
+    }
+
+    // every player gets their ticket cost back through withdraw()
+    function refundRound() private {
+        uint refunded = pot;
+        for (uint i = 0; i < players.length; i++) {
+            credit(players[i], stakes[i]);
+        }
+        resetRound();
+        emit RoundCancelled(refunded);
+    }
+
+    // payouts are held in the currency the round was played in
+    function credit(address account, uint amount) private {
+        if (address(paymentToken) == address(0)) {
+            pendingWithdrawals[account] += amount;
+        } else {
+            pendingTokenWithdrawals[address(paymentToken)][account] += amount;
+        }
+    }
+
+    function resetRound() private {
+        pot = 0;
+        roundStartedAt = 0;
         players = new address payable[](0);
+        delete stakes;
+    }
+
+    function withdraw() public {
+        uint amount = pendingWithdrawals[msg.sender];
+        require(amount > 0);
+        pendingWithdrawals[msg.sender] = 0;
+        (bool sent, ) = payable(msg.sender).call{value: amount}("");
+        require(sent);
+        emit Withdrawal(msg.sender, amount);
+    }
+
+    function withdrawToken(address token) public {
+        uint amount = pendingTokenWithdrawals[token][msg.sender];
+        require(amount > 0);
+        pendingTokenWithdrawals[token][msg.sender] = 0;
+        require(IERC20Permit(token).transfer(msg.sender, amount));
+        emit TokenWithdrawal(token, msg.sender, amount);
+    }
+
+    // switching currency mid-round would mix stakes, so only between rounds;
+    // the zero address switches back to ether
+    function setPaymentToken(address token, uint _ticketPrice) public onlyRole(ADMIN_ROLE) {
+        require(players.length == 0);
+        require(token == address(0) || _ticketPrice > 0);
+        paymentToken = IERC20Permit(token);
+        ticketPrice = _ticketPrice;
+        emit PaymentTokenChanged(token, _ticketPrice);
+    }
+
+    function setFee(uint _feeBasisPoints, address payable _treasury) public onlyRole(ADMIN_ROLE) {
+        require(_feeBasisPoints <= MAX_FEE_BASIS_POINTS);
+        require(_treasury != address(0));
+        feeBasisPoints = _feeBasisPoints;
+        treasury = _treasury;
+        emit FeeChanged(_feeBasisPoints, _treasury);
+    }
+
+    function withdrawFees() public restricted {
+        uint amount = accruedFees;
+        require(amount > 0);
+        accruedFees = 0;
+        (bool sent, ) = treasury.call{value: amount}("");
+        require(sent);
+        emit FeesWithdrawn(treasury, amount);
+    }
+
+    function withdrawTokenFees(address token) public restricted {
+        uint amount = accruedTokenFees[token];
+        require(amount > 0);
+        accruedTokenFees[token] = 0;
+        require(IERC20Permit(token).transfer(treasury, amount));
+        emit FeesWithdrawn(treasury, amount);
+    }
+
+    // ownership moves in two steps so that a mistyped address cannot lock
+    // the lottery: the proposed manager has to accept from its own key
+    function transferManager(address _pendingManager) public restricted {
+        pendingManager = _pendingManager;
+        emit ManagerTransferProposed(manager, _pendingManager);
+    }
+
+    // a treasury still at its default, the manager, moves to the new
+    // manager; one set with setFee stays where it was sent
+    function acceptManager() public {
+        require(msg.sender == pendingManager);
+        emit ManagerTransferred(manager, pendingManager);
+        if (treasury == manager) {
+            treasury = payable(pendingManager);
+            emit FeeChanged(feeBasisPoints, pendingManager);
+        }
+        manager = pendingManager;
+        pendingManager = address(0);
+    }
+
+    // UUPS: the upgrade logic lives in the implementation, so only a proxy
+    // pointing at a Lottery can be upgraded, and only by its manager. A
+    // factory clone delegates here too but never reads the ERC-1967 slot,
+    // so an upgrade would do nothing; it is refused by the empty slot.
+    function upgradeTo(address newImplementation) public restricted {
+        require(address(this) != self);
+        bytes32 current;
+        assembly {
+            current := sload(IMPLEMENTATION_SLOT)
+        }
+        require(current != bytes32(0));
+        require(Lottery(newImplementation).proxiableUUID() == IMPLEMENTATION_SLOT);
+        assembly {
+            sstore(IMPLEMENTATION_SLOT, newImplementation)
+        }
+        emit Upgraded(newImplementation);
+    }
+
+    function proxiableUUID() public view returns (bytes32) {
+        require(address(this) == self);
+        return IMPLEMENTATION_SLOT;
+    }
+
+    function grantRole(bytes32 role, address account) public restricted {
+        require(!hasRole[role][account]);
+        hasRole[role][account] = true;
+        roleMembers[role].push(account);
+        emit RoleGranted(role, account);
+    }
+
+    function revokeRole(bytes32 role, address account) public restricted {
+        require(hasRole[role][account]);
+        hasRole[role][account] = false;
+        address[] storage members = roleMembers[role];
+        for (uint i = 0; i < members.length; i++) {
+            if (members[i] == account) {
+                members[i] = members[members.length - 1];
+                members.pop();
+                break;
+            }
+        }
+        emit RoleRevoked(role, account);
+    }
+
+    function getRoleMembers(bytes32 role) public view returns (address[] memory) {
+        return roleMembers[role];
     }
 
     modifier restricted() {
@@ -30,6 +312,17 @@ contract Lottery {
         _;
     }
 
+    // the manager implicitly holds every role
+    modifier onlyRole(bytes32 role) {
+        require(msg.sender == manager || hasRole[role][msg.sender]);
+        _;
+    }
+
+    modifier whenNotPaused() {
+        require(!paused);
+        _;
+    }
+
     function getPlayers() public view returns (address payable[] memory) {
         return players;
     }
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.9;
+
+interface ILottery {
+    function initialize(address manager) external;
+}
+
+contract LotteryFactory {
+    address public immutable implementation;
+    address[] public lotteries;
+    mapping(address => uint[]) private lotteriesByCreator;
+
+    event LotteryCreated(uint indexed id, address indexed creator, address lottery);
+
+    constructor(address _implementation) {
+        require(_implementation != address(0));
+        implementation = _implementation;
+    }
+
+    // every lottery is an EIP-1167 minimal proxy delegating to the
+    // implementation, managed by whoever created it
+    function createLottery() public returns (uint id, address lottery) {
+        lottery = clone(implementation);
+        ILottery(lottery).initialize(msg.sender);
+
+        id = lotteries.length;
+        lotteries.push(lottery);
+        lotteriesByCreator[msg.sender].push(id);
+        emit LotteryCreated(id, msg.sender, lottery);
+    }
+
+    function lotteryCount() public view returns (uint) {
+        return lotteries.length;
+    }
+
+    function getLotteriesByCreator(address creator) public view returns (uint[] memory) {
+        return lotteriesByCreator[creator];
+    }
+
+    function clone(address target) private returns (address instance) {
+        assembly {
+            let ptr := mload(0x40)
+            mstore(ptr, 0x3d602d80600a3d3981f3363d3d373d3d3d363d73000000000000000000000000)
+            mstore(add(ptr, 0x14), shl(0x60, target))
+            mstore(add(ptr, 0x28), 0x5af43d82803e903d91602b57fd5bf30000000000000000000000000000000000)
+            instance := create(0, ptr, 0x37)
+        }
+        require(instance != address(0));
+    }
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.9;
+
+// ERC-1967 proxy for an upgradeable Lottery. The proxy only forwards calls;
+// upgrades go through Lottery.upgradeTo so the manager controls them.
+contract LotteryProxy {
+    // bytes32(uint(keccak256("eip1967.proxy.implementation")) - 1)
+    bytes32 private constant IMPLEMENTATION_SLOT = 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc;
+
+    event Upgraded(address indexed implementation);
+
+    constructor(address implementation, bytes memory data) {
+        require(implementation.code.length > 0);
+        assembly {
+            sstore(IMPLEMENTATION_SLOT, implementation)
+        }
+        emit Upgraded(implementation);
+
+        if (data.length > 0) {
+            (bool success, ) = implementation.delegatecall(data);
+            require(success);
+        }
+    }
+
+    fallback() external payable {
+        delegate();
+    }
+
+    receive() external payable {
+        delegate();
+    }
+
+    function delegate() private {
+        assembly {
+            let implementation := sload(IMPLEMENTATION_SLOT)
+            calldatacopy(0, 0, calldatasize())
+            let result := delegatecall(gas(), implementation, 0, calldatasize(), 0, 0)
+            returndatacopy(0, 0, returndatasize())
+            switch result
+            case 0 {
+                revert(0, returndatasize())
+            }
+            default {
+                return(0, returndatasize())
+            }
+        }
+    }
+}
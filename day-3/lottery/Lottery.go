@@ -30,8 +30,8 @@ var (
 
 // LotteryMetaData contains all meta data concerning the Lottery contract.
 var LotteryMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"enter\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getPlayers\",\"outputs\":[{\"internalType\":\"addresspayable[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"manager\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pickWinner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"players\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
-	Bin: "0x608060405234801561001057600080fd5b50600080546001600160a01b0319163317905561044a806100326000396000f3fe60806040526004361061004a5760003560e01c8063481c6a751461004f5780635d495aea1461008c5780638b5b9ccc146100a3578063e97dcb62146100c5578063f71d96cb146100cd575b600080fd5b34801561005b57600080fd5b5060005461006f906001600160a01b031681565b6040516001600160a01b0390911681526020015b60405180910390f35b34801561009857600080fd5b506100a16100ed565b005b3480156100af57600080fd5b506100b8610194565b6040516100839190610326565b6100a16101f6565b3480156100d957600080fd5b5061006f6100e8366004610373565b61024c565b6000546001600160a01b0316331461010457600080fd5b600154600090610112610276565b61011c919061038c565b905060018181548110610131576101316103ae565b60009182526020822001546040516001600160a01b03909116914780156108fc02929091818181858888f19350505050158015610172573d6000803e3d6000fd5b506040805160008152602081019182905251610190916001916102ac565b5050565b606060018054806020026020016040519081016040528092919081815260200182805480156101ec57602002820191906000526020600020905b81546001600160a01b031681526001909101906020018083116101ce575b5050505050905090565b662386f26fc10000341161020957600080fd5b6001805480820182556000919091527fb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf60180546001600160a01b03191633179055565b6001818154811061025c57600080fd5b6000918252602090912001546001600160a01b0316905081565b60004442600160405160200161028e939291906103c4565b6040516020818303038152906040528051906020012060001c905090565b828054828255906000526020600020908101928215610301579160200282015b8281111561030157825182546001600160a01b0319166001600160a01b039091161782556020909201916001909101906102cc565b5061030d929150610311565b5090565b5b8082111561030d5760008155600101610312565b6020808252825182820181905260009190848201906040850190845b818110156103675783516001600160a01b031683529284019291840191600101610342565b50909695505050505050565b60006020828403121561038557600080fd5b5035919050565b6000826103a957634e487b7160e01b600052601260045260246000fd5b500690565b634e487b7160e01b600052603260045260246000fd5b838152600060208481840152604083018454856000528260002060005b828110156104065781546001600160a01b0316845292840192600191820191016103e1565b50919897505050505050505056fea26469706673582212200153ab5ff3efd087ddad275516be82690fcf385dde8a7eb5ad34a51bd456856864736f6c63430008110033",
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_manager\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"feeBasisPoints\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"treasury\",\"type\":\"address\"}],\"name\":\"FeeChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"treasury\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"FeesWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"manager\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"pendingManager\",\"type\":\"address\"}],\"name\":\"ManagerTransferProposed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousManager\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"manager\",\"type\":\"address\"}],\"name\":\"ManagerTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Paused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"ticketPrice\",\"type\":\"uint256\"}],\"name\":\"PaymentTokenChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"}],\"name\":\"PlayerEntered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"refunded\",\"type\":\"uint256\"}],\"name\":\"RoundCancelled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"roundDuration\",\"type\":\"uint256\"}],\"name\":\"RoundDurationChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"TokenWithdrawal\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Unpaused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"implementation\",\"type\":\"address\"}],\"name\":\"Upgraded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"WinnerPicked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Withdrawal\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_FEE_BASIS_POINTS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"OPERATOR_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"acceptManager\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"accruedFees\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"accruedTokenFees\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"cancelRound\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"enter\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"enterWithPermit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"enterWithToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"feeBasisPoints\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getPlayers\",\"outputs\":[{\"internalType\":\"addresspayable[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"name\":\"getRoleMembers\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_manager\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"manager\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paymentToken\",\"outputs\":[{\"internalType\":\"contractIERC20Permit\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pendingManager\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"pendingTokenWithdrawals\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"pendingWithdrawals\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pickWinner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"players\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pot\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proxiableUUID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"refundExpiredRound\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"roundDeadline\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"roundDuration\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"roundStartedAt\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_feeBasisPoints\",\"type\":\"uint256\"},{\"internalType\":\"addresspayable\",\"name\":\"_treasury\",\"type\":\"address\"}],\"name\":\"setFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_ticketPrice\",\"type\":\"uint256\"}],\"name\":\"setPaymentToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_roundDuration\",\"type\":\"uint256\"}],\"name\":\"setRoundDuration\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ticketPrice\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_pendingManager\",\"type\":\"address\"}],\"name\":\"transferManager\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"treasury\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newImplementation\",\"type\":\"address\"}],\"name\":\"upgradeTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdrawFees\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"withdrawToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"withdrawTokenFees\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x60a0604052306080523480156200001557600080fd5b506040516200208938038062002089833981016040819052620000389162000091565b62000043816200004a565b50620000c3565b6001600160a01b0381166200005e57600080fd5b600080546001600160a01b039092166001600160a01b03199283168117909155600e805490921617905562093a80600655565b600060208284031215620000a457600080fd5b81516001600160a01b0381168114620000bc57600080fd5b9392505050565b608051611fa3620000e660003960008181610a0a0152610ec40152611fa36000f3fe6080604052600436106102675760003560e01c806386a594d011610144578063ba0e930a116100b6578063f3f437031161007a578063f3f43703146106da578063f56cc66514610707578063f5b541a614610727578063f62722a01461075b578063f71d96cb1461077b578063f7cb789a1461079b57600080fd5b8063ba0e930a1461065d578063c4d66de81461067d578063d547741f1461069d578063def20daf146106bd578063e97dcb62146106d257600080fd5b8063a16e56fc11610108578063a16e56fc146105a4578063a3246ad3146105ba578063aecf9f8f146105da578063af8532e314610612578063b4f2e8b814610627578063b8606eef1461064757600080fd5b806386a594d0146104f257806389476069146105075780638b5b9ccc1461052757806391d1485414610549578063a00fff6f1461058457600080fd5b8063481c6a75116101dd5780635c975abb116101a15780635c975abb146104465780635d495aea1461047057806361d027b314610485578063682c2058146104a557806375b238fc146104bb5780638456cb59146104dd57600080fd5b8063481c6a75146103d057806348ff15b3146103f05780634ba2363a146104055780634befe2ca1461041b57806352d1902d1461043157600080fd5b80633013ce291161022f5780633013ce29146103195780633659cfe61461035157806336c92c3f146103715780633ccfd60b146103915780633f4ba83a146103a6578063476343ee146103bb57600080fd5b80631209b1f61461026c5780631f27e31514610295578063274d3181146102ac5780632f2ff15d146102d95780632f497036146102f9575b600080fd5b34801561027857600080fd5b50610282600a5481565b6040519081526020015b60405180910390f35b3480156102a157600080fd5b506102aa6107b1565b005b3480156102b857600080fd5b506102826102c7366004611c6f565b600c6020526000908152604090205481565b3480156102e557600080fd5b506102aa6102f4366004611c93565b61086b565b34801561030557600080fd5b506102aa610314366004611cc3565b61093a565b34801561032557600080fd5b50600954610339906001600160a01b031681565b6040516001600160a01b03909116815260200161028c565b34801561035d57600080fd5b506102aa61036c366004611c6f565b6109e9565b34801561037d57600080fd5b506102aa61038c366004611d06565b610b17565b34801561039d57600080fd5b506102aa610bab565b3480156103b257600080fd5b506102aa610c63565b3480156103c757600080fd5b506102aa610cef565b3480156103dc57600080fd5b50600054610339906001600160a01b031681565b3480156103fc57600080fd5b506102aa610db8565b34801561041157600080fd5b5061028260045481565b34801561042757600080fd5b5061028261271081565b34801561043d57600080fd5b50610282610eb7565b34801561045257600080fd5b506005546104609060ff1681565b604051901515815260200161028c565b34801561047c57600080fd5b506102aa610f01565b34801561049157600080fd5b50600e54610339906001600160a01b031681565b3480156104b157600080fd5b50610282600f5481565b3480156104c757600080fd5b50610282600080516020611f4e83398151915281565b3480156104e957600080fd5b506102aa6110ad565b3480156104fe57600080fd5b506102aa611135565b34801561051357600080fd5b506102aa610522366004611c6f565b61118b565b34801561053357600080fd5b5061053c611295565b60405161028c9190611d1f565b34801561055557600080fd5b50610460610564366004611c93565b601060209081526000928352604080842090915290825290205460ff1681565b34801561059057600080fd5b50600154610339906001600160a01b031681565b3480156105b057600080fd5b5061028260075481565b3480156105c657600080fd5b5061053c6105d5366004611d06565b6112f7565b3480156105e657600080fd5b506102826105f5366004611d6c565b600b60209081526000928352604080842090915290825290205481565b34801561061e57600080fd5b50610282611363565b34801561063357600080fd5b506102aa610642366004611c93565b61138a565b34801561065357600080fd5b50610282600d5481565b34801561066957600080fd5b506102aa610678366004611c6f565b611459565b34801561068957600080fd5b506102aa610698366004611c6f565b6114c1565b3480156106a957600080fd5b506102aa6106b8366004611c93565b6114e0565b3480156106c957600080fd5b506102aa61169e565b6102aa6116c7565b3480156106e657600080fd5b506102826106f5366004611c6f565b60086020526000908152604090205481565b34801561071357600080fd5b506102aa610722366004611d9a565b611709565b34801561073357600080fd5b506102827f97667070c54ef182b0f5858b034beac1b6f3089aa2d3188bb1e8929f4fa9b92981565b34801561076757600080fd5b506102aa610776366004611c6f565b6117db565b34801561078757600080fd5b50610339610796366004611d06565b6118e5565b3480156107a757600080fd5b5061028260065481565b60055460ff16156107c157600080fd5b6009546001600160a01b03166107d657600080fd5b600954600a546040516323b872dd60e01b815233600482015230602482015260448101919091526001600160a01b03909116906323b872dd906064016020604051808303816000875af1158015610831573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906108559190611dc6565b61085e57600080fd5b610869600a5461190f565b565b6000546001600160a01b0316331461088257600080fd5b60008281526010602090815260408083206001600160a01b038516845290915290205460ff16156108b257600080fd5b60008281526010602090815260408083206001600160a01b038516808552908352818420805460ff191660019081179091558685526011845282852080549182018155855292842090920180546001600160a01b0319168317905551909184917f2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f39190a35050565b6009546001600160a01b031661094f57600080fd5b600954600a5460405163d505accf60e01b815233600482015230602482015260448101919091526064810186905260ff8516608482015260a4810184905260c481018390526001600160a01b039091169063d505accf9060e401600060405180830381600087803b1580156109c357600080fd5b505af11580156109d7573d6000803e3d6000fd5b505050506109e36107b1565b50505050565b6000546001600160a01b03163314610a0057600080fd5b6001600160a01b037f0000000000000000000000000000000000000000000000000000000000000000163003610a3557600080fd5b600080516020611f2e8339815191525480610a4f57600080fd5b600080516020611f2e83398151915260001b826001600160a01b03166352d1902d6040518163ffffffff1660e01b8152600401602060405180830381865afa158015610a9f573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610ac39190611de8565b14610acd57600080fd5b600080516020611f2e8339815191528290556040516001600160a01b038316907fbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b90600090a25050565b600054600080516020611f4e833981519152906001600160a01b0316331480610b595750600081815260106020908152604080832033845290915290205460ff165b610b6257600080fd5b60008211610b6f57600080fd5b60068290556040518281527f3afd47d65854f1a62e50ca90aa44232236db754d573813a23de85d26208e0a9c9060200160405180910390a15050565b3360009081526008602052604090205480610bc557600080fd5b336000818152600860205260408082208290555190919083908381818185875af1925050503d8060008114610c16576040519150601f19603f3d011682016040523d82523d6000602084013e610c1b565b606091505b5050905080610c2957600080fd5b60405182815233907f7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65906020015b60405180910390a25050565b600054600080516020611f4e833981519152906001600160a01b0316331480610ca55750600081815260106020908152604080832033845290915290205460ff165b610cae57600080fd5b6005805460ff191690556040513381527f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa906020015b60405180910390a150565b6000546001600160a01b03163314610d0657600080fd5b600f5480610d1357600080fd5b6000600f819055600e546040516001600160a01b039091169083908381818185875af1925050503d8060008114610d66576040519150601f19603f3d011682016040523d82523d6000602084013e610d6b565b606091505b5050905080610d7957600080fd5b600e546040518381526001600160a01b03909116907fc0819c13be868895eb93e40eaceb96de976442fa1d404e5c55f14bb65a8c489a90602001610c57565b6001546001600160a01b03163314610dcf57600080fd5b600154600080546040516001600160a01b0393841693909116917f9cb45c728de594dab506a1f1a8554e24c8eeaf983618d5ec5dd7bc6f3c49feee91a3600054600e546001600160a01b03918216911603610e9057600154600e80546001600160a01b0319166001600160a01b039092169182179055600d546040517fb2d190129c7f8c6952e604fc184b8aa257a6a1bcfc8d2b934242892af9f61cdc92610e879282526001600160a01b0316602082015260400190565b60405180910390a15b60018054600080546001600160a01b03199081166001600160a01b03841617909155169055565b6000306001600160a01b037f00000000000000000000000000000000000000000000000000000000000000001614610eee57600080fd5b50600080516020611f2e83398151915290565b6000547f97667070c54ef182b0f5858b034beac1b6f3089aa2d3188bb1e8929f4fa9b929906001600160a01b0316331480610f555750600081815260106020908152604080832033845290915290205460ff165b610f5e57600080fd5b60055460ff1615610f6e57600080fd5b600254610f7a57600080fd5b600254600090610f886119e0565b610f929190611e17565b9050600060028281548110610fa957610fa9611e2b565b6000918252602082200154600d546004546001600160a01b03909216935061271091610fd59190611e57565b610fdf9190611e74565b9050600081600454610ff19190611e88565b6009549091506001600160a01b03166110215781600f60008282546110169190611e9b565b909155506110519050565b6009546001600160a01b03166000908152600c60205260408120805484929061104b908490611e9b565b90915550505b61105b8382611a16565b611063611a9d565b826001600160a01b03167f64791dbae5677392ba76761a5273633cec8f1d9d8cfe808da7bac6ef16a880be8260405161109e91815260200190565b60405180910390a25050505050565b600054600080516020611f4e833981519152906001600160a01b03163314806110ef5750600081815260106020908152604080832033845290915290205460ff165b6110f857600080fd5b6005805460ff191660011790556040513381527f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a25890602001610ce4565b600054600080516020611f4e833981519152906001600160a01b03163314806111775750600081815260106020908152604080832033845290915290205460ff165b61118057600080fd5b611188611ad3565b50565b6001600160a01b0381166000908152600b60209081526040808320338452909152902054806111b957600080fd5b6001600160a01b0382166000818152600b6020908152604080832033808552925280832092909255905163a9059cbb60e01b815260048101919091526024810183905263a9059cbb906044016020604051808303816000875af1158015611224573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906112489190611dc6565b61125157600080fd5b60405181815233906001600160a01b038416907f42856d0378dde02337bb59ae41747abc77ded8ebdbbc5cbdd1e53693b75549389060200160405180910390a35050565b606060028054806020026020016040519081016040528092919081815260200182805480156112ed57602002820191906000526020600020905b81546001600160a01b031681526001909101906020018083116112cf575b5050505050905090565b60008181526011602090815260409182902080548351818402810184019094528084526060939283018282801561135757602002820191906000526020600020905b81546001600160a01b03168152600190910190602001808311611339575b50505050509050919050565b60025460009081036113755750600090565b6006546007546113859190611e9b565b905090565b600054600080516020611f4e833981519152906001600160a01b03163314806113cc5750600081815260106020908152604080832033845290915290205460ff165b6113d557600080fd5b6127108311156113e457600080fd5b6001600160a01b0382166113f757600080fd5b600d839055600e80546001600160a01b0319166001600160a01b0384169081179091556040805185815260208101929092527fb2d190129c7f8c6952e604fc184b8aa257a6a1bcfc8d2b934242892af9f61cdc910160405180910390a1505050565b6000546001600160a01b0316331461147057600080fd5b600180546001600160a01b0319166001600160a01b0383811691821790925560008054604051929316917fce60cba642b041029ec24fb21b6d5844c022b65c4c0ddd7b90495c9b286aa9ad9190a350565b6000546001600160a01b0316156114d757600080fd5b61118881611b80565b6000546001600160a01b031633146114f757600080fd5b60008281526010602090815260408083206001600160a01b038516845290915290205460ff1661152657600080fd5b60008281526010602090815260408083206001600160a01b03851684528252808320805460ff1916905584835260119091528120905b815481101561166257826001600160a01b031682828154811061158157611581611e2b565b6000918252602090912001546001600160a01b03160361165057815482906115ab90600190611e88565b815481106115bb576115bb611e2b565b9060005260206000200160009054906101000a90046001600160a01b03168282815481106115eb576115eb611e2b565b9060005260206000200160006101000a8154816001600160a01b0302191690836001600160a01b031602179055508180548061162957611629611eae565b600082815260209020810160001990810180546001600160a01b0319169055019055611662565b8061165a81611ec4565b91505061155c565b506040516001600160a01b0383169084907f155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a5290600090a3505050565b600254158015906116b657506116b2611363565b4210155b6116bf57600080fd5b610869611ad3565b60055460ff16156116d757600080fd5b6009546001600160a01b0316156116ed57600080fd5b662386f26fc10000341161170057600080fd5b6108693461190f565b600054600080516020611f4e833981519152906001600160a01b031633148061174b5750600081815260106020908152604080832033845290915290205460ff165b61175457600080fd5b6002541561176157600080fd5b6001600160a01b03831615806117775750600082115b61178057600080fd5b600980546001600160a01b0319166001600160a01b038516908117909155600a8390556040518381527f6a9d944da705340e0428e58c1cce0ae39fa9d014d36003b32dd5fda731509c249060200160405180910390a2505050565b6000546001600160a01b031633146117f257600080fd5b6001600160a01b0381166000908152600c60205260409020548061181557600080fd5b6001600160a01b038281166000818152600c602052604080822091909155600e54905163a9059cbb60e01b815292166004830152602482018390529063a9059cbb906044016020604051808303816000875af1158015611879573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061189d9190611dc6565b6118a657600080fd5b600e546040518281526001600160a01b03909116907fc0819c13be868895eb93e40eaceb96de976442fa1d404e5c55f14bb65a8c489a90602001610c57565b600281815481106118f557600080fd5b6000918252602090912001546001600160a01b0316905081565b60025460000361191e57426007555b6002805460018181019092557f405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ace0180546001600160a01b0319163317905560038054918201815560009081527fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b909101829055600480548392906119a3908490611e9b565b909155505060405181815233907fc16481c9484122696a660b57df0bf6839645c6b620cb1704ac2437de13be92259060200160405180910390a250565b6000444260026040516020016119f893929190611edd565b6040516020818303038152906040528051906020012060001c905090565b6009546001600160a01b0316611a59576001600160a01b03821660009081526008602052604081208054839290611a4e908490611e9b565b90915550611a999050565b6009546001600160a01b039081166000908152600b6020908152604080832093861683529290529081208054839290611a93908490611e9b565b90915550505b5050565b6000600481905560078190556040805191825260208201908190529051611ac691600291611bc6565b5061086960036000611c2b565b60045460005b600254811015611b4757611b3560028281548110611af957611af9611e2b565b600091825260209091200154600380546001600160a01b039092169184908110611b2557611b25611e2b565b9060005260206000200154611a16565b80611b3f81611ec4565b915050611ad9565b50611b50611a9d565b6040518181527fbf7aeff89cf7a4c3d0145879e39aa6a19e8e64ed585090ef86bf31f4d2ae57bf90602001610ce4565b6001600160a01b038116611b9357600080fd5b600080546001600160a01b039092166001600160a01b03199283168117909155600e805490921617905562093a80600655565b828054828255906000526020600020908101928215611c1b579160200282015b82811115611c1b57825182546001600160a01b0319166001600160a01b03909116178255602090920191600190910190611be6565b50611c27929150611c45565b5090565b508054600082559060005260206000209081019061118891905b5b80821115611c275760008155600101611c46565b6001600160a01b038116811461118857600080fd5b600060208284031215611c8157600080fd5b8135611c8c81611c5a565b9392505050565b60008060408385031215611ca657600080fd5b823591506020830135611cb881611c5a565b809150509250929050565b60008060008060808587031215611cd957600080fd5b84359350602085013560ff81168114611cf157600080fd5b93969395505050506040820135916060013590565b600060208284031215611d1857600080fd5b5035919050565b6020808252825182820181905260009190848201906040850190845b81811015611d605783516001600160a01b031683529284019291840191600101611d3b565b50909695505050505050565b60008060408385031215611d7f57600080fd5b8235611d8a81611c5a565b91506020830135611cb881611c5a565b60008060408385031215611dad57600080fd5b8235611db881611c5a565b946020939093013593505050565b600060208284031215611dd857600080fd5b81518015158114611c8c57600080fd5b600060208284031215611dfa57600080fd5b5051919050565b634e487b7160e01b600052601260045260246000fd5b600082611e2657611e26611e01565b500690565b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052601160045260246000fd5b8082028115828204841417611e6e57611e6e611e41565b92915050565b600082611e8357611e83611e01565b500490565b81810381811115611e6e57611e6e611e41565b80820180821115611e6e57611e6e611e41565b634e487b7160e01b600052603160045260246000fd5b600060018201611ed657611ed6611e41565b5060010190565b838152600060208481840152604083018454856000528260002060005b82811015611f1f5781546001600160a01b031684529284019260019182019101611efa565b50919897505050505050505056fe360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbca49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c21775a2646970667358221220025c44f605df2c0e6d85deb37379bf5bfd137d011cdb8754be727538f9fd99c064736f6c63430008150033",
 }
 
 // LotteryABI is the input ABI used to generate the binding from.
@@ -43,7 +43,7 @@ var LotteryABI = LotteryMetaData.ABI
 var LotteryBin = LotteryMetaData.Bin
 
 // DeployLottery deploys a new Ethereum contract, binding an instance of Lottery to it.
-func DeployLottery(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Lottery, error) {
+func DeployLottery(auth *bind.TransactOpts, backend bind.ContractBackend, _manager common.Address) (common.Address, *types.Transaction, *Lottery, error) {
 	parsed, err := LotteryMetaData.GetAbi()
 	if err != nil {
 		return common.Address{}, nil, nil, err
@@ -52,7 +52,7 @@ func DeployLottery(auth *bind.TransactOpts, backend bind.ContractBackend) (commo
 		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
 	}
 
-	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(LotteryBin), backend)
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(LotteryBin), backend, _manager)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -201,6 +201,192 @@ func (_Lottery *LotteryTransactorRaw) Transact(opts *bind.TransactOpts, method s
 	return _Lottery.Contract.contract.Transact(opts, method, params...)
 }
 
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_Lottery *LotteryCaller) ADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "ADMIN_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_Lottery *LotterySession) ADMINROLE() ([32]byte, error) {
+	return _Lottery.Contract.ADMINROLE(&_Lottery.CallOpts)
+}
+
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_Lottery *LotteryCallerSession) ADMINROLE() ([32]byte, error) {
+	return _Lottery.Contract.ADMINROLE(&_Lottery.CallOpts)
+}
+
+// MAXFEEBASISPOINTS is a free data retrieval call binding the contract method 0x4befe2ca.
+//
+// Solidity: function MAX_FEE_BASIS_POINTS() view returns(uint256)
+func (_Lottery *LotteryCaller) MAXFEEBASISPOINTS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "MAX_FEE_BASIS_POINTS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXFEEBASISPOINTS is a free data retrieval call binding the contract method 0x4befe2ca.
+//
+// Solidity: function MAX_FEE_BASIS_POINTS() view returns(uint256)
+func (_Lottery *LotterySession) MAXFEEBASISPOINTS() (*big.Int, error) {
+	return _Lottery.Contract.MAXFEEBASISPOINTS(&_Lottery.CallOpts)
+}
+
+// MAXFEEBASISPOINTS is a free data retrieval call binding the contract method 0x4befe2ca.
+//
+// Solidity: function MAX_FEE_BASIS_POINTS() view returns(uint256)
+func (_Lottery *LotteryCallerSession) MAXFEEBASISPOINTS() (*big.Int, error) {
+	return _Lottery.Contract.MAXFEEBASISPOINTS(&_Lottery.CallOpts)
+}
+
+// OPERATORROLE is a free data retrieval call binding the contract method 0xf5b541a6.
+//
+// Solidity: function OPERATOR_ROLE() view returns(bytes32)
+func (_Lottery *LotteryCaller) OPERATORROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "OPERATOR_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// OPERATORROLE is a free data retrieval call binding the contract method 0xf5b541a6.
+//
+// Solidity: function OPERATOR_ROLE() view returns(bytes32)
+func (_Lottery *LotterySession) OPERATORROLE() ([32]byte, error) {
+	return _Lottery.Contract.OPERATORROLE(&_Lottery.CallOpts)
+}
+
+// OPERATORROLE is a free data retrieval call binding the contract method 0xf5b541a6.
+//
+// Solidity: function OPERATOR_ROLE() view returns(bytes32)
+func (_Lottery *LotteryCallerSession) OPERATORROLE() ([32]byte, error) {
+	return _Lottery.Contract.OPERATORROLE(&_Lottery.CallOpts)
+}
+
+// AccruedFees is a free data retrieval call binding the contract method 0x682c2058.
+//
+// Solidity: function accruedFees() view returns(uint256)
+func (_Lottery *LotteryCaller) AccruedFees(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "accruedFees")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AccruedFees is a free data retrieval call binding the contract method 0x682c2058.
+//
+// Solidity: function accruedFees() view returns(uint256)
+func (_Lottery *LotterySession) AccruedFees() (*big.Int, error) {
+	return _Lottery.Contract.AccruedFees(&_Lottery.CallOpts)
+}
+
+// AccruedFees is a free data retrieval call binding the contract method 0x682c2058.
+//
+// Solidity: function accruedFees() view returns(uint256)
+func (_Lottery *LotteryCallerSession) AccruedFees() (*big.Int, error) {
+	return _Lottery.Contract.AccruedFees(&_Lottery.CallOpts)
+}
+
+// AccruedTokenFees is a free data retrieval call binding the contract method 0x274d3181.
+//
+// Solidity: function accruedTokenFees(address ) view returns(uint256)
+func (_Lottery *LotteryCaller) AccruedTokenFees(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "accruedTokenFees", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AccruedTokenFees is a free data retrieval call binding the contract method 0x274d3181.
+//
+// Solidity: function accruedTokenFees(address ) view returns(uint256)
+func (_Lottery *LotterySession) AccruedTokenFees(arg0 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.AccruedTokenFees(&_Lottery.CallOpts, arg0)
+}
+
+// AccruedTokenFees is a free data retrieval call binding the contract method 0x274d3181.
+//
+// Solidity: function accruedTokenFees(address ) view returns(uint256)
+func (_Lottery *LotteryCallerSession) AccruedTokenFees(arg0 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.AccruedTokenFees(&_Lottery.CallOpts, arg0)
+}
+
+// FeeBasisPoints is a free data retrieval call binding the contract method 0xb8606eef.
+//
+// Solidity: function feeBasisPoints() view returns(uint256)
+func (_Lottery *LotteryCaller) FeeBasisPoints(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "feeBasisPoints")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FeeBasisPoints is a free data retrieval call binding the contract method 0xb8606eef.
+//
+// Solidity: function feeBasisPoints() view returns(uint256)
+func (_Lottery *LotterySession) FeeBasisPoints() (*big.Int, error) {
+	return _Lottery.Contract.FeeBasisPoints(&_Lottery.CallOpts)
+}
+
+// FeeBasisPoints is a free data retrieval call binding the contract method 0xb8606eef.
+//
+// Solidity: function feeBasisPoints() view returns(uint256)
+func (_Lottery *LotteryCallerSession) FeeBasisPoints() (*big.Int, error) {
+	return _Lottery.Contract.FeeBasisPoints(&_Lottery.CallOpts)
+}
+
 // GetPlayers is a free data retrieval call binding the contract method 0x8b5b9ccc.
 //
 // Solidity: function getPlayers() view returns(address[])
@@ -232,6 +418,68 @@ func (_Lottery *LotteryCallerSession) GetPlayers() ([]common.Address, error) {
 	return _Lottery.Contract.GetPlayers(&_Lottery.CallOpts)
 }
 
+// GetRoleMembers is a free data retrieval call binding the contract method 0xa3246ad3.
+//
+// Solidity: function getRoleMembers(bytes32 role) view returns(address[])
+func (_Lottery *LotteryCaller) GetRoleMembers(opts *bind.CallOpts, role [32]byte) ([]common.Address, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "getRoleMembers", role)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetRoleMembers is a free data retrieval call binding the contract method 0xa3246ad3.
+//
+// Solidity: function getRoleMembers(bytes32 role) view returns(address[])
+func (_Lottery *LotterySession) GetRoleMembers(role [32]byte) ([]common.Address, error) {
+	return _Lottery.Contract.GetRoleMembers(&_Lottery.CallOpts, role)
+}
+
+// GetRoleMembers is a free data retrieval call binding the contract method 0xa3246ad3.
+//
+// Solidity: function getRoleMembers(bytes32 role) view returns(address[])
+func (_Lottery *LotteryCallerSession) GetRoleMembers(role [32]byte) ([]common.Address, error) {
+	return _Lottery.Contract.GetRoleMembers(&_Lottery.CallOpts, role)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 , address ) view returns(bool)
+func (_Lottery *LotteryCaller) HasRole(opts *bind.CallOpts, arg0 [32]byte, arg1 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "hasRole", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 , address ) view returns(bool)
+func (_Lottery *LotterySession) HasRole(arg0 [32]byte, arg1 common.Address) (bool, error) {
+	return _Lottery.Contract.HasRole(&_Lottery.CallOpts, arg0, arg1)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 , address ) view returns(bool)
+func (_Lottery *LotteryCallerSession) HasRole(arg0 [32]byte, arg1 common.Address) (bool, error) {
+	return _Lottery.Contract.HasRole(&_Lottery.CallOpts, arg0, arg1)
+}
+
 // Manager is a free data retrieval call binding the contract method 0x481c6a75.
 //
 // Solidity: function manager() view returns(address)
@@ -263,6 +511,161 @@ func (_Lottery *LotteryCallerSession) Manager() (common.Address, error) {
 	return _Lottery.Contract.Manager(&_Lottery.CallOpts)
 }
 
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_Lottery *LotteryCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_Lottery *LotterySession) Paused() (bool, error) {
+	return _Lottery.Contract.Paused(&_Lottery.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_Lottery *LotteryCallerSession) Paused() (bool, error) {
+	return _Lottery.Contract.Paused(&_Lottery.CallOpts)
+}
+
+// PaymentToken is a free data retrieval call binding the contract method 0x3013ce29.
+//
+// Solidity: function paymentToken() view returns(address)
+func (_Lottery *LotteryCaller) PaymentToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "paymentToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PaymentToken is a free data retrieval call binding the contract method 0x3013ce29.
+//
+// Solidity: function paymentToken() view returns(address)
+func (_Lottery *LotterySession) PaymentToken() (common.Address, error) {
+	return _Lottery.Contract.PaymentToken(&_Lottery.CallOpts)
+}
+
+// PaymentToken is a free data retrieval call binding the contract method 0x3013ce29.
+//
+// Solidity: function paymentToken() view returns(address)
+func (_Lottery *LotteryCallerSession) PaymentToken() (common.Address, error) {
+	return _Lottery.Contract.PaymentToken(&_Lottery.CallOpts)
+}
+
+// PendingManager is a free data retrieval call binding the contract method 0xa00fff6f.
+//
+// Solidity: function pendingManager() view returns(address)
+func (_Lottery *LotteryCaller) PendingManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "pendingManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PendingManager is a free data retrieval call binding the contract method 0xa00fff6f.
+//
+// Solidity: function pendingManager() view returns(address)
+func (_Lottery *LotterySession) PendingManager() (common.Address, error) {
+	return _Lottery.Contract.PendingManager(&_Lottery.CallOpts)
+}
+
+// PendingManager is a free data retrieval call binding the contract method 0xa00fff6f.
+//
+// Solidity: function pendingManager() view returns(address)
+func (_Lottery *LotteryCallerSession) PendingManager() (common.Address, error) {
+	return _Lottery.Contract.PendingManager(&_Lottery.CallOpts)
+}
+
+// PendingTokenWithdrawals is a free data retrieval call binding the contract method 0xaecf9f8f.
+//
+// Solidity: function pendingTokenWithdrawals(address , address ) view returns(uint256)
+func (_Lottery *LotteryCaller) PendingTokenWithdrawals(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "pendingTokenWithdrawals", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PendingTokenWithdrawals is a free data retrieval call binding the contract method 0xaecf9f8f.
+//
+// Solidity: function pendingTokenWithdrawals(address , address ) view returns(uint256)
+func (_Lottery *LotterySession) PendingTokenWithdrawals(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.PendingTokenWithdrawals(&_Lottery.CallOpts, arg0, arg1)
+}
+
+// PendingTokenWithdrawals is a free data retrieval call binding the contract method 0xaecf9f8f.
+//
+// Solidity: function pendingTokenWithdrawals(address , address ) view returns(uint256)
+func (_Lottery *LotteryCallerSession) PendingTokenWithdrawals(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.PendingTokenWithdrawals(&_Lottery.CallOpts, arg0, arg1)
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0xf3f43703.
+//
+// Solidity: function pendingWithdrawals(address ) view returns(uint256)
+func (_Lottery *LotteryCaller) PendingWithdrawals(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "pendingWithdrawals", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0xf3f43703.
+//
+// Solidity: function pendingWithdrawals(address ) view returns(uint256)
+func (_Lottery *LotterySession) PendingWithdrawals(arg0 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.PendingWithdrawals(&_Lottery.CallOpts, arg0)
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0xf3f43703.
+//
+// Solidity: function pendingWithdrawals(address ) view returns(uint256)
+func (_Lottery *LotteryCallerSession) PendingWithdrawals(arg0 common.Address) (*big.Int, error) {
+	return _Lottery.Contract.PendingWithdrawals(&_Lottery.CallOpts, arg0)
+}
+
 // Players is a free data retrieval call binding the contract method 0xf71d96cb.
 //
 // Solidity: function players(uint256 ) view returns(address)
@@ -294,39 +697,403 @@ func (_Lottery *LotteryCallerSession) Players(arg0 *big.Int) (common.Address, er
 	return _Lottery.Contract.Players(&_Lottery.CallOpts, arg0)
 }
 
-// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
+// Pot is a free data retrieval call binding the contract method 0x4ba2363a.
 //
-// Solidity: function enter() payable returns()
-func (_Lottery *LotteryTransactor) Enter(opts *bind.TransactOpts) (*types.Transaction, error) {
-	return _Lottery.contract.Transact(opts, "enter")
-}
+// Solidity: function pot() view returns(uint256)
+func (_Lottery *LotteryCaller) Pot(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "pot")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
 
-// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
-//
-// Solidity: function enter() payable returns()
-func (_Lottery *LotterySession) Enter() (*types.Transaction, error) {
-	return _Lottery.Contract.Enter(&_Lottery.TransactOpts)
 }
 
-// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
+// Pot is a free data retrieval call binding the contract method 0x4ba2363a.
 //
-// Solidity: function enter() payable returns()
-func (_Lottery *LotteryTransactorSession) Enter() (*types.Transaction, error) {
-	return _Lottery.Contract.Enter(&_Lottery.TransactOpts)
+// Solidity: function pot() view returns(uint256)
+func (_Lottery *LotterySession) Pot() (*big.Int, error) {
+	return _Lottery.Contract.Pot(&_Lottery.CallOpts)
 }
 
-// PickWinner is a paid mutator transaction binding the contract method 0x5d495aea.
+// Pot is a free data retrieval call binding the contract method 0x4ba2363a.
 //
-// Solidity: function pickWinner() returns()
-func (_Lottery *LotteryTransactor) PickWinner(opts *bind.TransactOpts) (*types.Transaction, error) {
-	return _Lottery.contract.Transact(opts, "pickWinner")
+// Solidity: function pot() view returns(uint256)
+func (_Lottery *LotteryCallerSession) Pot() (*big.Int, error) {
+	return _Lottery.Contract.Pot(&_Lottery.CallOpts)
 }
 
-// PickWinner is a paid mutator transaction binding the contract method 0x5d495aea.
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
 //
-// Solidity: function pickWinner() returns()
-func (_Lottery *LotterySession) PickWinner() (*types.Transaction, error) {
-	return _Lottery.Contract.PickWinner(&_Lottery.TransactOpts)
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_Lottery *LotteryCaller) ProxiableUUID(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "proxiableUUID")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
+//
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_Lottery *LotterySession) ProxiableUUID() ([32]byte, error) {
+	return _Lottery.Contract.ProxiableUUID(&_Lottery.CallOpts)
+}
+
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
+//
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_Lottery *LotteryCallerSession) ProxiableUUID() ([32]byte, error) {
+	return _Lottery.Contract.ProxiableUUID(&_Lottery.CallOpts)
+}
+
+// RoundDeadline is a free data retrieval call binding the contract method 0xaf8532e3.
+//
+// Solidity: function roundDeadline() view returns(uint256)
+func (_Lottery *LotteryCaller) RoundDeadline(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "roundDeadline")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RoundDeadline is a free data retrieval call binding the contract method 0xaf8532e3.
+//
+// Solidity: function roundDeadline() view returns(uint256)
+func (_Lottery *LotterySession) RoundDeadline() (*big.Int, error) {
+	return _Lottery.Contract.RoundDeadline(&_Lottery.CallOpts)
+}
+
+// RoundDeadline is a free data retrieval call binding the contract method 0xaf8532e3.
+//
+// Solidity: function roundDeadline() view returns(uint256)
+func (_Lottery *LotteryCallerSession) RoundDeadline() (*big.Int, error) {
+	return _Lottery.Contract.RoundDeadline(&_Lottery.CallOpts)
+}
+
+// RoundDuration is a free data retrieval call binding the contract method 0xf7cb789a.
+//
+// Solidity: function roundDuration() view returns(uint256)
+func (_Lottery *LotteryCaller) RoundDuration(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "roundDuration")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RoundDuration is a free data retrieval call binding the contract method 0xf7cb789a.
+//
+// Solidity: function roundDuration() view returns(uint256)
+func (_Lottery *LotterySession) RoundDuration() (*big.Int, error) {
+	return _Lottery.Contract.RoundDuration(&_Lottery.CallOpts)
+}
+
+// RoundDuration is a free data retrieval call binding the contract method 0xf7cb789a.
+//
+// Solidity: function roundDuration() view returns(uint256)
+func (_Lottery *LotteryCallerSession) RoundDuration() (*big.Int, error) {
+	return _Lottery.Contract.RoundDuration(&_Lottery.CallOpts)
+}
+
+// RoundStartedAt is a free data retrieval call binding the contract method 0xa16e56fc.
+//
+// Solidity: function roundStartedAt() view returns(uint256)
+func (_Lottery *LotteryCaller) RoundStartedAt(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "roundStartedAt")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RoundStartedAt is a free data retrieval call binding the contract method 0xa16e56fc.
+//
+// Solidity: function roundStartedAt() view returns(uint256)
+func (_Lottery *LotterySession) RoundStartedAt() (*big.Int, error) {
+	return _Lottery.Contract.RoundStartedAt(&_Lottery.CallOpts)
+}
+
+// RoundStartedAt is a free data retrieval call binding the contract method 0xa16e56fc.
+//
+// Solidity: function roundStartedAt() view returns(uint256)
+func (_Lottery *LotteryCallerSession) RoundStartedAt() (*big.Int, error) {
+	return _Lottery.Contract.RoundStartedAt(&_Lottery.CallOpts)
+}
+
+// TicketPrice is a free data retrieval call binding the contract method 0x1209b1f6.
+//
+// Solidity: function ticketPrice() view returns(uint256)
+func (_Lottery *LotteryCaller) TicketPrice(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "ticketPrice")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TicketPrice is a free data retrieval call binding the contract method 0x1209b1f6.
+//
+// Solidity: function ticketPrice() view returns(uint256)
+func (_Lottery *LotterySession) TicketPrice() (*big.Int, error) {
+	return _Lottery.Contract.TicketPrice(&_Lottery.CallOpts)
+}
+
+// TicketPrice is a free data retrieval call binding the contract method 0x1209b1f6.
+//
+// Solidity: function ticketPrice() view returns(uint256)
+func (_Lottery *LotteryCallerSession) TicketPrice() (*big.Int, error) {
+	return _Lottery.Contract.TicketPrice(&_Lottery.CallOpts)
+}
+
+// Treasury is a free data retrieval call binding the contract method 0x61d027b3.
+//
+// Solidity: function treasury() view returns(address)
+func (_Lottery *LotteryCaller) Treasury(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Lottery.contract.Call(opts, &out, "treasury")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Treasury is a free data retrieval call binding the contract method 0x61d027b3.
+//
+// Solidity: function treasury() view returns(address)
+func (_Lottery *LotterySession) Treasury() (common.Address, error) {
+	return _Lottery.Contract.Treasury(&_Lottery.CallOpts)
+}
+
+// Treasury is a free data retrieval call binding the contract method 0x61d027b3.
+//
+// Solidity: function treasury() view returns(address)
+func (_Lottery *LotteryCallerSession) Treasury() (common.Address, error) {
+	return _Lottery.Contract.Treasury(&_Lottery.CallOpts)
+}
+
+// AcceptManager is a paid mutator transaction binding the contract method 0x48ff15b3.
+//
+// Solidity: function acceptManager() returns()
+func (_Lottery *LotteryTransactor) AcceptManager(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "acceptManager")
+}
+
+// AcceptManager is a paid mutator transaction binding the contract method 0x48ff15b3.
+//
+// Solidity: function acceptManager() returns()
+func (_Lottery *LotterySession) AcceptManager() (*types.Transaction, error) {
+	return _Lottery.Contract.AcceptManager(&_Lottery.TransactOpts)
+}
+
+// AcceptManager is a paid mutator transaction binding the contract method 0x48ff15b3.
+//
+// Solidity: function acceptManager() returns()
+func (_Lottery *LotteryTransactorSession) AcceptManager() (*types.Transaction, error) {
+	return _Lottery.Contract.AcceptManager(&_Lottery.TransactOpts)
+}
+
+// CancelRound is a paid mutator transaction binding the contract method 0x86a594d0.
+//
+// Solidity: function cancelRound() returns()
+func (_Lottery *LotteryTransactor) CancelRound(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "cancelRound")
+}
+
+// CancelRound is a paid mutator transaction binding the contract method 0x86a594d0.
+//
+// Solidity: function cancelRound() returns()
+func (_Lottery *LotterySession) CancelRound() (*types.Transaction, error) {
+	return _Lottery.Contract.CancelRound(&_Lottery.TransactOpts)
+}
+
+// CancelRound is a paid mutator transaction binding the contract method 0x86a594d0.
+//
+// Solidity: function cancelRound() returns()
+func (_Lottery *LotteryTransactorSession) CancelRound() (*types.Transaction, error) {
+	return _Lottery.Contract.CancelRound(&_Lottery.TransactOpts)
+}
+
+// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
+//
+// Solidity: function enter() payable returns()
+func (_Lottery *LotteryTransactor) Enter(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "enter")
+}
+
+// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
+//
+// Solidity: function enter() payable returns()
+func (_Lottery *LotterySession) Enter() (*types.Transaction, error) {
+	return _Lottery.Contract.Enter(&_Lottery.TransactOpts)
+}
+
+// Enter is a paid mutator transaction binding the contract method 0xe97dcb62.
+//
+// Solidity: function enter() payable returns()
+func (_Lottery *LotteryTransactorSession) Enter() (*types.Transaction, error) {
+	return _Lottery.Contract.Enter(&_Lottery.TransactOpts)
+}
+
+// EnterWithPermit is a paid mutator transaction binding the contract method 0x2f497036.
+//
+// Solidity: function enterWithPermit(uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Lottery *LotteryTransactor) EnterWithPermit(opts *bind.TransactOpts, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "enterWithPermit", deadline, v, r, s)
+}
+
+// EnterWithPermit is a paid mutator transaction binding the contract method 0x2f497036.
+//
+// Solidity: function enterWithPermit(uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Lottery *LotterySession) EnterWithPermit(deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Lottery.Contract.EnterWithPermit(&_Lottery.TransactOpts, deadline, v, r, s)
+}
+
+// EnterWithPermit is a paid mutator transaction binding the contract method 0x2f497036.
+//
+// Solidity: function enterWithPermit(uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Lottery *LotteryTransactorSession) EnterWithPermit(deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Lottery.Contract.EnterWithPermit(&_Lottery.TransactOpts, deadline, v, r, s)
+}
+
+// EnterWithToken is a paid mutator transaction binding the contract method 0x1f27e315.
+//
+// Solidity: function enterWithToken() returns()
+func (_Lottery *LotteryTransactor) EnterWithToken(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "enterWithToken")
+}
+
+// EnterWithToken is a paid mutator transaction binding the contract method 0x1f27e315.
+//
+// Solidity: function enterWithToken() returns()
+func (_Lottery *LotterySession) EnterWithToken() (*types.Transaction, error) {
+	return _Lottery.Contract.EnterWithToken(&_Lottery.TransactOpts)
+}
+
+// EnterWithToken is a paid mutator transaction binding the contract method 0x1f27e315.
+//
+// Solidity: function enterWithToken() returns()
+func (_Lottery *LotteryTransactorSession) EnterWithToken() (*types.Transaction, error) {
+	return _Lottery.Contract.EnterWithToken(&_Lottery.TransactOpts)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_Lottery *LotteryTransactor) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "grantRole", role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_Lottery *LotterySession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.GrantRole(&_Lottery.TransactOpts, role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_Lottery *LotteryTransactorSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.GrantRole(&_Lottery.TransactOpts, role, account)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _manager) returns()
+func (_Lottery *LotteryTransactor) Initialize(opts *bind.TransactOpts, _manager common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "initialize", _manager)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _manager) returns()
+func (_Lottery *LotterySession) Initialize(_manager common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.Initialize(&_Lottery.TransactOpts, _manager)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _manager) returns()
+func (_Lottery *LotteryTransactorSession) Initialize(_manager common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.Initialize(&_Lottery.TransactOpts, _manager)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_Lottery *LotteryTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_Lottery *LotterySession) Pause() (*types.Transaction, error) {
+	return _Lottery.Contract.Pause(&_Lottery.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_Lottery *LotteryTransactorSession) Pause() (*types.Transaction, error) {
+	return _Lottery.Contract.Pause(&_Lottery.TransactOpts)
+}
+
+// PickWinner is a paid mutator transaction binding the contract method 0x5d495aea.
+//
+// Solidity: function pickWinner() returns()
+func (_Lottery *LotteryTransactor) PickWinner(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "pickWinner")
+}
+
+// PickWinner is a paid mutator transaction binding the contract method 0x5d495aea.
+//
+// Solidity: function pickWinner() returns()
+func (_Lottery *LotterySession) PickWinner() (*types.Transaction, error) {
+	return _Lottery.Contract.PickWinner(&_Lottery.TransactOpts)
 }
 
 // PickWinner is a paid mutator transaction binding the contract method 0x5d495aea.
@@ -335,3 +1102,2561 @@ func (_Lottery *LotterySession) PickWinner() (*types.Transaction, error) {
 func (_Lottery *LotteryTransactorSession) PickWinner() (*types.Transaction, error) {
 	return _Lottery.Contract.PickWinner(&_Lottery.TransactOpts)
 }
+
+// RefundExpiredRound is a paid mutator transaction binding the contract method 0xdef20daf.
+//
+// Solidity: function refundExpiredRound() returns()
+func (_Lottery *LotteryTransactor) RefundExpiredRound(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "refundExpiredRound")
+}
+
+// RefundExpiredRound is a paid mutator transaction binding the contract method 0xdef20daf.
+//
+// Solidity: function refundExpiredRound() returns()
+func (_Lottery *LotterySession) RefundExpiredRound() (*types.Transaction, error) {
+	return _Lottery.Contract.RefundExpiredRound(&_Lottery.TransactOpts)
+}
+
+// RefundExpiredRound is a paid mutator transaction binding the contract method 0xdef20daf.
+//
+// Solidity: function refundExpiredRound() returns()
+func (_Lottery *LotteryTransactorSession) RefundExpiredRound() (*types.Transaction, error) {
+	return _Lottery.Contract.RefundExpiredRound(&_Lottery.TransactOpts)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_Lottery *LotteryTransactor) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "revokeRole", role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_Lottery *LotterySession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.RevokeRole(&_Lottery.TransactOpts, role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_Lottery *LotteryTransactorSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.RevokeRole(&_Lottery.TransactOpts, role, account)
+}
+
+// SetFee is a paid mutator transaction binding the contract method 0xb4f2e8b8.
+//
+// Solidity: function setFee(uint256 _feeBasisPoints, address _treasury) returns()
+func (_Lottery *LotteryTransactor) SetFee(opts *bind.TransactOpts, _feeBasisPoints *big.Int, _treasury common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "setFee", _feeBasisPoints, _treasury)
+}
+
+// SetFee is a paid mutator transaction binding the contract method 0xb4f2e8b8.
+//
+// Solidity: function setFee(uint256 _feeBasisPoints, address _treasury) returns()
+func (_Lottery *LotterySession) SetFee(_feeBasisPoints *big.Int, _treasury common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.SetFee(&_Lottery.TransactOpts, _feeBasisPoints, _treasury)
+}
+
+// SetFee is a paid mutator transaction binding the contract method 0xb4f2e8b8.
+//
+// Solidity: function setFee(uint256 _feeBasisPoints, address _treasury) returns()
+func (_Lottery *LotteryTransactorSession) SetFee(_feeBasisPoints *big.Int, _treasury common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.SetFee(&_Lottery.TransactOpts, _feeBasisPoints, _treasury)
+}
+
+// SetPaymentToken is a paid mutator transaction binding the contract method 0xf56cc665.
+//
+// Solidity: function setPaymentToken(address token, uint256 _ticketPrice) returns()
+func (_Lottery *LotteryTransactor) SetPaymentToken(opts *bind.TransactOpts, token common.Address, _ticketPrice *big.Int) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "setPaymentToken", token, _ticketPrice)
+}
+
+// SetPaymentToken is a paid mutator transaction binding the contract method 0xf56cc665.
+//
+// Solidity: function setPaymentToken(address token, uint256 _ticketPrice) returns()
+func (_Lottery *LotterySession) SetPaymentToken(token common.Address, _ticketPrice *big.Int) (*types.Transaction, error) {
+	return _Lottery.Contract.SetPaymentToken(&_Lottery.TransactOpts, token, _ticketPrice)
+}
+
+// SetPaymentToken is a paid mutator transaction binding the contract method 0xf56cc665.
+//
+// Solidity: function setPaymentToken(address token, uint256 _ticketPrice) returns()
+func (_Lottery *LotteryTransactorSession) SetPaymentToken(token common.Address, _ticketPrice *big.Int) (*types.Transaction, error) {
+	return _Lottery.Contract.SetPaymentToken(&_Lottery.TransactOpts, token, _ticketPrice)
+}
+
+// SetRoundDuration is a paid mutator transaction binding the contract method 0x36c92c3f.
+//
+// Solidity: function setRoundDuration(uint256 _roundDuration) returns()
+func (_Lottery *LotteryTransactor) SetRoundDuration(opts *bind.TransactOpts, _roundDuration *big.Int) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "setRoundDuration", _roundDuration)
+}
+
+// SetRoundDuration is a paid mutator transaction binding the contract method 0x36c92c3f.
+//
+// Solidity: function setRoundDuration(uint256 _roundDuration) returns()
+func (_Lottery *LotterySession) SetRoundDuration(_roundDuration *big.Int) (*types.Transaction, error) {
+	return _Lottery.Contract.SetRoundDuration(&_Lottery.TransactOpts, _roundDuration)
+}
+
+// SetRoundDuration is a paid mutator transaction binding the contract method 0x36c92c3f.
+//
+// Solidity: function setRoundDuration(uint256 _roundDuration) returns()
+func (_Lottery *LotteryTransactorSession) SetRoundDuration(_roundDuration *big.Int) (*types.Transaction, error) {
+	return _Lottery.Contract.SetRoundDuration(&_Lottery.TransactOpts, _roundDuration)
+}
+
+// TransferManager is a paid mutator transaction binding the contract method 0xba0e930a.
+//
+// Solidity: function transferManager(address _pendingManager) returns()
+func (_Lottery *LotteryTransactor) TransferManager(opts *bind.TransactOpts, _pendingManager common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "transferManager", _pendingManager)
+}
+
+// TransferManager is a paid mutator transaction binding the contract method 0xba0e930a.
+//
+// Solidity: function transferManager(address _pendingManager) returns()
+func (_Lottery *LotterySession) TransferManager(_pendingManager common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.TransferManager(&_Lottery.TransactOpts, _pendingManager)
+}
+
+// TransferManager is a paid mutator transaction binding the contract method 0xba0e930a.
+//
+// Solidity: function transferManager(address _pendingManager) returns()
+func (_Lottery *LotteryTransactorSession) TransferManager(_pendingManager common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.TransferManager(&_Lottery.TransactOpts, _pendingManager)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_Lottery *LotteryTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_Lottery *LotterySession) Unpause() (*types.Transaction, error) {
+	return _Lottery.Contract.Unpause(&_Lottery.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_Lottery *LotteryTransactorSession) Unpause() (*types.Transaction, error) {
+	return _Lottery.Contract.Unpause(&_Lottery.TransactOpts)
+}
+
+// UpgradeTo is a paid mutator transaction binding the contract method 0x3659cfe6.
+//
+// Solidity: function upgradeTo(address newImplementation) returns()
+func (_Lottery *LotteryTransactor) UpgradeTo(opts *bind.TransactOpts, newImplementation common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "upgradeTo", newImplementation)
+}
+
+// UpgradeTo is a paid mutator transaction binding the contract method 0x3659cfe6.
+//
+// Solidity: function upgradeTo(address newImplementation) returns()
+func (_Lottery *LotterySession) UpgradeTo(newImplementation common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.UpgradeTo(&_Lottery.TransactOpts, newImplementation)
+}
+
+// UpgradeTo is a paid mutator transaction binding the contract method 0x3659cfe6.
+//
+// Solidity: function upgradeTo(address newImplementation) returns()
+func (_Lottery *LotteryTransactorSession) UpgradeTo(newImplementation common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.UpgradeTo(&_Lottery.TransactOpts, newImplementation)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_Lottery *LotteryTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_Lottery *LotterySession) Withdraw() (*types.Transaction, error) {
+	return _Lottery.Contract.Withdraw(&_Lottery.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_Lottery *LotteryTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _Lottery.Contract.Withdraw(&_Lottery.TransactOpts)
+}
+
+// WithdrawFees is a paid mutator transaction binding the contract method 0x476343ee.
+//
+// Solidity: function withdrawFees() returns()
+func (_Lottery *LotteryTransactor) WithdrawFees(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "withdrawFees")
+}
+
+// WithdrawFees is a paid mutator transaction binding the contract method 0x476343ee.
+//
+// Solidity: function withdrawFees() returns()
+func (_Lottery *LotterySession) WithdrawFees() (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawFees(&_Lottery.TransactOpts)
+}
+
+// WithdrawFees is a paid mutator transaction binding the contract method 0x476343ee.
+//
+// Solidity: function withdrawFees() returns()
+func (_Lottery *LotteryTransactorSession) WithdrawFees() (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawFees(&_Lottery.TransactOpts)
+}
+
+// WithdrawToken is a paid mutator transaction binding the contract method 0x89476069.
+//
+// Solidity: function withdrawToken(address token) returns()
+func (_Lottery *LotteryTransactor) WithdrawToken(opts *bind.TransactOpts, token common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "withdrawToken", token)
+}
+
+// WithdrawToken is a paid mutator transaction binding the contract method 0x89476069.
+//
+// Solidity: function withdrawToken(address token) returns()
+func (_Lottery *LotterySession) WithdrawToken(token common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawToken(&_Lottery.TransactOpts, token)
+}
+
+// WithdrawToken is a paid mutator transaction binding the contract method 0x89476069.
+//
+// Solidity: function withdrawToken(address token) returns()
+func (_Lottery *LotteryTransactorSession) WithdrawToken(token common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawToken(&_Lottery.TransactOpts, token)
+}
+
+// WithdrawTokenFees is a paid mutator transaction binding the contract method 0xf62722a0.
+//
+// Solidity: function withdrawTokenFees(address token) returns()
+func (_Lottery *LotteryTransactor) WithdrawTokenFees(opts *bind.TransactOpts, token common.Address) (*types.Transaction, error) {
+	return _Lottery.contract.Transact(opts, "withdrawTokenFees", token)
+}
+
+// WithdrawTokenFees is a paid mutator transaction binding the contract method 0xf62722a0.
+//
+// Solidity: function withdrawTokenFees(address token) returns()
+func (_Lottery *LotterySession) WithdrawTokenFees(token common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawTokenFees(&_Lottery.TransactOpts, token)
+}
+
+// WithdrawTokenFees is a paid mutator transaction binding the contract method 0xf62722a0.
+//
+// Solidity: function withdrawTokenFees(address token) returns()
+func (_Lottery *LotteryTransactorSession) WithdrawTokenFees(token common.Address) (*types.Transaction, error) {
+	return _Lottery.Contract.WithdrawTokenFees(&_Lottery.TransactOpts, token)
+}
+
+// LotteryFeeChangedIterator is returned from FilterFeeChanged and is used to iterate over the raw logs and unpacked data for FeeChanged events raised by the Lottery contract.
+type LotteryFeeChangedIterator struct {
+	Event *LotteryFeeChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryFeeChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryFeeChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryFeeChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryFeeChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryFeeChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryFeeChanged represents a FeeChanged event raised by the Lottery contract.
+type LotteryFeeChanged struct {
+	FeeBasisPoints *big.Int
+	Treasury       common.Address
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeeChanged is a free log retrieval operation binding the contract event 0xb2d190129c7f8c6952e604fc184b8aa257a6a1bcfc8d2b934242892af9f61cdc.
+//
+// Solidity: event FeeChanged(uint256 feeBasisPoints, address treasury)
+func (_Lottery *LotteryFilterer) FilterFeeChanged(opts *bind.FilterOpts) (*LotteryFeeChangedIterator, error) {
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "FeeChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryFeeChangedIterator{contract: _Lottery.contract, event: "FeeChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchFeeChanged is a free log subscription operation binding the contract event 0xb2d190129c7f8c6952e604fc184b8aa257a6a1bcfc8d2b934242892af9f61cdc.
+//
+// Solidity: event FeeChanged(uint256 feeBasisPoints, address treasury)
+func (_Lottery *LotteryFilterer) WatchFeeChanged(opts *bind.WatchOpts, sink chan<- *LotteryFeeChanged) (event.Subscription, error) {
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "FeeChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryFeeChanged)
+				if err := _Lottery.contract.UnpackLog(event, "FeeChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeeChanged is a log parse operation binding the contract event 0xb2d190129c7f8c6952e604fc184b8aa257a6a1bcfc8d2b934242892af9f61cdc.
+//
+// Solidity: event FeeChanged(uint256 feeBasisPoints, address treasury)
+func (_Lottery *LotteryFilterer) ParseFeeChanged(log types.Log) (*LotteryFeeChanged, error) {
+	event := new(LotteryFeeChanged)
+	if err := _Lottery.contract.UnpackLog(event, "FeeChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryFeesWithdrawnIterator is returned from FilterFeesWithdrawn and is used to iterate over the raw logs and unpacked data for FeesWithdrawn events raised by the Lottery contract.
+type LotteryFeesWithdrawnIterator struct {
+	Event *LotteryFeesWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryFeesWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryFeesWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryFeesWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryFeesWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryFeesWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryFeesWithdrawn represents a FeesWithdrawn event raised by the Lottery contract.
+type LotteryFeesWithdrawn struct {
+	Treasury common.Address
+	Amount   *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeesWithdrawn is a free log retrieval operation binding the contract event 0xc0819c13be868895eb93e40eaceb96de976442fa1d404e5c55f14bb65a8c489a.
+//
+// Solidity: event FeesWithdrawn(address indexed treasury, uint256 amount)
+func (_Lottery *LotteryFilterer) FilterFeesWithdrawn(opts *bind.FilterOpts, treasury []common.Address) (*LotteryFeesWithdrawnIterator, error) {
+
+	var treasuryRule []interface{}
+	for _, treasuryItem := range treasury {
+		treasuryRule = append(treasuryRule, treasuryItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "FeesWithdrawn", treasuryRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryFeesWithdrawnIterator{contract: _Lottery.contract, event: "FeesWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchFeesWithdrawn is a free log subscription operation binding the contract event 0xc0819c13be868895eb93e40eaceb96de976442fa1d404e5c55f14bb65a8c489a.
+//
+// Solidity: event FeesWithdrawn(address indexed treasury, uint256 amount)
+func (_Lottery *LotteryFilterer) WatchFeesWithdrawn(opts *bind.WatchOpts, sink chan<- *LotteryFeesWithdrawn, treasury []common.Address) (event.Subscription, error) {
+
+	var treasuryRule []interface{}
+	for _, treasuryItem := range treasury {
+		treasuryRule = append(treasuryRule, treasuryItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "FeesWithdrawn", treasuryRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryFeesWithdrawn)
+				if err := _Lottery.contract.UnpackLog(event, "FeesWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeesWithdrawn is a log parse operation binding the contract event 0xc0819c13be868895eb93e40eaceb96de976442fa1d404e5c55f14bb65a8c489a.
+//
+// Solidity: event FeesWithdrawn(address indexed treasury, uint256 amount)
+func (_Lottery *LotteryFilterer) ParseFeesWithdrawn(log types.Log) (*LotteryFeesWithdrawn, error) {
+	event := new(LotteryFeesWithdrawn)
+	if err := _Lottery.contract.UnpackLog(event, "FeesWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryManagerTransferProposedIterator is returned from FilterManagerTransferProposed and is used to iterate over the raw logs and unpacked data for ManagerTransferProposed events raised by the Lottery contract.
+type LotteryManagerTransferProposedIterator struct {
+	Event *LotteryManagerTransferProposed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryManagerTransferProposedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryManagerTransferProposed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryManagerTransferProposed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryManagerTransferProposedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryManagerTransferProposedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryManagerTransferProposed represents a ManagerTransferProposed event raised by the Lottery contract.
+type LotteryManagerTransferProposed struct {
+	Manager        common.Address
+	PendingManager common.Address
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterManagerTransferProposed is a free log retrieval operation binding the contract event 0xce60cba642b041029ec24fb21b6d5844c022b65c4c0ddd7b90495c9b286aa9ad.
+//
+// Solidity: event ManagerTransferProposed(address indexed manager, address indexed pendingManager)
+func (_Lottery *LotteryFilterer) FilterManagerTransferProposed(opts *bind.FilterOpts, manager []common.Address, pendingManager []common.Address) (*LotteryManagerTransferProposedIterator, error) {
+
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+	var pendingManagerRule []interface{}
+	for _, pendingManagerItem := range pendingManager {
+		pendingManagerRule = append(pendingManagerRule, pendingManagerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "ManagerTransferProposed", managerRule, pendingManagerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryManagerTransferProposedIterator{contract: _Lottery.contract, event: "ManagerTransferProposed", logs: logs, sub: sub}, nil
+}
+
+// WatchManagerTransferProposed is a free log subscription operation binding the contract event 0xce60cba642b041029ec24fb21b6d5844c022b65c4c0ddd7b90495c9b286aa9ad.
+//
+// Solidity: event ManagerTransferProposed(address indexed manager, address indexed pendingManager)
+func (_Lottery *LotteryFilterer) WatchManagerTransferProposed(opts *bind.WatchOpts, sink chan<- *LotteryManagerTransferProposed, manager []common.Address, pendingManager []common.Address) (event.Subscription, error) {
+
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+	var pendingManagerRule []interface{}
+	for _, pendingManagerItem := range pendingManager {
+		pendingManagerRule = append(pendingManagerRule, pendingManagerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "ManagerTransferProposed", managerRule, pendingManagerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryManagerTransferProposed)
+				if err := _Lottery.contract.UnpackLog(event, "ManagerTransferProposed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseManagerTransferProposed is a log parse operation binding the contract event 0xce60cba642b041029ec24fb21b6d5844c022b65c4c0ddd7b90495c9b286aa9ad.
+//
+// Solidity: event ManagerTransferProposed(address indexed manager, address indexed pendingManager)
+func (_Lottery *LotteryFilterer) ParseManagerTransferProposed(log types.Log) (*LotteryManagerTransferProposed, error) {
+	event := new(LotteryManagerTransferProposed)
+	if err := _Lottery.contract.UnpackLog(event, "ManagerTransferProposed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryManagerTransferredIterator is returned from FilterManagerTransferred and is used to iterate over the raw logs and unpacked data for ManagerTransferred events raised by the Lottery contract.
+type LotteryManagerTransferredIterator struct {
+	Event *LotteryManagerTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryManagerTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryManagerTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryManagerTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryManagerTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryManagerTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryManagerTransferred represents a ManagerTransferred event raised by the Lottery contract.
+type LotteryManagerTransferred struct {
+	PreviousManager common.Address
+	Manager         common.Address
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterManagerTransferred is a free log retrieval operation binding the contract event 0x9cb45c728de594dab506a1f1a8554e24c8eeaf983618d5ec5dd7bc6f3c49feee.
+//
+// Solidity: event ManagerTransferred(address indexed previousManager, address indexed manager)
+func (_Lottery *LotteryFilterer) FilterManagerTransferred(opts *bind.FilterOpts, previousManager []common.Address, manager []common.Address) (*LotteryManagerTransferredIterator, error) {
+
+	var previousManagerRule []interface{}
+	for _, previousManagerItem := range previousManager {
+		previousManagerRule = append(previousManagerRule, previousManagerItem)
+	}
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "ManagerTransferred", previousManagerRule, managerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryManagerTransferredIterator{contract: _Lottery.contract, event: "ManagerTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchManagerTransferred is a free log subscription operation binding the contract event 0x9cb45c728de594dab506a1f1a8554e24c8eeaf983618d5ec5dd7bc6f3c49feee.
+//
+// Solidity: event ManagerTransferred(address indexed previousManager, address indexed manager)
+func (_Lottery *LotteryFilterer) WatchManagerTransferred(opts *bind.WatchOpts, sink chan<- *LotteryManagerTransferred, previousManager []common.Address, manager []common.Address) (event.Subscription, error) {
+
+	var previousManagerRule []interface{}
+	for _, previousManagerItem := range previousManager {
+		previousManagerRule = append(previousManagerRule, previousManagerItem)
+	}
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "ManagerTransferred", previousManagerRule, managerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryManagerTransferred)
+				if err := _Lottery.contract.UnpackLog(event, "ManagerTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseManagerTransferred is a log parse operation binding the contract event 0x9cb45c728de594dab506a1f1a8554e24c8eeaf983618d5ec5dd7bc6f3c49feee.
+//
+// Solidity: event ManagerTransferred(address indexed previousManager, address indexed manager)
+func (_Lottery *LotteryFilterer) ParseManagerTransferred(log types.Log) (*LotteryManagerTransferred, error) {
+	event := new(LotteryManagerTransferred)
+	if err := _Lottery.contract.UnpackLog(event, "ManagerTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the Lottery contract.
+type LotteryPausedIterator struct {
+	Event *LotteryPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryPaused represents a Paused event raised by the Lottery contract.
+type LotteryPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_Lottery *LotteryFilterer) FilterPaused(opts *bind.FilterOpts) (*LotteryPausedIterator, error) {
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryPausedIterator{contract: _Lottery.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_Lottery *LotteryFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *LotteryPaused) (event.Subscription, error) {
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryPaused)
+				if err := _Lottery.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_Lottery *LotteryFilterer) ParsePaused(log types.Log) (*LotteryPaused, error) {
+	event := new(LotteryPaused)
+	if err := _Lottery.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryPaymentTokenChangedIterator is returned from FilterPaymentTokenChanged and is used to iterate over the raw logs and unpacked data for PaymentTokenChanged events raised by the Lottery contract.
+type LotteryPaymentTokenChangedIterator struct {
+	Event *LotteryPaymentTokenChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryPaymentTokenChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryPaymentTokenChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryPaymentTokenChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryPaymentTokenChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryPaymentTokenChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryPaymentTokenChanged represents a PaymentTokenChanged event raised by the Lottery contract.
+type LotteryPaymentTokenChanged struct {
+	Token       common.Address
+	TicketPrice *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaymentTokenChanged is a free log retrieval operation binding the contract event 0x6a9d944da705340e0428e58c1cce0ae39fa9d014d36003b32dd5fda731509c24.
+//
+// Solidity: event PaymentTokenChanged(address indexed token, uint256 ticketPrice)
+func (_Lottery *LotteryFilterer) FilterPaymentTokenChanged(opts *bind.FilterOpts, token []common.Address) (*LotteryPaymentTokenChangedIterator, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "PaymentTokenChanged", tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryPaymentTokenChangedIterator{contract: _Lottery.contract, event: "PaymentTokenChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchPaymentTokenChanged is a free log subscription operation binding the contract event 0x6a9d944da705340e0428e58c1cce0ae39fa9d014d36003b32dd5fda731509c24.
+//
+// Solidity: event PaymentTokenChanged(address indexed token, uint256 ticketPrice)
+func (_Lottery *LotteryFilterer) WatchPaymentTokenChanged(opts *bind.WatchOpts, sink chan<- *LotteryPaymentTokenChanged, token []common.Address) (event.Subscription, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "PaymentTokenChanged", tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryPaymentTokenChanged)
+				if err := _Lottery.contract.UnpackLog(event, "PaymentTokenChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaymentTokenChanged is a log parse operation binding the contract event 0x6a9d944da705340e0428e58c1cce0ae39fa9d014d36003b32dd5fda731509c24.
+//
+// Solidity: event PaymentTokenChanged(address indexed token, uint256 ticketPrice)
+func (_Lottery *LotteryFilterer) ParsePaymentTokenChanged(log types.Log) (*LotteryPaymentTokenChanged, error) {
+	event := new(LotteryPaymentTokenChanged)
+	if err := _Lottery.contract.UnpackLog(event, "PaymentTokenChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryPlayerEnteredIterator is returned from FilterPlayerEntered and is used to iterate over the raw logs and unpacked data for PlayerEntered events raised by the Lottery contract.
+type LotteryPlayerEnteredIterator struct {
+	Event *LotteryPlayerEntered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryPlayerEnteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryPlayerEntered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryPlayerEntered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryPlayerEnteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryPlayerEnteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryPlayerEntered represents a PlayerEntered event raised by the Lottery contract.
+type LotteryPlayerEntered struct {
+	Player common.Address
+	Stake  *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPlayerEntered is a free log retrieval operation binding the contract event 0xc16481c9484122696a660b57df0bf6839645c6b620cb1704ac2437de13be9225.
+//
+// Solidity: event PlayerEntered(address indexed player, uint256 stake)
+func (_Lottery *LotteryFilterer) FilterPlayerEntered(opts *bind.FilterOpts, player []common.Address) (*LotteryPlayerEnteredIterator, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "PlayerEntered", playerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryPlayerEnteredIterator{contract: _Lottery.contract, event: "PlayerEntered", logs: logs, sub: sub}, nil
+}
+
+// WatchPlayerEntered is a free log subscription operation binding the contract event 0xc16481c9484122696a660b57df0bf6839645c6b620cb1704ac2437de13be9225.
+//
+// Solidity: event PlayerEntered(address indexed player, uint256 stake)
+func (_Lottery *LotteryFilterer) WatchPlayerEntered(opts *bind.WatchOpts, sink chan<- *LotteryPlayerEntered, player []common.Address) (event.Subscription, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "PlayerEntered", playerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryPlayerEntered)
+				if err := _Lottery.contract.UnpackLog(event, "PlayerEntered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePlayerEntered is a log parse operation binding the contract event 0xc16481c9484122696a660b57df0bf6839645c6b620cb1704ac2437de13be9225.
+//
+// Solidity: event PlayerEntered(address indexed player, uint256 stake)
+func (_Lottery *LotteryFilterer) ParsePlayerEntered(log types.Log) (*LotteryPlayerEntered, error) {
+	event := new(LotteryPlayerEntered)
+	if err := _Lottery.contract.UnpackLog(event, "PlayerEntered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryRoleGrantedIterator is returned from FilterRoleGranted and is used to iterate over the raw logs and unpacked data for RoleGranted events raised by the Lottery contract.
+type LotteryRoleGrantedIterator struct {
+	Event *LotteryRoleGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryRoleGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryRoleGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryRoleGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryRoleGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryRoleGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryRoleGranted represents a RoleGranted event raised by the Lottery contract.
+type LotteryRoleGranted struct {
+	Role    [32]byte
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleGranted is a free log retrieval operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address) (*LotteryRoleGrantedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "RoleGranted", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryRoleGrantedIterator{contract: _Lottery.contract, event: "RoleGranted", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleGranted is a free log subscription operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *LotteryRoleGranted, role [][32]byte, account []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "RoleGranted", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryRoleGranted)
+				if err := _Lottery.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleGranted is a log parse operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) ParseRoleGranted(log types.Log) (*LotteryRoleGranted, error) {
+	event := new(LotteryRoleGranted)
+	if err := _Lottery.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryRoleRevokedIterator is returned from FilterRoleRevoked and is used to iterate over the raw logs and unpacked data for RoleRevoked events raised by the Lottery contract.
+type LotteryRoleRevokedIterator struct {
+	Event *LotteryRoleRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryRoleRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryRoleRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryRoleRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryRoleRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryRoleRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryRoleRevoked represents a RoleRevoked event raised by the Lottery contract.
+type LotteryRoleRevoked struct {
+	Role    [32]byte
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleRevoked is a free log retrieval operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address) (*LotteryRoleRevokedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "RoleRevoked", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryRoleRevokedIterator{contract: _Lottery.contract, event: "RoleRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleRevoked is a free log subscription operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *LotteryRoleRevoked, role [][32]byte, account []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "RoleRevoked", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryRoleRevoked)
+				if err := _Lottery.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleRevoked is a log parse operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Lottery *LotteryFilterer) ParseRoleRevoked(log types.Log) (*LotteryRoleRevoked, error) {
+	event := new(LotteryRoleRevoked)
+	if err := _Lottery.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryRoundCancelledIterator is returned from FilterRoundCancelled and is used to iterate over the raw logs and unpacked data for RoundCancelled events raised by the Lottery contract.
+type LotteryRoundCancelledIterator struct {
+	Event *LotteryRoundCancelled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryRoundCancelledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryRoundCancelled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryRoundCancelled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryRoundCancelledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryRoundCancelledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryRoundCancelled represents a RoundCancelled event raised by the Lottery contract.
+type LotteryRoundCancelled struct {
+	Refunded *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoundCancelled is a free log retrieval operation binding the contract event 0xbf7aeff89cf7a4c3d0145879e39aa6a19e8e64ed585090ef86bf31f4d2ae57bf.
+//
+// Solidity: event RoundCancelled(uint256 refunded)
+func (_Lottery *LotteryFilterer) FilterRoundCancelled(opts *bind.FilterOpts) (*LotteryRoundCancelledIterator, error) {
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "RoundCancelled")
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryRoundCancelledIterator{contract: _Lottery.contract, event: "RoundCancelled", logs: logs, sub: sub}, nil
+}
+
+// WatchRoundCancelled is a free log subscription operation binding the contract event 0xbf7aeff89cf7a4c3d0145879e39aa6a19e8e64ed585090ef86bf31f4d2ae57bf.
+//
+// Solidity: event RoundCancelled(uint256 refunded)
+func (_Lottery *LotteryFilterer) WatchRoundCancelled(opts *bind.WatchOpts, sink chan<- *LotteryRoundCancelled) (event.Subscription, error) {
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "RoundCancelled")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryRoundCancelled)
+				if err := _Lottery.contract.UnpackLog(event, "RoundCancelled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoundCancelled is a log parse operation binding the contract event 0xbf7aeff89cf7a4c3d0145879e39aa6a19e8e64ed585090ef86bf31f4d2ae57bf.
+//
+// Solidity: event RoundCancelled(uint256 refunded)
+func (_Lottery *LotteryFilterer) ParseRoundCancelled(log types.Log) (*LotteryRoundCancelled, error) {
+	event := new(LotteryRoundCancelled)
+	if err := _Lottery.contract.UnpackLog(event, "RoundCancelled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryRoundDurationChangedIterator is returned from FilterRoundDurationChanged and is used to iterate over the raw logs and unpacked data for RoundDurationChanged events raised by the Lottery contract.
+type LotteryRoundDurationChangedIterator struct {
+	Event *LotteryRoundDurationChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryRoundDurationChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryRoundDurationChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryRoundDurationChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryRoundDurationChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryRoundDurationChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryRoundDurationChanged represents a RoundDurationChanged event raised by the Lottery contract.
+type LotteryRoundDurationChanged struct {
+	RoundDuration *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoundDurationChanged is a free log retrieval operation binding the contract event 0x3afd47d65854f1a62e50ca90aa44232236db754d573813a23de85d26208e0a9c.
+//
+// Solidity: event RoundDurationChanged(uint256 roundDuration)
+func (_Lottery *LotteryFilterer) FilterRoundDurationChanged(opts *bind.FilterOpts) (*LotteryRoundDurationChangedIterator, error) {
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "RoundDurationChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryRoundDurationChangedIterator{contract: _Lottery.contract, event: "RoundDurationChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchRoundDurationChanged is a free log subscription operation binding the contract event 0x3afd47d65854f1a62e50ca90aa44232236db754d573813a23de85d26208e0a9c.
+//
+// Solidity: event RoundDurationChanged(uint256 roundDuration)
+func (_Lottery *LotteryFilterer) WatchRoundDurationChanged(opts *bind.WatchOpts, sink chan<- *LotteryRoundDurationChanged) (event.Subscription, error) {
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "RoundDurationChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryRoundDurationChanged)
+				if err := _Lottery.contract.UnpackLog(event, "RoundDurationChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoundDurationChanged is a log parse operation binding the contract event 0x3afd47d65854f1a62e50ca90aa44232236db754d573813a23de85d26208e0a9c.
+//
+// Solidity: event RoundDurationChanged(uint256 roundDuration)
+func (_Lottery *LotteryFilterer) ParseRoundDurationChanged(log types.Log) (*LotteryRoundDurationChanged, error) {
+	event := new(LotteryRoundDurationChanged)
+	if err := _Lottery.contract.UnpackLog(event, "RoundDurationChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryTokenWithdrawalIterator is returned from FilterTokenWithdrawal and is used to iterate over the raw logs and unpacked data for TokenWithdrawal events raised by the Lottery contract.
+type LotteryTokenWithdrawalIterator struct {
+	Event *LotteryTokenWithdrawal // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryTokenWithdrawalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryTokenWithdrawal)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryTokenWithdrawal)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryTokenWithdrawalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryTokenWithdrawalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryTokenWithdrawal represents a TokenWithdrawal event raised by the Lottery contract.
+type LotteryTokenWithdrawal struct {
+	Token  common.Address
+	Payee  common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokenWithdrawal is a free log retrieval operation binding the contract event 0x42856d0378dde02337bb59ae41747abc77ded8ebdbbc5cbdd1e53693b7554938.
+//
+// Solidity: event TokenWithdrawal(address indexed token, address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) FilterTokenWithdrawal(opts *bind.FilterOpts, token []common.Address, payee []common.Address) (*LotteryTokenWithdrawalIterator, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "TokenWithdrawal", tokenRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryTokenWithdrawalIterator{contract: _Lottery.contract, event: "TokenWithdrawal", logs: logs, sub: sub}, nil
+}
+
+// WatchTokenWithdrawal is a free log subscription operation binding the contract event 0x42856d0378dde02337bb59ae41747abc77ded8ebdbbc5cbdd1e53693b7554938.
+//
+// Solidity: event TokenWithdrawal(address indexed token, address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) WatchTokenWithdrawal(opts *bind.WatchOpts, sink chan<- *LotteryTokenWithdrawal, token []common.Address, payee []common.Address) (event.Subscription, error) {
+
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "TokenWithdrawal", tokenRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryTokenWithdrawal)
+				if err := _Lottery.contract.UnpackLog(event, "TokenWithdrawal", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokenWithdrawal is a log parse operation binding the contract event 0x42856d0378dde02337bb59ae41747abc77ded8ebdbbc5cbdd1e53693b7554938.
+//
+// Solidity: event TokenWithdrawal(address indexed token, address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) ParseTokenWithdrawal(log types.Log) (*LotteryTokenWithdrawal, error) {
+	event := new(LotteryTokenWithdrawal)
+	if err := _Lottery.contract.UnpackLog(event, "TokenWithdrawal", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the Lottery contract.
+type LotteryUnpausedIterator struct {
+	Event *LotteryUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryUnpaused represents a Unpaused event raised by the Lottery contract.
+type LotteryUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_Lottery *LotteryFilterer) FilterUnpaused(opts *bind.FilterOpts) (*LotteryUnpausedIterator, error) {
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryUnpausedIterator{contract: _Lottery.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_Lottery *LotteryFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *LotteryUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryUnpaused)
+				if err := _Lottery.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_Lottery *LotteryFilterer) ParseUnpaused(log types.Log) (*LotteryUnpaused, error) {
+	event := new(LotteryUnpaused)
+	if err := _Lottery.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryUpgradedIterator is returned from FilterUpgraded and is used to iterate over the raw logs and unpacked data for Upgraded events raised by the Lottery contract.
+type LotteryUpgradedIterator struct {
+	Event *LotteryUpgraded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryUpgradedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryUpgraded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryUpgraded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryUpgradedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryUpgradedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryUpgraded represents a Upgraded event raised by the Lottery contract.
+type LotteryUpgraded struct {
+	Implementation common.Address
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterUpgraded is a free log retrieval operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_Lottery *LotteryFilterer) FilterUpgraded(opts *bind.FilterOpts, implementation []common.Address) (*LotteryUpgradedIterator, error) {
+
+	var implementationRule []interface{}
+	for _, implementationItem := range implementation {
+		implementationRule = append(implementationRule, implementationItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "Upgraded", implementationRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryUpgradedIterator{contract: _Lottery.contract, event: "Upgraded", logs: logs, sub: sub}, nil
+}
+
+// WatchUpgraded is a free log subscription operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_Lottery *LotteryFilterer) WatchUpgraded(opts *bind.WatchOpts, sink chan<- *LotteryUpgraded, implementation []common.Address) (event.Subscription, error) {
+
+	var implementationRule []interface{}
+	for _, implementationItem := range implementation {
+		implementationRule = append(implementationRule, implementationItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "Upgraded", implementationRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryUpgraded)
+				if err := _Lottery.contract.UnpackLog(event, "Upgraded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUpgraded is a log parse operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_Lottery *LotteryFilterer) ParseUpgraded(log types.Log) (*LotteryUpgraded, error) {
+	event := new(LotteryUpgraded)
+	if err := _Lottery.contract.UnpackLog(event, "Upgraded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryWinnerPickedIterator is returned from FilterWinnerPicked and is used to iterate over the raw logs and unpacked data for WinnerPicked events raised by the Lottery contract.
+type LotteryWinnerPickedIterator struct {
+	Event *LotteryWinnerPicked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryWinnerPickedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryWinnerPicked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryWinnerPicked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryWinnerPickedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryWinnerPickedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryWinnerPicked represents a WinnerPicked event raised by the Lottery contract.
+type LotteryWinnerPicked struct {
+	Winner common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWinnerPicked is a free log retrieval operation binding the contract event 0x64791dbae5677392ba76761a5273633cec8f1d9d8cfe808da7bac6ef16a880be.
+//
+// Solidity: event WinnerPicked(address indexed winner, uint256 amount)
+func (_Lottery *LotteryFilterer) FilterWinnerPicked(opts *bind.FilterOpts, winner []common.Address) (*LotteryWinnerPickedIterator, error) {
+
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "WinnerPicked", winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryWinnerPickedIterator{contract: _Lottery.contract, event: "WinnerPicked", logs: logs, sub: sub}, nil
+}
+
+// WatchWinnerPicked is a free log subscription operation binding the contract event 0x64791dbae5677392ba76761a5273633cec8f1d9d8cfe808da7bac6ef16a880be.
+//
+// Solidity: event WinnerPicked(address indexed winner, uint256 amount)
+func (_Lottery *LotteryFilterer) WatchWinnerPicked(opts *bind.WatchOpts, sink chan<- *LotteryWinnerPicked, winner []common.Address) (event.Subscription, error) {
+
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "WinnerPicked", winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryWinnerPicked)
+				if err := _Lottery.contract.UnpackLog(event, "WinnerPicked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWinnerPicked is a log parse operation binding the contract event 0x64791dbae5677392ba76761a5273633cec8f1d9d8cfe808da7bac6ef16a880be.
+//
+// Solidity: event WinnerPicked(address indexed winner, uint256 amount)
+func (_Lottery *LotteryFilterer) ParseWinnerPicked(log types.Log) (*LotteryWinnerPicked, error) {
+	event := new(LotteryWinnerPicked)
+	if err := _Lottery.contract.UnpackLog(event, "WinnerPicked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LotteryWithdrawalIterator is returned from FilterWithdrawal and is used to iterate over the raw logs and unpacked data for Withdrawal events raised by the Lottery contract.
+type LotteryWithdrawalIterator struct {
+	Event *LotteryWithdrawal // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LotteryWithdrawalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LotteryWithdrawal)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LotteryWithdrawal)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LotteryWithdrawalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LotteryWithdrawalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LotteryWithdrawal represents a Withdrawal event raised by the Lottery contract.
+type LotteryWithdrawal struct {
+	Payee  common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawal is a free log retrieval operation binding the contract event 0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65.
+//
+// Solidity: event Withdrawal(address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) FilterWithdrawal(opts *bind.FilterOpts, payee []common.Address) (*LotteryWithdrawalIterator, error) {
+
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _Lottery.contract.FilterLogs(opts, "Withdrawal", payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LotteryWithdrawalIterator{contract: _Lottery.contract, event: "Withdrawal", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawal is a free log subscription operation binding the contract event 0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65.
+//
+// Solidity: event Withdrawal(address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) WatchWithdrawal(opts *bind.WatchOpts, sink chan<- *LotteryWithdrawal, payee []common.Address) (event.Subscription, error) {
+
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _Lottery.contract.WatchLogs(opts, "Withdrawal", payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LotteryWithdrawal)
+				if err := _Lottery.contract.UnpackLog(event, "Withdrawal", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawal is a log parse operation binding the contract event 0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65.
+//
+// Solidity: event Withdrawal(address indexed payee, uint256 amount)
+func (_Lottery *LotteryFilterer) ParseWithdrawal(log types.Log) (*LotteryWithdrawal, error) {
+	event := new(LotteryWithdrawal)
+	if err := _Lottery.contract.UnpackLog(event, "Withdrawal", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,49 @@
+package lotterypb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client is a LotteryService client that sends its API key with every
+// call, for the manager RPCs.
+type Client struct {
+	LotteryServiceClient
+	conn *grpc.ClientConn
+}
+
+// Dial connects to a 'fred-coin serve --grpc' server. The connection is
+// not encrypted, so put a TLS terminating proxy in front of servers
+// reached over the internet. Options are passed on to grpc.Dial, such as
+// a dialer for tests.
+func Dial(target string, apiKey string, options ...grpc.DialOption) (*Client, error) {
+	options = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withApiKey(ctx, apiKey), method, req, reply, cc, opts...)
+		}),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withApiKey(ctx, apiKey), desc, cc, method, opts...)
+		}),
+	}, options...)
+
+	conn, err := grpc.Dial(target, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{LotteryServiceClient: NewLotteryServiceClient(conn), conn: conn}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func withApiKey(ctx context.Context, apiKey string) context.Context {
+	if apiKey == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "x-api-key", apiKey)
+}
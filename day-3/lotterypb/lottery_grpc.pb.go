@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: lotterypb/lottery.proto
+
+package lotterypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LotteryService_GetState_FullMethodName    = "/fredcoin.lottery.v1.LotteryService/GetState"
+	LotteryService_ListPlayers_FullMethodName = "/fredcoin.lottery.v1.LotteryService/ListPlayers"
+	LotteryService_Enter_FullMethodName       = "/fredcoin.lottery.v1.LotteryService/Enter"
+	LotteryService_PickWinner_FullMethodName  = "/fredcoin.lottery.v1.LotteryService/PickWinner"
+	LotteryService_WatchEvents_FullMethodName = "/fredcoin.lottery.v1.LotteryService/WatchEvents"
+	LotteryService_GetRound_FullMethodName    = "/fredcoin.lottery.v1.LotteryService/GetRound"
+)
+
+// LotteryServiceClient is the client API for LotteryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LotteryServiceClient interface {
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*LotteryState, error)
+	ListPlayers(ctx context.Context, in *ListPlayersRequest, opts ...grpc.CallOption) (*ListPlayersResponse, error)
+	// Enter builds an unsigned entry for the caller's wallet to sign.
+	Enter(ctx context.Context, in *EnterRequest, opts ...grpc.CallOption) (*EnterResponse, error)
+	// PickWinner is signed by the server and needs the x-api-key metadata.
+	PickWinner(ctx context.Context, in *PickWinnerRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// WatchEvents streams decoded lottery events as blocks arrive.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (LotteryService_WatchEventsClient, error)
+	// GetRound returns an ended round from the indexer, or the open round.
+	GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*Round, error)
+}
+
+type lotteryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLotteryServiceClient(cc grpc.ClientConnInterface) LotteryServiceClient {
+	return &lotteryServiceClient{cc}
+}
+
+func (c *lotteryServiceClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*LotteryState, error) {
+	out := new(LotteryState)
+	err := c.cc.Invoke(ctx, LotteryService_GetState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryServiceClient) ListPlayers(ctx context.Context, in *ListPlayersRequest, opts ...grpc.CallOption) (*ListPlayersResponse, error) {
+	out := new(ListPlayersResponse)
+	err := c.cc.Invoke(ctx, LotteryService_ListPlayers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryServiceClient) Enter(ctx context.Context, in *EnterRequest, opts ...grpc.CallOption) (*EnterResponse, error) {
+	out := new(EnterResponse)
+	err := c.cc.Invoke(ctx, LotteryService_Enter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryServiceClient) PickWinner(ctx context.Context, in *PickWinnerRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, LotteryService_PickWinner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (LotteryService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LotteryService_ServiceDesc.Streams[0], LotteryService_WatchEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lotteryServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LotteryService_WatchEventsClient interface {
+	Recv() (*LotteryEvent, error)
+	grpc.ClientStream
+}
+
+type lotteryServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lotteryServiceWatchEventsClient) Recv() (*LotteryEvent, error) {
+	m := new(LotteryEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lotteryServiceClient) GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*Round, error) {
+	out := new(Round)
+	err := c.cc.Invoke(ctx, LotteryService_GetRound_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LotteryServiceServer is the server API for LotteryService service.
+// All implementations must embed UnimplementedLotteryServiceServer
+// for forward compatibility
+type LotteryServiceServer interface {
+	GetState(context.Context, *GetStateRequest) (*LotteryState, error)
+	ListPlayers(context.Context, *ListPlayersRequest) (*ListPlayersResponse, error)
+	// Enter builds an unsigned entry for the caller's wallet to sign.
+	Enter(context.Context, *EnterRequest) (*EnterResponse, error)
+	// PickWinner is signed by the server and needs the x-api-key metadata.
+	PickWinner(context.Context, *PickWinnerRequest) (*TransactionResponse, error)
+	// WatchEvents streams decoded lottery events as blocks arrive.
+	WatchEvents(*WatchEventsRequest, LotteryService_WatchEventsServer) error
+	// GetRound returns an ended round from the indexer, or the open round.
+	GetRound(context.Context, *GetRoundRequest) (*Round, error)
+	mustEmbedUnimplementedLotteryServiceServer()
+}
+
+// UnimplementedLotteryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLotteryServiceServer struct {
+}
+
+func (UnimplementedLotteryServiceServer) GetState(context.Context, *GetStateRequest) (*LotteryState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedLotteryServiceServer) ListPlayers(context.Context, *ListPlayersRequest) (*ListPlayersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlayers not implemented")
+}
+func (UnimplementedLotteryServiceServer) Enter(context.Context, *EnterRequest) (*EnterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enter not implemented")
+}
+func (UnimplementedLotteryServiceServer) PickWinner(context.Context, *PickWinnerRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PickWinner not implemented")
+}
+func (UnimplementedLotteryServiceServer) WatchEvents(*WatchEventsRequest, LotteryService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedLotteryServiceServer) GetRound(context.Context, *GetRoundRequest) (*Round, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRound not implemented")
+}
+func (UnimplementedLotteryServiceServer) mustEmbedUnimplementedLotteryServiceServer() {}
+
+// UnsafeLotteryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LotteryServiceServer will
+// result in compilation errors.
+type UnsafeLotteryServiceServer interface {
+	mustEmbedUnimplementedLotteryServiceServer()
+}
+
+func RegisterLotteryServiceServer(s grpc.ServiceRegistrar, srv LotteryServiceServer) {
+	s.RegisterService(&LotteryService_ServiceDesc, srv)
+}
+
+func _LotteryService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServiceServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryService_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServiceServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryService_ListPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServiceServer).ListPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryService_ListPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServiceServer).ListPlayers(ctx, req.(*ListPlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryService_Enter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServiceServer).Enter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryService_Enter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServiceServer).Enter(ctx, req.(*EnterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryService_PickWinner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickWinnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServiceServer).PickWinner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryService_PickWinner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServiceServer).PickWinner(ctx, req.(*PickWinnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LotteryServiceServer).WatchEvents(m, &lotteryServiceWatchEventsServer{stream})
+}
+
+type LotteryService_WatchEventsServer interface {
+	Send(*LotteryEvent) error
+	grpc.ServerStream
+}
+
+type lotteryServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lotteryServiceWatchEventsServer) Send(m *LotteryEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LotteryService_GetRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServiceServer).GetRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryService_GetRound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServiceServer).GetRound(ctx, req.(*GetRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LotteryService_ServiceDesc is the grpc.ServiceDesc for LotteryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LotteryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fredcoin.lottery.v1.LotteryService",
+	HandlerType: (*LotteryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetState",
+			Handler:    _LotteryService_GetState_Handler,
+		},
+		{
+			MethodName: "ListPlayers",
+			Handler:    _LotteryService_ListPlayers_Handler,
+		},
+		{
+			MethodName: "Enter",
+			Handler:    _LotteryService_Enter_Handler,
+		},
+		{
+			MethodName: "PickWinner",
+			Handler:    _LotteryService_PickWinner_Handler,
+		},
+		{
+			MethodName: "GetRound",
+			Handler:    _LotteryService_GetRound_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _LotteryService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lotterypb/lottery.proto",
+}